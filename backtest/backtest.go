@@ -0,0 +1,576 @@
+// Package backtest provides types and functions to replay candle series
+// through indicators and simulate trading rules on them.
+package backtest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidStrategy is returned when strategy has no entry or exit
+	// rule, or contains nil indicators.
+	ErrInvalidStrategy = errors.New("invalid strategy")
+
+	// ErrInvalidCapital is returned when initial capital is not
+	// positive.
+	ErrInvalidCapital = errors.New("invalid initial capital")
+
+	// ErrInvalidPeriods is returned when negative number of periods per
+	// year is provided.
+	ErrInvalidPeriods = errors.New("invalid number of periods per year")
+
+	// ErrInvalidCandles is returned when no candles are provided.
+	ErrInvalidCandles = errors.New("invalid candles")
+
+	// ErrInvalidPrice is returned when a position would be opened at
+	// a price that is not positive, e.g. a zero open price.
+	ErrInvalidPrice = errors.New("invalid fill price")
+)
+
+var (
+	// _hundred is 100 in decimal format.
+	_hundred = decimal.NewFromInt(100)
+)
+
+// Commission calculates the fee paid for a fill of the provided
+// notional value.
+type Commission func(notional decimal.Decimal) decimal.Decimal
+
+// PercentCommission creates new commission model that charges the
+// provided percent of every fill's notional value.
+func PercentCommission(pct decimal.Decimal) Commission {
+	return func(notional decimal.Decimal) decimal.Decimal {
+		return notional.Abs().Mul(pct).Div(_hundred)
+	}
+}
+
+// FixedCommission creates new commission model that charges the same
+// amount for every fill.
+func FixedCommission(amount decimal.Decimal) Commission {
+	return func(decimal.Decimal) decimal.Decimal {
+		return amount
+	}
+}
+
+// Slippage adjusts the price of a fill. Buy specifies whether the fill
+// buys or sells.
+type Slippage func(price decimal.Decimal, buy bool) decimal.Decimal
+
+// PercentSlippage creates new slippage model that moves the fill price
+// against the trader by the provided percent.
+func PercentSlippage(pct decimal.Decimal) Slippage {
+	return func(price decimal.Decimal, buy bool) decimal.Decimal {
+		diff := price.Mul(pct).Div(_hundred)
+		if buy {
+			return price.Add(diff)
+		}
+
+		return price.Sub(diff)
+	}
+}
+
+// FixedSlippage creates new slippage model that moves the fill price
+// against the trader by the provided amount.
+func FixedSlippage(amount decimal.Decimal) Slippage {
+	return func(price decimal.Decimal, buy bool) decimal.Decimal {
+		if buy {
+			return price.Add(amount)
+		}
+
+		return price.Sub(amount)
+	}
+}
+
+// Context holds the state of the replay at a single candle.
+type Context struct {
+	// index specifies the index of the current candle.
+	index int
+
+	// candles specifies all replayed candles.
+	candles []indc.Candle
+
+	// values specifies indicator values aligned with candles.
+	values map[string][]decimal.Decimal
+
+	// offsets specifies the index of the first candle that has a value
+	// of the indicator.
+	offsets map[string]int
+
+	// position specifies whether a position is open.
+	position bool
+}
+
+// Index returns the index of the current candle.
+func (ctx Context) Index() int {
+	return ctx.index
+}
+
+// Candle returns the candle that is back candles before the current
+// one. False is returned if such candle does not exist.
+func (ctx Context) Candle(back int) (indc.Candle, bool) {
+	i := ctx.index - back
+	if back < 0 || i < 0 {
+		return indc.Candle{}, false
+	}
+
+	return ctx.candles[i], true
+}
+
+// Value returns the value of the named indicator that is back candles
+// before the current one. False is returned if the indicator does not
+// exist or does not have enough data for that candle.
+func (ctx Context) Value(name string, back int) (decimal.Decimal, bool) {
+	vv, ok := ctx.values[name]
+	if !ok || back < 0 {
+		return decimal.Zero, false
+	}
+
+	i := ctx.index - back - ctx.offsets[name]
+	if i < 0 || i >= len(vv) {
+		return decimal.Zero, false
+	}
+
+	return vv[i], true
+}
+
+// InPosition returns true if a position is currently open.
+func (ctx Context) InPosition() bool {
+	return ctx.position
+}
+
+// Rule decides whether an action should be taken at the close of the
+// current candle.
+type Rule func(Context) bool
+
+// Strategy holds indicators and rules that drive the trading.
+type Strategy struct {
+	// Indicators specifies named indicators that are fed with close
+	// prices.
+	Indicators map[string]indc.Indicator
+
+	// Entry specifies when a position should be opened.
+	Entry Rule
+
+	// Exit specifies when an open position should be closed.
+	Exit Rule
+
+	// Direction specifies whether long (TrendUp) or short (TrendDown)
+	// positions are opened. Zero value means long.
+	Direction indc.Trend
+}
+
+// validate checks whether the strategy has valid properties.
+func (s Strategy) validate() error {
+	if s.Entry == nil || s.Exit == nil {
+		return ErrInvalidStrategy
+	}
+
+	for _, ind := range s.Indicators {
+		if ind == nil {
+			return ErrInvalidStrategy
+		}
+	}
+
+	if s.Direction != 0 {
+		return s.Direction.Validate()
+	}
+
+	return nil
+}
+
+// Trade holds information about a single closed position.
+type Trade struct {
+	// EntryTime specifies the time of the entry candle.
+	EntryTime time.Time `json:"entry_time"`
+
+	// ExitTime specifies the time of the exit candle.
+	ExitTime time.Time `json:"exit_time"`
+
+	// EntryIndex specifies the index of the entry candle.
+	EntryIndex int `json:"entry_index"`
+
+	// ExitIndex specifies the index of the exit candle.
+	ExitIndex int `json:"exit_index"`
+
+	// EntryPrice specifies the entry fill price.
+	EntryPrice decimal.Decimal `json:"entry_price"`
+
+	// ExitPrice specifies the exit fill price.
+	ExitPrice decimal.Decimal `json:"exit_price"`
+
+	// Quantity specifies the position size. It is negative for short
+	// positions.
+	Quantity decimal.Decimal `json:"quantity"`
+
+	// Fees specifies the commission paid for both fills.
+	Fees decimal.Decimal `json:"fees"`
+
+	// PnL specifies the profit or loss after fees.
+	PnL decimal.Decimal `json:"pnl"`
+
+	// Return specifies the profit or loss in percent of the entry
+	// notional value.
+	Return decimal.Decimal `json:"return"`
+}
+
+// Report holds the results of a backtest.
+type Report struct {
+	// Equity specifies the marked-to-market equity at the close of every
+	// candle.
+	Equity []decimal.Decimal `json:"equity"`
+
+	// Trades specifies all closed positions.
+	Trades []Trade `json:"trades"`
+
+	// Return specifies the total return in percent.
+	Return decimal.Decimal `json:"return"`
+
+	// WinRate specifies the percent of trades with positive PnL.
+	WinRate decimal.Decimal `json:"win_rate"`
+
+	// MaxDrawdown specifies the largest peak to trough equity decline in
+	// percent.
+	MaxDrawdown decimal.Decimal `json:"max_drawdown"`
+
+	// Sharpe specifies the annualized Sharpe ratio of per candle equity
+	// returns, assuming zero risk-free rate.
+	Sharpe decimal.Decimal `json:"sharpe"`
+}
+
+// Engine holds all the necessary information needed to run a backtest.
+// The zero value is not usable.
+type Engine struct {
+	// valid specifies whether Engine paremeters were validated.
+	valid bool
+
+	// strategy specifies indicators and rules.
+	strategy Strategy
+
+	// capital specifies the initial capital.
+	capital decimal.Decimal
+
+	// commission specifies the fee model. Nil means no fees.
+	commission Commission
+
+	// slippage specifies the fill price model. Nil means no slippage.
+	slippage Slippage
+
+	// periods specifies the number of candles per year used to annualize
+	// the Sharpe ratio.
+	periods int
+}
+
+// NewEngine validates provided configuration options and creates new
+// Engine. Nil commission or slippage disables the corresponding model.
+// If periods is zero, 252 (daily candles) is used.
+func NewEngine(s Strategy, capital decimal.Decimal, commission Commission, slippage Slippage, periods int) (Engine, error) {
+	if periods == 0 {
+		periods = 252
+	}
+
+	e := Engine{
+		strategy:   s,
+		capital:    capital,
+		commission: commission,
+		slippage:   slippage,
+		periods:    periods,
+	}
+
+	if err := e.validate(); err != nil {
+		return Engine{}, err
+	}
+
+	return e, nil
+}
+
+// validate checks whether the engine has valid configuration
+// properties.
+func (e *Engine) validate() error {
+	if err := e.strategy.validate(); err != nil {
+		return err
+	}
+
+	if !e.capital.IsPositive() {
+		return ErrInvalidCapital
+	}
+
+	if e.periods < 1 {
+		return ErrInvalidPeriods
+	}
+
+	e.valid = true
+
+	return nil
+}
+
+// Run replays the candles, oldest first. Rules are evaluated at the
+// close of every candle and the resulting orders are filled at the open
+// of the next candle. A position that is still open after the last
+// candle is closed at its close price.
+func (e Engine) Run(cc []indc.Candle) (Report, error) {
+	if !e.valid {
+		return Report{}, indc.ErrInvalidIndicator
+	}
+
+	if len(cc) == 0 {
+		return Report{}, ErrInvalidCandles
+	}
+
+	ctx, err := e.context(cc)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var (
+		rep     Report
+		cash    = e.capital
+		qty     decimal.Decimal
+		open    Trade
+		pending bool
+	)
+
+	rep.Equity = make([]decimal.Decimal, len(cc))
+
+	for i := range cc {
+		if pending {
+			if ctx.position {
+				rep.Trades = append(rep.Trades, e.exit(&cash, qty, open, cc[i].Open, i, cc[i].Time))
+				qty = decimal.Zero
+			} else {
+				open, qty, err = e.enter(&cash, cc[i].Open, i, cc[i].Time)
+				if err != nil {
+					return Report{}, err
+				}
+			}
+
+			ctx.position = !ctx.position
+			pending = false
+		}
+
+		rep.Equity[i] = cash.Add(qty.Mul(cc[i].Close))
+
+		if i == len(cc)-1 {
+			break
+		}
+
+		ctx.index = i
+
+		if ctx.position {
+			pending = e.strategy.Exit(ctx)
+		} else {
+			pending = e.strategy.Entry(ctx)
+		}
+	}
+
+	if ctx.position {
+		last := len(cc) - 1
+		rep.Trades = append(rep.Trades, e.exit(&cash, qty, open, cc[last].Close, last, cc[last].Time))
+		rep.Equity[last] = cash
+	}
+
+	e.stats(&rep)
+
+	return rep, nil
+}
+
+// context calculates indicator values and creates the replay context.
+func (e Engine) context(cc []indc.Candle) (Context, error) {
+	ctx := Context{
+		candles: cc,
+		values:  make(map[string][]decimal.Decimal, len(e.strategy.Indicators)),
+		offsets: make(map[string]int, len(e.strategy.Indicators)),
+	}
+
+	closes := indc.Closes(cc)
+
+	for name, ind := range e.strategy.Indicators {
+		ctx.offsets[name] = ind.Count() - 1
+
+		if len(closes) < ind.Count() {
+			ctx.values[name] = nil
+			continue
+		}
+
+		vv, err := indc.Series(ind, closes)
+		if err != nil {
+			return Context{}, err
+		}
+
+		ctx.values[name] = vv
+	}
+
+	return ctx, nil
+}
+
+// enter opens a position with all available cash.
+func (e Engine) enter(cash *decimal.Decimal, price decimal.Decimal, idx int, t time.Time) (Trade, decimal.Decimal, error) {
+	short := e.strategy.Direction == indc.TrendDown
+
+	price = e.fill(price, !short)
+	if !price.IsPositive() {
+		return Trade{}, decimal.Zero, fmt.Errorf("%w: %s at candle %d", ErrInvalidPrice, price, idx)
+	}
+
+	fee := e.fee(*cash)
+
+	qty := cash.Sub(fee).Div(price)
+	if short {
+		qty = qty.Neg()
+	}
+
+	*cash = cash.Sub(qty.Mul(price)).Sub(fee)
+
+	return Trade{
+		EntryTime:  t,
+		EntryIndex: idx,
+		EntryPrice: price,
+		Quantity:   qty,
+		Fees:       fee,
+	}, qty, nil
+}
+
+// exit closes the open position.
+func (e Engine) exit(cash *decimal.Decimal, qty decimal.Decimal, tr Trade, price decimal.Decimal, idx int, t time.Time) Trade {
+	price = e.fill(price, qty.IsNegative())
+	fee := e.fee(qty.Mul(price))
+
+	*cash = cash.Add(qty.Mul(price)).Sub(fee)
+
+	tr.ExitTime = t
+	tr.ExitIndex = idx
+	tr.ExitPrice = price
+	tr.Fees = tr.Fees.Add(fee)
+	tr.PnL = qty.Mul(price.Sub(tr.EntryPrice)).Sub(tr.Fees)
+
+	if notional := qty.Mul(tr.EntryPrice).Abs(); !notional.IsZero() {
+		tr.Return = tr.PnL.Div(notional).Mul(_hundred)
+	}
+
+	return tr
+}
+
+// fill returns the price adjusted by the slippage model.
+func (e Engine) fill(price decimal.Decimal, buy bool) decimal.Decimal {
+	if e.slippage == nil {
+		return price
+	}
+
+	return e.slippage(price, buy)
+}
+
+// fee returns the commission of the notional value.
+func (e Engine) fee(notional decimal.Decimal) decimal.Decimal {
+	if e.commission == nil {
+		return decimal.Zero
+	}
+
+	return e.commission(notional)
+}
+
+// stats calculates summary statistics of the report.
+func (e Engine) stats(rep *Report) {
+	last := rep.Equity[len(rep.Equity)-1]
+	rep.Return = last.Div(e.capital).Sub(decimal.NewFromInt(1)).Mul(_hundred)
+
+	if len(rep.Trades) > 0 {
+		wins := 0
+
+		for _, tr := range rep.Trades {
+			if tr.PnL.IsPositive() {
+				wins++
+			}
+		}
+
+		rep.WinRate = decimal.NewFromInt(int64(wins)).Div(decimal.NewFromInt(int64(len(rep.Trades)))).Mul(_hundred)
+	}
+
+	rep.MaxDrawdown = MaxDrawdown(rep.Equity)
+	rep.Sharpe = Sharpe(rep.Equity, e.periods)
+}
+
+// MaxDrawdown returns the largest peak to trough decline of the equity
+// curve in percent. It is calculated by indc.MaxDrawdown over the whole
+// curve. Zero is returned when the curve has less than two values.
+func MaxDrawdown(equity []decimal.Decimal) decimal.Decimal {
+	mdd, err := indc.NewMaxDrawdown(indc.SourcePrice, len(equity)-1)
+	if err != nil {
+		return decimal.Zero
+	}
+
+	res, err := mdd.Calc(equity)
+	if err != nil {
+		// unlikely to happen
+		return decimal.Zero
+	}
+
+	return res
+}
+
+// Sharpe returns the Sharpe ratio of the equity curve's per period
+// returns, annualized with the provided number of periods per year and
+// assuming zero risk-free rate. Returns that follow zero equity are
+// skipped and the rest are passed to indc.Sharpe. Zero is returned when
+// there are less than two returns or they do not vary.
+func Sharpe(equity []decimal.Decimal, periods int) decimal.Decimal {
+	var rr []decimal.Decimal
+
+	for i := 1; i < len(equity); i++ {
+		if equity[i-1].IsZero() {
+			continue
+		}
+
+		rr = append(rr, equity[i].Div(equity[i-1]).Sub(decimal.NewFromInt(1)))
+	}
+
+	s, err := indc.NewSharpe(indc.SourceReturn, len(rr), periods, decimal.Zero)
+	if err != nil {
+		return decimal.Zero
+	}
+
+	res, err := s.Calc(rr)
+	if err != nil {
+		// unlikely to happen
+		return decimal.Zero
+	}
+
+	return res
+}
+
+// WriteTradesCSV writes the report's trades as CSV data with a header
+// record.
+func (rep Report) WriteTradesCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{
+		"entry_time", "exit_time", "entry_price", "exit_price",
+		"quantity", "fees", "pnl", "return",
+	}); err != nil {
+		return err
+	}
+
+	for _, tr := range rep.Trades {
+		if err := cw.Write([]string{
+			tr.EntryTime.Format(time.RFC3339),
+			tr.ExitTime.Format(time.RFC3339),
+			tr.EntryPrice.String(),
+			tr.ExitPrice.String(),
+			tr.Quantity.String(),
+			tr.Fees.String(),
+			tr.PnL.String(),
+			tr.Return.String(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,443 @@
+package backtest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		if exp == assert.AnError { //nolint:goerr113 // direct check is needed
+			assert.Error(t, err)
+			return
+		}
+
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+// candles creates candles with equal open and close prices.
+func candles(pp ...int64) []indc.Candle {
+	cc := make([]indc.Candle, len(pp))
+
+	for i, p := range pp {
+		cc[i] = indc.Candle{
+			Time:  time.Date(2021, 1, i+1, 0, 0, 0, 0, time.UTC),
+			Open:  decimal.NewFromInt(p),
+			High:  decimal.NewFromInt(p),
+			Low:   decimal.NewFromInt(p),
+			Close: decimal.NewFromInt(p),
+		}
+	}
+
+	return cc
+}
+
+// at creates rule that triggers at the provided candle index.
+func at(idx int) Rule {
+	return func(ctx Context) bool {
+		return ctx.Index() == idx
+	}
+}
+
+// strs converts decimals to strings.
+func strs(dd []decimal.Decimal) []string {
+	ss := make([]string, len(dd))
+	for i := range dd {
+		ss[i] = dd[i].String()
+	}
+
+	return ss
+}
+
+func Test_Commission(t *testing.T) {
+	assert.Equal(t, "1.5", PercentCommission(decimal.NewFromInt(1))(decimal.NewFromInt(-150)).String())
+	assert.Equal(t, "2", FixedCommission(decimal.NewFromInt(2))(decimal.NewFromInt(150)).String())
+}
+
+func Test_Slippage(t *testing.T) {
+	pct := PercentSlippage(decimal.NewFromInt(1))
+	assert.Equal(t, "101", pct(decimal.NewFromInt(100), true).String())
+	assert.Equal(t, "99", pct(decimal.NewFromInt(100), false).String())
+
+	fixed := FixedSlippage(decimal.NewFromInt(2))
+	assert.Equal(t, "102", fixed(decimal.NewFromInt(100), true).String())
+	assert.Equal(t, "98", fixed(decimal.NewFromInt(100), false).String())
+}
+
+func Test_Context(t *testing.T) {
+	e, err := NewEngine(Strategy{
+		Indicators: map[string]indc.Indicator{
+			"sma": indc.SMA{},
+		},
+		Entry: at(0),
+		Exit:  at(0),
+	}, decimal.NewFromInt(100), nil, nil, 0)
+	require.NoError(t, err)
+
+	_, err = e.context(candles(1, 2))
+	assertEqualError(t, assert.AnError, err)
+
+	sma, err := indc.NewSMA(2)
+	require.NoError(t, err)
+
+	long, err := indc.NewSMA(5)
+	require.NoError(t, err)
+
+	e.strategy.Indicators = map[string]indc.Indicator{
+		"sma":  sma,
+		"long": long,
+	}
+
+	ctx, err := e.context(candles(1, 3, 5))
+	require.NoError(t, err)
+
+	ctx.index = 2
+
+	v, ok := ctx.Value("sma", 0)
+	assert.True(t, ok)
+	assert.Equal(t, "4", v.String())
+
+	v, ok = ctx.Value("sma", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "2", v.String())
+
+	_, ok = ctx.Value("sma", 2)
+	assert.False(t, ok)
+
+	_, ok = ctx.Value("sma", -1)
+	assert.False(t, ok)
+
+	_, ok = ctx.Value("long", 0)
+	assert.False(t, ok)
+
+	_, ok = ctx.Value("ema", 0)
+	assert.False(t, ok)
+
+	c, ok := ctx.Candle(2)
+	assert.True(t, ok)
+	assert.Equal(t, "1", c.Close.String())
+
+	_, ok = ctx.Candle(3)
+	assert.False(t, ok)
+
+	assert.False(t, ctx.InPosition())
+}
+
+func Test_NewEngine(t *testing.T) {
+	strategy := Strategy{
+		Entry: at(0),
+		Exit:  at(1),
+	}
+
+	cc := map[string]struct {
+		Strategy Strategy
+		Capital  decimal.Decimal
+		Periods  int
+		Error    error
+	}{
+		"Missing rules": {
+			Capital: decimal.NewFromInt(100),
+			Error:   ErrInvalidStrategy,
+		},
+		"Nil indicator": {
+			Strategy: Strategy{
+				Indicators: map[string]indc.Indicator{"sma": nil},
+				Entry:      at(0),
+				Exit:       at(1),
+			},
+			Capital: decimal.NewFromInt(100),
+			Error:   ErrInvalidStrategy,
+		},
+		"Invalid direction": {
+			Strategy: Strategy{
+				Entry:     at(0),
+				Exit:      at(1),
+				Direction: 70,
+			},
+			Capital: decimal.NewFromInt(100),
+			Error:   indc.ErrInvalidTrend,
+		},
+		"Invalid capital": {
+			Strategy: strategy,
+			Error:    ErrInvalidCapital,
+		},
+		"Invalid periods": {
+			Strategy: strategy,
+			Capital:  decimal.NewFromInt(100),
+			Periods:  -1,
+			Error:    ErrInvalidPeriods,
+		},
+		"Successfully created new Engine": {
+			Strategy: strategy,
+			Capital:  decimal.NewFromInt(100),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewEngine(c.Strategy, c.Capital, nil, nil, c.Periods)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, 252, res.periods)
+		})
+	}
+}
+
+func Test_Engine_Run(t *testing.T) {
+	cc := map[string]struct {
+		Strategy   Strategy
+		Commission Commission
+		Slippage   Slippage
+		Candles    []indc.Candle
+		Equity     []string
+		Trades     []Trade
+		Return     string
+		WinRate    string
+		Error      error
+	}{
+		"Invalid candles": {
+			Strategy: Strategy{Entry: at(0), Exit: at(2)},
+			Error:    ErrInvalidCandles,
+		},
+		"Invalid fill price": {
+			Strategy: Strategy{Entry: at(0), Exit: at(2)},
+			Candles:  candles(10, 0, 20),
+			Error:    fmt.Errorf("%w: 0 at candle 1", ErrInvalidPrice),
+		},
+		"Successful long trade": {
+			Strategy: Strategy{Entry: at(0), Exit: at(2)},
+			Candles:  candles(10, 10, 20, 20, 10),
+			Equity:   []string{"100", "100", "200", "200", "200"},
+			Trades: []Trade{
+				{
+					EntryIndex: 1,
+					ExitIndex:  3,
+					EntryPrice: decimal.NewFromInt(10),
+					ExitPrice:  decimal.NewFromInt(20),
+					Quantity:   decimal.NewFromInt(10),
+					Fees:       decimal.Zero,
+					PnL:        decimal.NewFromInt(100),
+					Return:     decimal.NewFromInt(100),
+				},
+			},
+			Return:  "100",
+			WinRate: "100",
+		},
+		"Successful long trade with commission": {
+			Strategy:   Strategy{Entry: at(0), Exit: at(2)},
+			Commission: PercentCommission(decimal.NewFromInt(1)),
+			Candles:    candles(10, 10, 20, 20),
+			Equity:     []string{"100", "99", "198", "196.02"},
+			Trades: []Trade{
+				{
+					EntryIndex: 1,
+					ExitIndex:  3,
+					EntryPrice: decimal.NewFromInt(10),
+					ExitPrice:  decimal.NewFromInt(20),
+					Quantity:   decimal.RequireFromString("9.9"),
+					Fees:       decimal.RequireFromString("2.98"),
+					PnL:        decimal.RequireFromString("96.02"),
+					Return:     decimal.RequireFromString("96.98989898989899"),
+				},
+			},
+			Return:  "96.02",
+			WinRate: "100",
+		},
+		"Successful short trade with slippage": {
+			Strategy: Strategy{Entry: at(0), Exit: at(2), Direction: indc.TrendDown},
+			Slippage: FixedSlippage(decimal.NewFromInt(1)),
+			Candles:  candles(11, 11, 6, 6),
+			Equity:   []string{"100", "90", "140", "130"},
+			Trades: []Trade{
+				{
+					EntryIndex: 1,
+					ExitIndex:  3,
+					EntryPrice: decimal.NewFromInt(10),
+					ExitPrice:  decimal.NewFromInt(7),
+					Quantity:   decimal.NewFromInt(-10),
+					Fees:       decimal.Zero,
+					PnL:        decimal.NewFromInt(30),
+					Return:     decimal.NewFromInt(30),
+				},
+			},
+			Return:  "30",
+			WinRate: "100",
+		},
+		"Open position is closed at the last close": {
+			Strategy: Strategy{Entry: at(0), Exit: at(10)},
+			Candles:  candles(10, 10, 5),
+			Equity:   []string{"100", "100", "50"},
+			Trades: []Trade{
+				{
+					EntryIndex: 1,
+					ExitIndex:  2,
+					EntryPrice: decimal.NewFromInt(10),
+					ExitPrice:  decimal.NewFromInt(5),
+					Quantity:   decimal.NewFromInt(10),
+					Fees:       decimal.Zero,
+					PnL:        decimal.NewFromInt(-50),
+					Return:     decimal.NewFromInt(-50),
+				},
+			},
+			Return:  "-50",
+			WinRate: "0",
+		},
+		"Signal on the last candle is ignored": {
+			Strategy: Strategy{Entry: at(2), Exit: at(10)},
+			Candles:  candles(10, 10, 5),
+			Equity:   []string{"100", "100", "100"},
+			Return:   "0",
+			WinRate:  "0",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := NewEngine(c.Strategy, decimal.NewFromInt(100), c.Commission, c.Slippage, 0)
+			require.NoError(t, err)
+
+			res, err := e.Run(c.Candles)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Equity, strs(res.Equity))
+			assert.Equal(t, c.Return, res.Return.String())
+			assert.Equal(t, c.WinRate, res.WinRate.String())
+			require.Len(t, res.Trades, len(c.Trades))
+
+			for i, tr := range res.Trades {
+				exp := c.Trades[i]
+				assert.Equal(t, exp.EntryIndex, tr.EntryIndex)
+				assert.Equal(t, exp.ExitIndex, tr.ExitIndex)
+				assert.True(t, c.Candles[exp.EntryIndex].Time.Equal(tr.EntryTime))
+				assert.True(t, c.Candles[exp.ExitIndex].Time.Equal(tr.ExitTime))
+				assert.Equal(t, exp.EntryPrice.String(), tr.EntryPrice.String())
+				assert.Equal(t, exp.ExitPrice.String(), tr.ExitPrice.String())
+				assert.Equal(t, exp.Quantity.String(), tr.Quantity.String())
+				assert.Equal(t, exp.Fees.String(), tr.Fees.String())
+				assert.Equal(t, exp.PnL.String(), tr.PnL.String())
+				assert.Equal(t, exp.Return.String(), tr.Return.String())
+			}
+		})
+	}
+
+	_, err := Engine{}.Run(candles(1))
+	assertEqualError(t, indc.ErrInvalidIndicator, err)
+}
+
+func Test_Engine_Run_CSV(t *testing.T) {
+	cc, err := indc.ReadCandlesCSV(strings.NewReader(`date,open,high,low,close
+2021-01-01,10,11,9,10
+2021-01-02,10,12,10,12
+2021-01-03,12,15,12,14
+2021-01-04,14,14,11,11
+2021-01-05,11,12,9,12
+2021-01-06,11,12,10,12
+`))
+	require.NoError(t, err)
+
+	sma, err := indc.NewSMA(2)
+	require.NoError(t, err)
+
+	e, err := NewEngine(Strategy{
+		Indicators: map[string]indc.Indicator{"sma": sma},
+		Entry: func(ctx Context) bool {
+			v, ok := ctx.Value("sma", 0)
+			c, _ := ctx.Candle(0)
+
+			return ok && c.Close.GreaterThan(v)
+		},
+		Exit: func(ctx Context) bool {
+			v, _ := ctx.Value("sma", 0)
+			c, _ := ctx.Candle(0)
+
+			return c.Close.LessThan(v)
+		},
+	}, decimal.NewFromInt(1200), nil, nil, 0)
+	require.NoError(t, err)
+
+	res, err := e.Run(cc)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1200", "1200", "1400", "1100", "1100", "1200"}, strs(res.Equity))
+	require.Len(t, res.Trades, 2)
+	assert.Equal(t, "-100", res.Trades[0].PnL.String())
+	assert.Equal(t, "100", res.Trades[1].PnL.String())
+	assert.Equal(t, "50", res.WinRate.String())
+
+	var buf bytes.Buffer
+
+	require.NoError(t, res.WriteTradesCSV(&buf))
+	assert.Equal(t, "entry_time,exit_time,entry_price,exit_price,quantity,fees,pnl,return\n"+
+		"2021-01-03T00:00:00Z,2021-01-05T00:00:00Z,12,11,100,0,-100,-8.33333333333333\n"+
+		"2021-01-06T00:00:00Z,2021-01-06T00:00:00Z,11,12,100,0,100,9.09090909090909\n", buf.String())
+}
+
+func Test_MaxDrawdown(t *testing.T) {
+	assert.Equal(t, "50", MaxDrawdown([]decimal.Decimal{
+		decimal.NewFromInt(100),
+		decimal.NewFromInt(120),
+		decimal.NewFromInt(60),
+		decimal.NewFromInt(130),
+		decimal.NewFromInt(110),
+	}).String())
+	assert.True(t, MaxDrawdown(nil).IsZero())
+	assert.True(t, MaxDrawdown([]decimal.Decimal{decimal.NewFromInt(100)}).IsZero())
+}
+
+func Test_Sharpe(t *testing.T) {
+	assert.True(t, Sharpe([]decimal.Decimal{
+		decimal.NewFromInt(100),
+		decimal.NewFromInt(100),
+		decimal.NewFromInt(100),
+	}, 252).IsZero())
+	assert.True(t, Sharpe([]decimal.Decimal{
+		decimal.NewFromInt(100),
+		decimal.NewFromInt(110),
+	}, 252).IsZero())
+
+	res := Sharpe([]decimal.Decimal{
+		decimal.NewFromInt(100),
+		decimal.NewFromInt(110),
+		decimal.NewFromInt(105),
+		decimal.NewFromInt(120),
+	}, 1)
+	assert.Equal(t, "0.6666", res.Round(4).String())
+
+	res = Sharpe([]decimal.Decimal{
+		decimal.NewFromInt(100),
+		decimal.Zero,
+		decimal.NewFromInt(110),
+		decimal.NewFromInt(121),
+	}, 1)
+	assert.Equal(t, "-0.5785", res.Round(4).String())
+}
@@ -0,0 +1,362 @@
+package indc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidSize is returned when box, range or reversal size is not
+	// positive.
+	ErrInvalidSize = errors.New("invalid size")
+)
+
+// HeikinAshi converts candles into Heikin-Ashi candles. Close is the
+// average of all prices, open is the midpoint of the previous
+// Heikin-Ashi candle body and high and low include both of them.
+// Time and volume are left untouched.
+func HeikinAshi(cc []Candle) []Candle {
+	res := make([]Candle, len(cc))
+
+	for i, c := range cc {
+		ha := Candle{
+			Time:   c.Time,
+			Close:  c.Open.Add(c.High).Add(c.Low).Add(c.Close).Div(decimal.NewFromInt(4)),
+			Volume: c.Volume,
+		}
+
+		if i == 0 {
+			ha.Open = c.Open.Add(c.Close).Div(decimal.NewFromInt(2))
+		} else {
+			ha.Open = res[i-1].Open.Add(res[i-1].Close).Div(decimal.NewFromInt(2))
+		}
+
+		ha.High = decimal.Max(c.High, ha.Open, ha.Close)
+		ha.Low = decimal.Min(c.Low, ha.Open, ha.Close)
+
+		res[i] = ha
+	}
+
+	return res
+}
+
+// Renko converts close prices of candles into Renko bricks of the fixed
+// box size. A brick in the direction of the previous one is added when
+// the price moves a box beyond it, a reversal brick requires a move of
+// two boxes. Every brick is timestamped with the candle that completed
+// it and the volume traded since the previous brick is attributed to
+// the first brick completed by a candle. Incomplete bricks are not
+// returned.
+func Renko(cc []Candle, box decimal.Decimal) ([]Candle, error) {
+	if !box.IsPositive() {
+		return nil, &ValidationError{
+			Indicator:  "renko",
+			Param:      "box size",
+			Value:      box,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidSize,
+		}
+	}
+
+	if len(cc) == 0 {
+		return nil, nil
+	}
+
+	var (
+		res    []Candle
+		lo, hi = cc[0].Close, cc[0].Close
+		vol    decimal.Decimal
+	)
+
+	for _, c := range cc {
+		vol = vol.Add(c.Volume)
+
+		for {
+			open, close, ok := brick(c.Close, lo, hi, box)
+			if !ok {
+				break
+			}
+
+			lo, hi = decimal.Min(open, close), decimal.Max(open, close)
+			res = append(res, column(c.Time, open, close, vol))
+			vol = decimal.Zero
+		}
+	}
+
+	return res, nil
+}
+
+// brick returns the next Renko brick formed by the price, if any. Lo
+// and hi specify the bounds of the previous brick.
+func brick(p, lo, hi, box decimal.Decimal) (decimal.Decimal, decimal.Decimal, bool) {
+	switch {
+	case p.GreaterThanOrEqual(hi.Add(box)):
+		return hi, hi.Add(box), true
+	case p.LessThanOrEqual(lo.Sub(box)):
+		return lo, lo.Sub(box), true
+	default:
+		return decimal.Zero, decimal.Zero, false
+	}
+}
+
+// RenkoATR converts close prices of candles into Renko bricks with the
+// box size equal to the average true range of the newest length
+// candles. See Renko for details.
+func RenkoATR(cc []Candle, length int) ([]Candle, error) {
+	box, err := atr(cc, length)
+	if err != nil {
+		return nil, relabel(err, "renko")
+	}
+
+	return Renko(cc, box)
+}
+
+// atr calculates the simple average of true ranges of the newest length
+// candles.
+func atr(cc []Candle, length int) (decimal.Decimal, error) {
+	if length < 1 {
+		return decimal.Zero, &ValidationError{
+			Param:      "length",
+			Value:      length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	if len(cc) < length {
+		return decimal.Zero, &DataSizeError{Expected: length, Actual: len(cc)}
+	}
+
+	sum := decimal.Zero
+
+	for i := len(cc) - length; i < len(cc); i++ {
+		tr := cc[i].High.Sub(cc[i].Low)
+
+		if i > 0 {
+			tr = decimal.Max(tr, cc[i].High.Sub(cc[i-1].Close).Abs(), cc[i].Low.Sub(cc[i-1].Close).Abs())
+		}
+
+		sum = sum.Add(tr)
+	}
+
+	return sum.Div(decimal.NewFromInt(int64(length))), nil
+}
+
+// RangeBars converts candles into bars that span exactly the provided
+// price range from their low to their high. Prices of every candle are
+// visited in the order open, the extreme closer to the open, the other
+// extreme and close. A bar is closed at its range boundary and the next
+// bar opens at the same price. Every bar is timestamped with the candle
+// during which it opened and the volume of a candle is attributed to the
+// bar that is active at its close. The last, still forming bar is not
+// returned.
+func RangeBars(cc []Candle, size decimal.Decimal) ([]Candle, error) {
+	if !size.IsPositive() {
+		return nil, &ValidationError{
+			Indicator:  "range",
+			Param:      "size",
+			Value:      size,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidSize,
+		}
+	}
+
+	if len(cc) == 0 {
+		return nil, nil
+	}
+
+	var res []Candle
+
+	bar := column(cc[0].Time, cc[0].Open, cc[0].Open, decimal.Zero)
+
+	for _, c := range cc {
+		for _, p := range path(c) {
+			for {
+				boundary, ok := rangeBoundary(bar, p, size)
+				if !ok {
+					break
+				}
+
+				bar.High = decimal.Max(bar.High, boundary)
+				bar.Low = decimal.Min(bar.Low, boundary)
+				bar.Close = boundary
+				res = append(res, bar)
+
+				bar = column(c.Time, boundary, boundary, decimal.Zero)
+			}
+
+			bar.High = decimal.Max(bar.High, p)
+			bar.Low = decimal.Min(bar.Low, p)
+			bar.Close = p
+		}
+
+		bar.Volume = bar.Volume.Add(c.Volume)
+	}
+
+	return res, nil
+}
+
+// rangeBoundary returns the price at which the range bar closes when
+// the price is reached, if any.
+func rangeBoundary(bar Candle, p, size decimal.Decimal) (decimal.Decimal, bool) {
+	switch {
+	case p.GreaterThan(bar.Low.Add(size)):
+		return bar.Low.Add(size), true
+	case p.LessThan(bar.High.Sub(size)):
+		return bar.High.Sub(size), true
+	default:
+		return decimal.Zero, false
+	}
+}
+
+// path returns the assumed sequence of prices within the candle.
+func path(c Candle) []decimal.Decimal {
+	if c.High.Sub(c.Open).LessThan(c.Open.Sub(c.Low)) {
+		return []decimal.Decimal{c.Open, c.High, c.Low, c.Close}
+	}
+
+	return []decimal.Decimal{c.Open, c.Low, c.High, c.Close}
+}
+
+// Kagi converts close prices of candles into Kagi lines. A line is
+// extended while the price moves in its direction and a new line is
+// started from its end once the price reverses by at least the reversal
+// amount. Every line is returned as a candle that opens at its start and
+// closes at its end, timestamped with the candle that started it and
+// holding the volume traded while it was drawn. The last line is
+// returned as well, even though it may still be extended.
+func Kagi(cc []Candle, reversal decimal.Decimal) ([]Candle, error) {
+	if !reversal.IsPositive() {
+		return nil, &ValidationError{
+			Indicator:  "kagi",
+			Param:      "reversal",
+			Value:      reversal,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidSize,
+		}
+	}
+
+	if len(cc) == 0 {
+		return nil, nil
+	}
+
+	var (
+		res        []Candle
+		t          = cc[0].Time
+		start, end = cc[0].Close, cc[0].Close
+		dir        int
+		vol        decimal.Decimal
+	)
+
+	for _, c := range cc {
+		p := c.Close
+
+		switch {
+		case dir == 0 && p.Sub(start).Abs().GreaterThanOrEqual(reversal):
+			dir, end = p.Sub(start).Sign(), p
+		case dir > 0 && p.GreaterThan(end), dir < 0 && p.LessThan(end):
+			end = p
+		case dir > 0 && end.Sub(p).GreaterThanOrEqual(reversal),
+			dir < 0 && p.Sub(end).GreaterThanOrEqual(reversal):
+			res = append(res, column(t, start, end, vol))
+			t, start, end, dir, vol = c.Time, end, p, -dir, decimal.Zero
+		}
+
+		vol = vol.Add(c.Volume)
+	}
+
+	if dir == 0 {
+		return res, nil
+	}
+
+	return append(res, column(t, start, end, vol)), nil
+}
+
+// PointFigure converts close prices of candles into Point and Figure
+// columns of the box size. A column of Xs is extended while the price
+// fills new boxes above it and a column of Os while it fills new boxes
+// below it; a new column is started one box away from the previous one
+// once the price reverses by the reversal amount of boxes. Every column
+// is returned as a candle that opens at its first box and closes at its
+// last box, timestamped with the candle that started it and holding the
+// volume traded while it was drawn. The last column is returned as well,
+// even though it may still be extended.
+func PointFigure(cc []Candle, box decimal.Decimal, reversal int) ([]Candle, error) {
+	if !box.IsPositive() {
+		return nil, &ValidationError{
+			Indicator:  "pnf",
+			Param:      "box size",
+			Value:      box,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidSize,
+		}
+	}
+
+	if reversal < 1 {
+		return nil, &ValidationError{
+			Indicator:  "pnf",
+			Param:      "reversal",
+			Value:      reversal,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidSize,
+		}
+	}
+
+	if len(cc) == 0 {
+		return nil, nil
+	}
+
+	var (
+		res         []Candle
+		t           = cc[0].Time
+		base        = cc[0].Close.Div(box).Floor().Mul(box)
+		first, last = base, base
+		dir         int
+		vol         decimal.Decimal
+		rev         = box.Mul(decimal.NewFromInt(int64(reversal)))
+	)
+
+	for _, c := range cc {
+		up := c.Close.Div(box).Floor().Mul(box)
+		down := c.Close.Div(box).Ceil().Mul(box)
+
+		switch {
+		case dir == 0 && up.GreaterThanOrEqual(base.Add(box)):
+			dir, last = 1, up
+		case dir == 0 && down.LessThanOrEqual(base.Sub(box)):
+			dir, last = -1, down
+		case dir > 0 && up.GreaterThan(last):
+			last = up
+		case dir < 0 && down.LessThan(last):
+			last = down
+		case dir > 0 && down.LessThanOrEqual(last.Sub(rev)):
+			res = append(res, column(t, first, last, vol))
+			t, first, last, dir, vol = c.Time, last.Sub(box), down, -1, decimal.Zero
+		case dir < 0 && up.GreaterThanOrEqual(last.Add(rev)):
+			res = append(res, column(t, first, last, vol))
+			t, first, last, dir, vol = c.Time, last.Add(box), up, 1, decimal.Zero
+		}
+
+		vol = vol.Add(c.Volume)
+	}
+
+	if dir == 0 {
+		return res, nil
+	}
+
+	return append(res, column(t, first, last, vol)), nil
+}
+
+// column creates a candle that moves from open to close.
+func column(t time.Time, open, close, vol decimal.Decimal) Candle {
+	return Candle{
+		Time:   t,
+		Open:   open,
+		High:   decimal.Max(open, close),
+		Low:    decimal.Min(open, close),
+		Close:  close,
+		Volume: vol,
+	}
+}
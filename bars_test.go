@@ -0,0 +1,389 @@
+package indc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bar is a shorthand for creating candles of the nth day.
+func bar(day int, o, h, l, c, v string) Candle {
+	return Candle{
+		Time:   time.Date(2021, 1, day, 0, 0, 0, 0, time.UTC),
+		Open:   decimal.RequireFromString(o),
+		High:   decimal.RequireFromString(h),
+		Low:    decimal.RequireFromString(l),
+		Close:  decimal.RequireFromString(c),
+		Volume: decimal.RequireFromString(v),
+	}
+}
+
+// closes creates candles of consecutive days from close prices, each
+// with the volume of 1.
+func closes(pp ...string) []Candle {
+	cc := make([]Candle, len(pp))
+	for i, p := range pp {
+		cc[i] = bar(i+1, p, p, p, p, "1")
+	}
+
+	return cc
+}
+
+func assertEqualCandles(t *testing.T, exp, res []Candle) {
+	t.Helper()
+
+	require.Len(t, res, len(exp))
+
+	for i := range exp {
+		assert.Equal(t, exp[i].Time, res[i].Time, "time %d", i)
+		assert.Equal(t, exp[i].Open.String(), res[i].Open.String(), "open %d", i)
+		assert.Equal(t, exp[i].High.String(), res[i].High.String(), "high %d", i)
+		assert.Equal(t, exp[i].Low.String(), res[i].Low.String(), "low %d", i)
+		assert.Equal(t, exp[i].Close.String(), res[i].Close.String(), "close %d", i)
+		assert.Equal(t, exp[i].Volume.String(), res[i].Volume.String(), "volume %d", i)
+	}
+}
+
+func Test_HeikinAshi(t *testing.T) {
+	res := HeikinAshi([]Candle{
+		bar(1, "10", "12", "9", "11", "5"),
+		bar(2, "11", "14", "10", "13", "6"),
+	})
+
+	assertEqualCandles(t, []Candle{
+		bar(1, "10.5", "12", "9", "10.5", "5"),
+		bar(2, "10.5", "14", "10", "12", "6"),
+	}, res)
+
+	assert.Empty(t, HeikinAshi(nil))
+}
+
+func Test_Renko(t *testing.T) {
+	cc := map[string]struct {
+		Candles []Candle
+		Box     decimal.Decimal
+		Result  []Candle
+		Error   error
+	}{
+		"Invalid box size": {
+			Candles: closes("1"),
+			Error: &ValidationError{
+				Indicator:  "renko",
+				Param:      "box size",
+				Value:      decimal.Decimal{},
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidSize,
+			},
+		},
+		"Successfully converted empty candles": {
+			Box: decimal.NewFromInt(1),
+		},
+		"Successfully converted candles": {
+			Candles: closes("10", "11.5", "12", "9.5", "9", "13"),
+			Box:     decimal.NewFromInt(1),
+			Result: []Candle{
+				bar(2, "10", "11", "10", "11", "2"),
+				bar(3, "11", "12", "11", "12", "1"),
+				bar(4, "11", "11", "10", "10", "1"),
+				bar(5, "10", "10", "9", "9", "1"),
+				bar(6, "10", "11", "10", "11", "1"),
+				bar(6, "11", "12", "11", "12", "0"),
+				bar(6, "12", "13", "12", "13", "0"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Renko(c.Candles, c.Box)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assertEqualCandles(t, c.Result, res)
+		})
+	}
+}
+
+func Test_RenkoATR(t *testing.T) {
+	cc := map[string]struct {
+		Candles []Candle
+		Length  int
+		Result  []Candle
+		Error   error
+	}{
+		"Invalid length": {
+			Candles: closes("1"),
+			Error: &ValidationError{
+				Indicator:  "renko",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Invalid data size": {
+			Candles: closes("1"),
+			Length:  2,
+			Error:   &DataSizeError{Expected: 2, Actual: 1},
+		},
+		"Successfully converted candles": {
+			Candles: []Candle{
+				bar(1, "10", "11", "9", "10", "1"),
+				bar(2, "10", "13", "10", "12", "1"),
+				bar(3, "12", "15", "11", "14", "1"),
+			},
+			Length: 2,
+			Result: []Candle{
+				bar(3, "10", "13.5", "10", "13.5", "3"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := RenkoATR(c.Candles, c.Length)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assertEqualCandles(t, c.Result, res)
+		})
+	}
+}
+
+func Test_atr(t *testing.T) {
+	cc := []Candle{
+		bar(1, "10", "12", "9", "11", "1"),
+		bar(2, "11", "14", "10", "13", "1"),
+		bar(3, "13", "13", "12", "12", "1"),
+	}
+
+	res, err := atr(cc, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", res.String())
+
+	res, err = atr(cc, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "2.6666666666666667", res.String())
+}
+
+func Test_RangeBars(t *testing.T) {
+	cc := map[string]struct {
+		Candles []Candle
+		Size    decimal.Decimal
+		Result  []Candle
+		Error   error
+	}{
+		"Invalid size": {
+			Candles: closes("1"),
+			Size:    decimal.NewFromInt(-1),
+			Error: &ValidationError{
+				Indicator:  "range",
+				Param:      "size",
+				Value:      decimal.NewFromInt(-1),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidSize,
+			},
+		},
+		"Successfully converted empty candles": {
+			Size: decimal.NewFromInt(2),
+		},
+		"Successfully converted candles": {
+			Candles: []Candle{
+				bar(1, "10", "11", "10", "11", "1"),
+				bar(2, "11", "14", "11", "14", "2"),
+				bar(3, "14", "14", "9", "9", "3"),
+			},
+			Size: decimal.NewFromInt(2),
+			Result: []Candle{
+				bar(1, "10", "12", "10", "12", "1"),
+				bar(2, "12", "14", "12", "12", "2"),
+				bar(3, "12", "12", "10", "10", "0"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := RangeBars(c.Candles, c.Size)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assertEqualCandles(t, c.Result, res)
+		})
+	}
+}
+
+func Test_path(t *testing.T) {
+	assert.Equal(t, []decimal.Decimal{
+		decimal.NewFromInt(2),
+		decimal.NewFromInt(3),
+		decimal.NewFromInt(0),
+		decimal.NewFromInt(1),
+	}, path(Candle{
+		Open:  decimal.NewFromInt(2),
+		High:  decimal.NewFromInt(3),
+		Low:   decimal.NewFromInt(0),
+		Close: decimal.NewFromInt(1),
+	}))
+
+	assert.Equal(t, []decimal.Decimal{
+		decimal.NewFromInt(2),
+		decimal.NewFromInt(1),
+		decimal.NewFromInt(4),
+		decimal.NewFromInt(3),
+	}, path(Candle{
+		Open:  decimal.NewFromInt(2),
+		High:  decimal.NewFromInt(4),
+		Low:   decimal.NewFromInt(1),
+		Close: decimal.NewFromInt(3),
+	}))
+}
+
+func Test_Kagi(t *testing.T) {
+	cc := map[string]struct {
+		Candles  []Candle
+		Reversal decimal.Decimal
+		Result   []Candle
+		Error    error
+	}{
+		"Invalid reversal": {
+			Candles: closes("1"),
+			Error: &ValidationError{
+				Indicator:  "kagi",
+				Param:      "reversal",
+				Value:      decimal.Decimal{},
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidSize,
+			},
+		},
+		"Successfully converted empty candles": {
+			Reversal: decimal.NewFromInt(2),
+		},
+		"Successfully converted candles without reversal": {
+			Candles:  closes("10", "11", "9"),
+			Reversal: decimal.NewFromInt(2),
+		},
+		"Successfully converted candles": {
+			Candles:  closes("10", "11", "13", "12", "10", "9", "12"),
+			Reversal: decimal.NewFromInt(2),
+			Result: []Candle{
+				bar(1, "10", "13", "10", "13", "4"),
+				bar(5, "13", "13", "9", "9", "2"),
+				bar(7, "9", "12", "9", "12", "1"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Kagi(c.Candles, c.Reversal)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assertEqualCandles(t, c.Result, res)
+		})
+	}
+}
+
+func Test_PointFigure(t *testing.T) {
+	cc := map[string]struct {
+		Candles  []Candle
+		Box      decimal.Decimal
+		Reversal int
+		Result   []Candle
+		Error    error
+	}{
+		"Invalid box size": {
+			Candles:  closes("1"),
+			Reversal: 3,
+			Error: &ValidationError{
+				Indicator:  "pnf",
+				Param:      "box size",
+				Value:      decimal.Decimal{},
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidSize,
+			},
+		},
+		"Invalid reversal": {
+			Candles: closes("1"),
+			Box:     decimal.NewFromInt(1),
+			Error: &ValidationError{
+				Indicator:  "pnf",
+				Param:      "reversal",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidSize,
+			},
+		},
+		"Successfully converted empty candles": {
+			Box:      decimal.NewFromInt(1),
+			Reversal: 3,
+		},
+		"Successfully converted candles without a full box": {
+			Candles:  closes("10", "10.5", "9.5"),
+			Box:      decimal.NewFromInt(1),
+			Reversal: 3,
+		},
+		"Successfully converted candles": {
+			Candles:  closes("10", "12.5", "14", "12", "10.5", "10", "13.2"),
+			Box:      decimal.NewFromInt(1),
+			Reversal: 3,
+			Result: []Candle{
+				bar(1, "10", "14", "10", "14", "4"),
+				bar(5, "13", "13", "10", "10", "2"),
+				bar(7, "11", "13", "11", "13", "1"),
+			},
+		},
+		"Successfully converted falling candles": {
+			Candles:  closes("10", "8.5", "8.9"),
+			Box:      decimal.NewFromInt(1),
+			Reversal: 1,
+			Result: []Candle{
+				bar(1, "10", "10", "9", "9", "3"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := PointFigure(c.Candles, c.Box, c.Reversal)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assertEqualCandles(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package indc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnknownIndicator is returned when indicator name is not
+	// recognized.
+	ErrUnknownIndicator = errors.New("unknown indicator")
+
+	// ErrInvalidArgument is returned when indicator argument cannot be
+	// parsed or too many arguments are provided.
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// builder creates new indicator from parameter values ordered as in the
+// indicator's description.
+type builder func(vv []interface{}, opts []Option) (Indicator, error)
+
+// _builders holds builders of all available indicators.
+var _builders = map[string]builder{
+	"aroon": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewAroon(vv[0].(Trend), vv[1].(int), opts...))
+	},
+	"bb": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewBB(vv[0].(bool), vv[1].(Band), vv[2].(decimal.Decimal), vv[3].(int), opts...))
+	},
+	"cci": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewCCI(vv[0].(MAType), vv[1].(int), vv[2].(decimal.Decimal), opts...))
+	},
+	"dema": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewDEMA(vv[0].(int), opts...))
+	},
+	"ema": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewEMA(vv[0].(int), opts...))
+	},
+	"hma": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewHMA(vv[0].(int), opts...))
+	},
+	"roc": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewROC(vv[0].(int), opts...))
+	},
+	"rsi": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewRSI(vv[0].(int), opts...))
+	},
+	"sma": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewSMA(vv[0].(int), opts...))
+	},
+	"srsi": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewSRSI(vv[0].(int), opts...))
+	},
+	"stoch": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewStoch(vv[0].(int), opts...))
+	},
+	"wma": func(vv []interface{}, opts []Option) (Indicator, error) {
+		return built(NewWMA(vv[0].(int), opts...))
+	},
+}
+
+// built converts constructor results to Indicator interface, so that
+// nil interface is returned on errors.
+func built[T Indicator](ind T, err error) (Indicator, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return ind, nil
+}
+
+// Build creates new indicator by its canonical name from the provided
+// textual arguments, e.g. Build("bb", []string{"upper", "2", "20"}).
+// Arguments are bound to the parameters of the indicator's description
+// in order. Boolean parameters are optional flags: when the next
+// argument is not a valid bool, the parameter uses its default value and
+// the argument is bound to the following parameter. Any other parameter
+// must be parsed from its argument. Parameters without remaining
+// arguments use their default values.
+func Build(name string, args []string, opts ...Option) (Indicator, error) {
+	b, ok := _builders[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownIndicator, name)
+	}
+
+	var d Description
+
+	for _, desc := range Descriptions() {
+		if desc.Name == name {
+			d = desc
+			break
+		}
+	}
+
+	if len(args) > len(d.Params) {
+		return nil, &ValidationError{
+			Indicator:  name,
+			Param:      "arguments",
+			Value:      len(args),
+			Constraint: fmt.Sprintf("must not exceed %d", len(d.Params)),
+			Err:        ErrInvalidArgument,
+		}
+	}
+
+	vv := make([]interface{}, len(d.Params))
+
+	var ai int
+
+	for pi, p := range d.Params {
+		vv[pi] = p.Default
+
+		if ai >= len(args) {
+			continue
+		}
+
+		v, err := parseArg(p.Type, args[ai])
+		if err != nil {
+			if p.Type == ParamTypeBool {
+				continue
+			}
+
+			return nil, &ValidationError{
+				Indicator:  name,
+				Param:      p.Name,
+				Value:      args[ai],
+				Constraint: "must be a valid " + paramTypeName(p.Type),
+				Err:        ErrInvalidArgument,
+			}
+		}
+
+		vv[pi] = v
+		ai++
+	}
+
+	if ai < len(args) {
+		return nil, &ValidationError{
+			Indicator:  name,
+			Param:      "arguments",
+			Value:      len(args),
+			Constraint: fmt.Sprintf("must not exceed %d", len(d.Params)),
+			Err:        ErrInvalidArgument,
+		}
+	}
+
+	return b(vv, opts)
+}
+
+// parseArg parses textual argument as a value of the parameter type.
+func parseArg(pt ParamType, s string) (interface{}, error) {
+	switch pt {
+	case ParamTypeInt:
+		return strconv.Atoi(s)
+	case ParamTypeDecimal:
+		return decimal.NewFromString(s)
+	case ParamTypeBool:
+		switch s {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		default:
+			return nil, ErrInvalidArgument
+		}
+	case ParamTypeTrend:
+		var t Trend
+		if err := t.UnmarshalText([]byte(s)); err != nil {
+			return nil, err
+		}
+
+		return t, nil
+	case ParamTypeBand:
+		var b Band
+		if err := b.UnmarshalText([]byte(s)); err != nil {
+			return nil, err
+		}
+
+		return b, nil
+	case ParamTypeMA:
+		var mat MAType
+		if err := mat.UnmarshalText([]byte(s)); err != nil {
+			return nil, err
+		}
+
+		return mat, nil
+	default:
+		return nil, ErrInvalidParamType
+	}
+}
+
+// paramTypeName returns textual name of the parameter type.
+func paramTypeName(pt ParamType) string {
+	b, err := pt.MarshalText()
+	if err != nil {
+		// unlikely to happen
+		return "value"
+	}
+
+	return string(b)
+}
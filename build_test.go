@@ -0,0 +1,164 @@
+package indc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Build(t *testing.T) {
+	cc := map[string]struct {
+		Name   string
+		Args   []string
+		Opts   []Option
+		Result Indicator
+		Error  error
+	}{
+		"Unknown indicator": {
+			Name:  "macd",
+			Error: fmt.Errorf("%w: %q", ErrUnknownIndicator, "macd"),
+		},
+		"Invalid argument": {
+			Name: "rsi",
+			Args: []string{"abc"},
+			Error: &ValidationError{
+				Indicator:  "rsi",
+				Param:      "length",
+				Value:      "abc",
+				Constraint: "must be a valid int",
+				Err:        ErrInvalidArgument,
+			},
+		},
+		"Invalid argument after omitted flag": {
+			Name: "bb",
+			Args: []string{"upper", "x"},
+			Error: &ValidationError{
+				Indicator:  "bb",
+				Param:      "std_dev",
+				Value:      "x",
+				Constraint: "must be a valid decimal",
+				Err:        ErrInvalidArgument,
+			},
+		},
+		"Too many arguments after omitted flag": {
+			Name: "bb",
+			Args: []string{"upper", "2", "20", "5"},
+			Error: &ValidationError{
+				Indicator:  "bb",
+				Param:      "arguments",
+				Value:      4,
+				Constraint: "must not exceed 4",
+				Err:        ErrInvalidArgument,
+			},
+		},
+		"Invalid argument of another parameter type": {
+			Name: "cci",
+			Args: []string{"14", "20"},
+			Error: &ValidationError{
+				Indicator:  "cci",
+				Param:      "ma",
+				Value:      "14",
+				Constraint: "must be a valid ma",
+				Err:        ErrInvalidArgument,
+			},
+		},
+		"Too many arguments with invalid argument": {
+			Name: "sma",
+			Args: []string{"x", "6"},
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "arguments",
+				Value:      2,
+				Constraint: "must not exceed 1",
+				Err:        ErrInvalidArgument,
+			},
+		},
+		"Too many arguments": {
+			Name: "sma",
+			Args: []string{"5", "6"},
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "arguments",
+				Value:      2,
+				Constraint: "must not exceed 1",
+				Err:        ErrInvalidArgument,
+			},
+		},
+		"Constructor returns an error": {
+			Name: "sma",
+			Args: []string{"0"},
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully built indicator with default parameters": {
+			Name:   "rsi",
+			Result: RSI{valid: true, length: 14},
+		},
+		"Successfully built indicator with omitted flag": {
+			Name: "bb",
+			Args: []string{"upper", "2", "20"},
+			Result: BB{
+				valid:  true,
+				band:   BandUpper,
+				stdDev: decimal.NewFromInt(2),
+				sma:    SMA{valid: true, length: 20},
+			},
+		},
+		"Successfully built indicator with default trailing parameters": {
+			Name: "bb",
+			Args: []string{"false", "lower", "3"},
+			Result: BB{
+				valid:  true,
+				band:   BandLower,
+				stdDev: decimal.NewFromInt(3),
+				sma:    SMA{valid: true, length: 20},
+			},
+		},
+		"Successfully built indicator with all parameters": {
+			Name: "aroon",
+			Args: []string{"down", "7"},
+			Opts: []Option{WithLenient()},
+			Result: Aroon{
+				valid:  true,
+				trend:  TrendDown,
+				length: 7,
+				cfg:    config{lenient: true},
+			},
+		},
+		"Invalid trailing argument": {
+			Name: "cci",
+			Args: []string{"ema", "5", "true"},
+			Error: &ValidationError{
+				Indicator:  "cci",
+				Param:      "factor",
+				Value:      "true",
+				Constraint: "must be a valid decimal",
+				Err:        ErrInvalidArgument,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Build(c.Name, c.Args, c.Opts...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package indc
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidCandle is returned when candle data cannot be parsed.
+	ErrInvalidCandle = errors.New("invalid candle")
+)
+
+// Candle holds prices and traded volume of a single time interval.
+type Candle struct {
+	// Time specifies the start of the interval.
+	Time time.Time `json:"time"`
+
+	// Open specifies the first price of the interval.
+	Open decimal.Decimal `json:"open"`
+
+	// High specifies the highest price of the interval.
+	High decimal.Decimal `json:"high"`
+
+	// Low specifies the lowest price of the interval.
+	Low decimal.Decimal `json:"low"`
+
+	// Close specifies the last price of the interval.
+	Close decimal.Decimal `json:"close"`
+
+	// Volume specifies the traded volume of the interval.
+	Volume decimal.Decimal `json:"volume"`
+}
+
+// Opens returns open prices of the provided candles.
+func Opens(cc []Candle) []decimal.Decimal {
+	return pluck(cc, func(c Candle) decimal.Decimal { return c.Open })
+}
+
+// Highs returns high prices of the provided candles.
+func Highs(cc []Candle) []decimal.Decimal {
+	return pluck(cc, func(c Candle) decimal.Decimal { return c.High })
+}
+
+// Lows returns low prices of the provided candles.
+func Lows(cc []Candle) []decimal.Decimal {
+	return pluck(cc, func(c Candle) decimal.Decimal { return c.Low })
+}
+
+// Closes returns close prices of the provided candles.
+func Closes(cc []Candle) []decimal.Decimal {
+	return pluck(cc, func(c Candle) decimal.Decimal { return c.Close })
+}
+
+// Volumes returns volumes of the provided candles.
+func Volumes(cc []Candle) []decimal.Decimal {
+	return pluck(cc, func(c Candle) decimal.Decimal { return c.Volume })
+}
+
+// pluck returns a single field of every candle.
+func pluck(cc []Candle, fn func(Candle) decimal.Decimal) []decimal.Decimal {
+	res := make([]decimal.Decimal, len(cc))
+
+	for i := range cc {
+		res[i] = fn(cc[i])
+	}
+
+	return res
+}
+
+// ReadCandlesCSV reads candles from CSV data. The first record must be a
+// header that contains time (or date, timestamp), open, high, low and
+// close columns; volume column is optional. Column names are case
+// insensitive and columns may be in any order.
+// Time can be formatted as RFC 3339, "2006-01-02 15:04:05", "2006-01-02"
+// or as unix seconds.
+func ReadCandlesCSV(r io.Reader) ([]Candle, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", ErrInvalidCandle, err)
+	}
+
+	cols := map[string]int{"volume": -1}
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "date", "timestamp":
+			name = "time"
+		}
+
+		cols[name] = i
+	}
+
+	for _, name := range []string{"time", "open", "high", "low", "close"} {
+		if _, ok := cols[name]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrInvalidCandle, name)
+		}
+	}
+
+	var cc []Candle
+
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return cc, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCandle, line, err)
+		}
+
+		c, err := parseCandle(rec, cols)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCandle, line, err)
+		}
+
+		cc = append(cc, c)
+	}
+}
+
+// parseCandle parses a single CSV record.
+func parseCandle(rec []string, cols map[string]int) (Candle, error) {
+	var (
+		c   Candle
+		err error
+	)
+
+	c.Time, err = ParseTime(rec[cols["time"]])
+	if err != nil {
+		return Candle{}, err
+	}
+
+	for name, dst := range map[string]*decimal.Decimal{
+		"open":   &c.Open,
+		"high":   &c.High,
+		"low":    &c.Low,
+		"close":  &c.Close,
+		"volume": &c.Volume,
+	} {
+		i := cols[name]
+		if i < 0 {
+			continue
+		}
+
+		*dst, err = decimal.NewFromString(strings.TrimSpace(rec[i]))
+		if err != nil {
+			return Candle{}, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return c, nil
+}
+
+// ParseTime parses time formatted as RFC 3339, "2006-01-02 15:04:05",
+// "2006-01-02" or as unix seconds.
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown time format %q", s)
+}
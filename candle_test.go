@@ -0,0 +1,154 @@
+package indc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CandleFields(t *testing.T) {
+	cc := []Candle{
+		{
+			Open:   decimal.NewFromInt(1),
+			High:   decimal.NewFromInt(2),
+			Low:    decimal.NewFromInt(3),
+			Close:  decimal.NewFromInt(4),
+			Volume: decimal.NewFromInt(5),
+		},
+	}
+
+	assert.Equal(t, []decimal.Decimal{cc[0].Open}, Opens(cc))
+	assert.Equal(t, []decimal.Decimal{cc[0].High}, Highs(cc))
+	assert.Equal(t, []decimal.Decimal{cc[0].Low}, Lows(cc))
+	assert.Equal(t, []decimal.Decimal{cc[0].Close}, Closes(cc))
+	assert.Equal(t, []decimal.Decimal{cc[0].Volume}, Volumes(cc))
+	assert.Empty(t, Closes(nil))
+}
+
+func Test_ReadCandlesCSV(t *testing.T) {
+	cc := map[string]struct {
+		Data   string
+		Result []Candle
+		Error  error
+	}{
+		"Empty data": {
+			Error: fmt.Errorf("%w: reading header: EOF", ErrInvalidCandle),
+		},
+		"Missing column": {
+			Data:  "time,open,high,low\n",
+			Error: fmt.Errorf("%w: missing close column", ErrInvalidCandle),
+		},
+		"Invalid time": {
+			Data:  "time,open,high,low,close\nyesterday,1,2,0.5,1.5\n",
+			Error: fmt.Errorf("%w: line 2: unknown time format \"yesterday\"", ErrInvalidCandle),
+		},
+		"Invalid price": {
+			Data:  "time,open,high,low,close\n2021-01-02,1,x,0.5,1.5\n",
+			Error: fmt.Errorf("%w: line 2: high: can't convert x to decimal", ErrInvalidCandle),
+		},
+		"Invalid record length": {
+			Data:  "time,open,high,low,close\n2021-01-02,1,2\n",
+			Error: fmt.Errorf("%w: line 2: record on line 2: wrong number of fields", ErrInvalidCandle),
+		},
+		"Successfully read candles without volume": {
+			Data: "Date,Open,High,Low,Close\n2021-01-02,1,2,0.5,1.5\n",
+			Result: []Candle{
+				{
+					Time:  time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					Open:  decimal.NewFromInt(1),
+					High:  decimal.NewFromInt(2),
+					Low:   decimal.RequireFromString("0.5"),
+					Close: decimal.RequireFromString("1.5"),
+				},
+			},
+		},
+		"Successfully read candles with columns in different order": {
+			Data: "close, volume, low, high, open, timestamp\n1.5, 10, 0.5, 2, 1, 1609545600\n",
+			Result: []Candle{
+				{
+					Time:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					Open:   decimal.NewFromInt(1),
+					High:   decimal.NewFromInt(2),
+					Low:    decimal.RequireFromString("0.5"),
+					Close:  decimal.RequireFromString("1.5"),
+					Volume: decimal.NewFromInt(10),
+				},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ReadCandlesCSV(strings.NewReader(c.Data))
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			require.Len(t, res, len(c.Result))
+
+			for i := range res {
+				assert.True(t, c.Result[i].Time.Equal(res[i].Time))
+				assert.Equal(t, c.Result[i].Open.String(), res[i].Open.String())
+				assert.Equal(t, c.Result[i].High.String(), res[i].High.String())
+				assert.Equal(t, c.Result[i].Low.String(), res[i].Low.String())
+				assert.Equal(t, c.Result[i].Close.String(), res[i].Close.String())
+				assert.Equal(t, c.Result[i].Volume.String(), res[i].Volume.String())
+			}
+		})
+	}
+}
+
+func Test_ParseTime(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result time.Time
+		Error  error
+	}{
+		"Invalid time": {
+			Text:  "now",
+			Error: assert.AnError,
+		},
+		"Successfully parsed unix seconds": {
+			Text:   "60",
+			Result: time.Date(1970, 1, 1, 0, 1, 0, 0, time.UTC),
+		},
+		"Successfully parsed RFC 3339": {
+			Text:   "2021-01-02T03:04:05Z",
+			Result: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		"Successfully parsed date and time": {
+			Text:   "2021-01-02 03:04:05",
+			Result: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		"Successfully parsed date": {
+			Text:   " 2021-01-02 ",
+			Result: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ParseTime(c.Text)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, c.Result.Equal(res))
+		})
+	}
+}
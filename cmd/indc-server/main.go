@@ -0,0 +1,71 @@
+// Command indc-server serves the indicator JSON API of the server
+// package over HTTP.
+//
+// Usage:
+//
+//	indc-server [flags]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/jellydator/indc/server"
+)
+
+var (
+	// errUsage is returned when command line arguments are invalid.
+	errUsage = errors.New("invalid usage")
+)
+
+func main() {
+	srv, err := newHTTPServer(os.Args[1:], os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "indc-server: %v\n", err)
+		os.Exit(2)
+	}
+
+	fmt.Fprintf(os.Stderr, "indc-server: listening on %s\n", srv.Addr)
+
+	if err := srv.ListenAndServe(); err != nil {
+		fmt.Fprintf(os.Stderr, "indc-server: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newHTTPServer parses command line arguments and creates new HTTP
+// server of the indicator API.
+func newHTTPServer(args []string, stderr io.Writer) (*http.Server, error) {
+	fs := flag.NewFlagSet("indc-server", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	limit := fs.Int64("limit", server.DefaultLimit, "maximum request body size in bytes")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("%w: unexpected arguments %q", errUsage, fs.Args())
+	}
+
+	s, err := server.NewServer(*limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	return &http.Server{
+		Addr:              *addr,
+		Handler:           s,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      time.Minute,
+		IdleTimeout:       2 * time.Minute,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		if exp == assert.AnError { //nolint:goerr113 // direct check is needed
+			assert.Error(t, err)
+			return
+		}
+
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+func Test_newHTTPServer(t *testing.T) {
+	cc := map[string]struct {
+		Args   []string
+		Result string
+		Error  error
+	}{
+		"Invalid flag": {
+			Args:  []string{"--foo"},
+			Error: fmt.Errorf("%w: flag provided but not defined: -foo", errUsage),
+		},
+		"Unexpected arguments": {
+			Args:  []string{"foo"},
+			Error: fmt.Errorf("%w: unexpected arguments [\"foo\"]", errUsage),
+		},
+		"Invalid limit": {
+			Args:  []string{"--limit", "-1"},
+			Error: fmt.Errorf("%w: invalid request size limit", errUsage),
+		},
+		"Successfully created default server": {
+			Result: "localhost:8080",
+		},
+		"Successfully created server": {
+			Args:   []string{"--addr", ":9000", "--limit", "1024"},
+			Result: ":9000",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := newHTTPServer(c.Args, io.Discard)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res.Addr)
+			assert.NotNil(t, res.Handler)
+			assert.NotZero(t, res.ReadHeaderTimeout)
+		})
+	}
+}
@@ -0,0 +1,518 @@
+// Command indc calculates indicators over candles or price series read
+// from CSV or JSON Lines data.
+//
+// Usage:
+//
+//	indc [flags] [file]
+//
+// Data is read from the file or, if it is omitted or "-", from the
+// standard input. Every --ind flag adds an output column calculated from
+// an indicator spec, e.g. --ind "bb(upper,2,20)" or any expression
+// supported by the expr package, e.g. --ind "close - sma(20)".
+// Rows that do not have enough preceding data for every indicator are
+// marked as warm-up rows.
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jellydator/indc"
+	"github.com/jellydator/indc/expr"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// errUsage is returned when command line arguments are invalid.
+	errUsage = errors.New("invalid usage")
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "indc: %v\n", err)
+
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+
+		os.Exit(1)
+	}
+}
+
+// specs is a repeatable flag of indicator specs.
+type specs []string
+
+// String returns all specs joined by semicolons.
+func (s *specs) String() string {
+	return strings.Join(*s, "; ")
+}
+
+// Set adds new spec.
+func (s *specs) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// options holds parsed command line flags.
+type options struct {
+	// specs specifies indicator specs.
+	specs specs
+
+	// input specifies the input format.
+	input string
+
+	// output specifies the output format.
+	output string
+
+	// column specifies the single price column that should be read
+	// instead of candles.
+	column string
+}
+
+// run executes the command with the provided arguments and streams.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var opts options
+
+	fs := flag.NewFlagSet("indc", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&opts.specs, "ind", "indicator spec, e.g. \"rsi(14)\" or \"bb(upper,2,20)\"; can be repeated")
+	fs.StringVar(&opts.input, "in", "", "input format: csv or jsonl; detected from the file extension by default")
+	fs.StringVar(&opts.output, "out", "table", "output format: csv, json (one object per line) or table")
+	fs.StringVar(&opts.column, "column", "", "read a single price column instead of candles")
+
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: indc [flags] [file]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	if len(opts.specs) == 0 {
+		return fmt.Errorf("%w: at least one --ind flag is required", errUsage)
+	}
+
+	if fs.NArg() > 1 {
+		return fmt.Errorf("%w: at most one input file is allowed", errUsage)
+	}
+
+	path := fs.Arg(0)
+
+	ee := make([]expr.Expr, len(opts.specs))
+
+	for i, s := range opts.specs {
+		e, err := expr.Parse(s)
+		if err != nil {
+			return fmt.Errorf("%w: %q: %v", errUsage, s, err)
+		}
+
+		ee[i] = e
+	}
+
+	r := stdin
+
+	if path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		r = f
+	}
+
+	format := opts.input
+	if format == "" {
+		format = "csv"
+
+		if ext := strings.ToLower(filepath.Ext(path)); ext == ".jsonl" || ext == ".ndjson" {
+			format = "jsonl"
+		}
+	}
+
+	var (
+		recs []map[string]string
+		err  error
+	)
+
+	switch format {
+	case "csv":
+		recs, err = readCSV(r)
+	case "jsonl":
+		recs, err = readJSONL(r)
+	default:
+		return fmt.Errorf("%w: unknown input format %q", errUsage, format)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cc, err := candles(recs, opts.column)
+	if err != nil {
+		return err
+	}
+
+	tbl, err := calculate(cc, opts.specs, ee)
+	if err != nil {
+		return err
+	}
+
+	switch opts.output {
+	case "csv":
+		return tbl.writeCSV(stdout)
+	case "json":
+		return tbl.writeJSON(stdout)
+	case "table":
+		return tbl.writeTable(stdout)
+	default:
+		return fmt.Errorf("%w: unknown output format %q", errUsage, opts.output)
+	}
+}
+
+// readCSV reads records of CSV data with a header. Column names are
+// lowercased.
+func readCSV(r io.Reader) ([]map[string]string, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var recs []map[string]string
+
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return recs, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rec := make(map[string]string, len(row))
+		for i := range row {
+			rec[header[i]] = strings.TrimSpace(row[i])
+		}
+
+		recs = append(recs, rec)
+	}
+}
+
+// readJSONL reads records of JSON Lines data, where every line is a
+// JSON object. Keys are lowercased. Empty lines are skipped.
+func readJSONL(r io.Reader) ([]map[string]string, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var recs []map[string]string
+
+	for line := 1; sc.Scan(); line++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+
+		dec := json.NewDecoder(strings.NewReader(sc.Text()))
+		dec.UseNumber()
+
+		var obj map[string]interface{}
+		if err := dec.Decode(&obj); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rec := make(map[string]string, len(obj))
+		for k, v := range obj {
+			if v != nil {
+				rec[strings.ToLower(k)] = fmt.Sprint(v)
+			}
+		}
+
+		recs = append(recs, rec)
+	}
+
+	return recs, sc.Err()
+}
+
+// candles converts records into candles. If column is provided, its
+// values are used as every price of the candles. Otherwise, close field
+// is required, while missing open, high and low fields default to the
+// close price. Time (date or timestamp) and volume fields are optional.
+func candles(recs []map[string]string, column string) ([]indc.Candle, error) {
+	column = strings.ToLower(column)
+	cc := make([]indc.Candle, len(recs))
+
+	for i, rec := range recs {
+		c, err := candle(rec, column)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		cc[i] = c
+	}
+
+	return cc, nil
+}
+
+// candle converts a single record into a candle.
+func candle(rec map[string]string, column string) (indc.Candle, error) {
+	var c indc.Candle
+
+	for _, k := range []string{"time", "date", "timestamp"} {
+		if v, ok := rec[k]; ok && v != "" {
+			t, err := indc.ParseTime(v)
+			if err != nil {
+				return indc.Candle{}, err
+			}
+
+			c.Time = t
+
+			break
+		}
+	}
+
+	price := func(k string, dst *decimal.Decimal, required bool) error {
+		v, ok := rec[k]
+		if !ok || v == "" {
+			if required {
+				return fmt.Errorf("missing %s field", k)
+			}
+
+			return nil
+		}
+
+		d, err := decimal.NewFromString(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+
+		*dst = d
+
+		return nil
+	}
+
+	if column != "" {
+		if err := price(column, &c.Close, true); err != nil {
+			return indc.Candle{}, err
+		}
+
+		c.Open, c.High, c.Low = c.Close, c.Close, c.Close
+
+		return c, nil
+	}
+
+	if err := price("close", &c.Close, true); err != nil {
+		return indc.Candle{}, err
+	}
+
+	c.Open, c.High, c.Low = c.Close, c.Close, c.Close
+
+	for k, dst := range map[string]*decimal.Decimal{
+		"open":   &c.Open,
+		"high":   &c.High,
+		"low":    &c.Low,
+		"volume": &c.Volume,
+	} {
+		if err := price(k, dst, false); err != nil {
+			return indc.Candle{}, err
+		}
+	}
+
+	return c, nil
+}
+
+// table holds calculation results aligned with candles.
+type table struct {
+	// candles specifies the input candles.
+	candles []indc.Candle
+
+	// names specifies the names of the calculated columns.
+	names []string
+
+	// values specifies formatted values of every row and column. Empty
+	// string marks missing warm-up values.
+	values [][]string
+
+	// warmup specifies whether rows have missing values.
+	warmup []bool
+
+	// timed specifies whether candles have times.
+	timed bool
+}
+
+// calculate evaluates every expression at every candle.
+func calculate(cc []indc.Candle, names []string, ee []expr.Expr) (table, error) {
+	tbl := table{
+		candles: cc,
+		names:   names,
+		values:  make([][]string, len(cc)),
+		warmup:  make([]bool, len(cc)),
+	}
+
+	for i := range cc {
+		if !cc[i].Time.IsZero() {
+			tbl.timed = true
+		}
+
+		tbl.values[i] = make([]string, len(ee))
+
+		for j, e := range ee {
+			if i+1 < e.Count() {
+				tbl.warmup[i] = true
+				continue
+			}
+
+			v, err := format(e, cc[:i+1])
+			if err != nil {
+				return table{}, fmt.Errorf("row %d: %s: %w", i+1, names[j], err)
+			}
+
+			tbl.values[i][j] = v
+		}
+	}
+
+	return tbl, nil
+}
+
+// format evaluates the expression and formats its result.
+func format(e expr.Expr, cc []indc.Candle) (string, error) {
+	if e.Type() == expr.TypeBool {
+		v, err := e.Bool(cc)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprint(v), nil
+	}
+
+	v, err := e.Number(cc)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
+
+// header returns the names of all output columns.
+func (tbl table) header() []string {
+	var hh []string
+
+	if tbl.timed {
+		hh = append(hh, "time")
+	}
+
+	hh = append(hh, "close")
+	hh = append(hh, tbl.names...)
+
+	return append(hh, "warmup")
+}
+
+// row returns all output values of the row. Missing values are replaced
+// with the provided placeholder.
+func (tbl table) row(i int, missing string) []string {
+	var rr []string
+
+	if tbl.timed {
+		var t string
+		if !tbl.candles[i].Time.IsZero() {
+			t = tbl.candles[i].Time.Format(time.RFC3339)
+		}
+
+		rr = append(rr, t)
+	}
+
+	rr = append(rr, tbl.candles[i].Close.String())
+
+	for _, v := range tbl.values[i] {
+		if v == "" {
+			v = missing
+		}
+
+		rr = append(rr, v)
+	}
+
+	return append(rr, fmt.Sprint(tbl.warmup[i]))
+}
+
+// writeCSV writes the table as CSV data with a header.
+func (tbl table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(tbl.header()); err != nil {
+		return err
+	}
+
+	for i := range tbl.candles {
+		if err := cw.Write(tbl.row(i, "")); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeJSON writes the table as JSON Lines data, where missing values
+// are nulls.
+func (tbl table) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	for i := range tbl.candles {
+		obj := map[string]interface{}{
+			"close":  tbl.candles[i].Close,
+			"warmup": tbl.warmup[i],
+		}
+
+		if !tbl.candles[i].Time.IsZero() {
+			obj["time"] = tbl.candles[i].Time
+		}
+
+		for j, name := range tbl.names {
+			var v interface{}
+			if tbl.values[i][j] != "" {
+				v = tbl.values[i][j]
+			}
+
+			obj[name] = v
+		}
+
+		if err := enc.Encode(obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTable writes the table as aligned plain text columns, where
+// missing values are dashes.
+func (tbl table) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(tbl.header(), "\t"))
+
+	for i := range tbl.candles {
+		fmt.Fprintln(tw, strings.Join(tbl.row(i, "-"), "\t"))
+	}
+
+	return tw.Flush()
+}
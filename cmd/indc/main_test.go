@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/jellydator/indc"
+	"github.com/jellydator/indc/expr"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		if exp == assert.AnError { //nolint:goerr113 // direct check is needed
+			assert.Error(t, err)
+			return
+		}
+
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+// _candlesCSV holds candles in CSV format.
+const _candlesCSV = `Date,Open,High,Low,Close,Volume
+2021-01-01,1,2,0.5,1,10
+2021-01-02,1,3,1,2,20
+2021-01-03,2,4,2,4,30
+`
+
+func Test_run(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonl := filepath.Join(dir, "prices.jsonl")
+	require.NoError(t, os.WriteFile(jsonl, []byte("{\"Price\": 1}\n\n{\"price\": \"3\", \"time\": 60}\n"), 0o600))
+
+	cc := map[string]struct {
+		Args   []string
+		Stdin  string
+		Result string
+		Error  error
+	}{
+		"Invalid flag": {
+			Args:  []string{"--foo"},
+			Error: fmt.Errorf("%w: flag provided but not defined: -foo", errUsage),
+		},
+		"Unsupported lenient flag": {
+			Args:  []string{"--ind", "sma(1)", "--lenient"},
+			Error: fmt.Errorf("%w: flag provided but not defined: -lenient", errUsage),
+		},
+		"Missing indicators": {
+			Error: fmt.Errorf("%w: at least one --ind flag is required", errUsage),
+		},
+		"Too many files": {
+			Args:  []string{"--ind", "sma(2)", "a.csv", "b.csv"},
+			Error: fmt.Errorf("%w: at most one input file is allowed", errUsage),
+		},
+		"Invalid indicator spec": {
+			Args:  []string{"--ind", "sma(2"},
+			Error: fmt.Errorf("%w: \"sma(2\": invalid syntax at position 5: expected \",\" or \")\"", errUsage),
+		},
+		"Missing file": {
+			Args: []string{"--ind", "sma(2)", filepath.Join(dir, "missing.csv")},
+			Error: &fs.PathError{
+				Op:   "open",
+				Path: filepath.Join(dir, "missing.csv"),
+				Err:  syscall.ENOENT,
+			},
+		},
+		"Invalid input format": {
+			Args:  []string{"--ind", "sma(2)", "--in", "xml"},
+			Error: fmt.Errorf("%w: unknown input format \"xml\"", errUsage),
+		},
+		"Invalid input data": {
+			Args:  []string{"--ind", "sma(2)"},
+			Error: assert.AnError,
+		},
+		"Invalid candle data": {
+			Args:  []string{"--ind", "sma(2)"},
+			Stdin: "close\nabc\n",
+			Error: assert.AnError,
+		},
+		"Calculation returns an error": {
+			Args:  []string{"--ind", "close / (open - open)"},
+			Stdin: _candlesCSV,
+			Error: fmt.Errorf("row 1: close / (open - open): %w",
+				fmt.Errorf("(close / (open - open)): %w", indc.ErrDivisionByZero)),
+		},
+		"Invalid output format": {
+			Args:  []string{"--ind", "sma(2)", "--out", "xml"},
+			Stdin: _candlesCSV,
+			Error: fmt.Errorf("%w: unknown output format \"xml\"", errUsage),
+		},
+		"Successfully written table": {
+			Args:  []string{"--ind", "sma(2)", "--ind", "close > sma(2)"},
+			Stdin: _candlesCSV,
+			Result: "time                  close  sma(2)  close > sma(2)  warmup\n" +
+				"2021-01-01T00:00:00Z  1      -       -               true\n" +
+				"2021-01-02T00:00:00Z  2      1.5     true            false\n" +
+				"2021-01-03T00:00:00Z  4      3       true            false\n",
+		},
+		"Successfully written CSV": {
+			Args:  []string{"--ind", "bb(upper,2,2)", "--ind", "high - low", "--out", "csv", "-"},
+			Stdin: _candlesCSV,
+			Result: "time,close,\"bb(upper,2,2)\",high - low,warmup\n" +
+				"2021-01-01T00:00:00Z,1,,1.5,true\n" +
+				"2021-01-02T00:00:00Z,2,2.5,2,false\n" +
+				"2021-01-03T00:00:00Z,4,5,2,false\n",
+		},
+		"Successfully written JSON from a single JSON Lines column": {
+			Args: []string{"--ind", "sma(2)", "--column", "PRICE", "--out", "json", jsonl},
+			Result: "{\"close\":\"1\",\"sma(2)\":null,\"warmup\":true}\n" +
+				"{\"close\":\"3\",\"sma(2)\":\"2\",\"time\":\"1970-01-01T00:01:00Z\",\"warmup\":false}\n",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			err := run(c.Args, strings.NewReader(c.Stdin), &stdout, &stderr)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, stdout.String())
+		})
+	}
+}
+
+func Test_readCSV(t *testing.T) {
+	_, err := readCSV(strings.NewReader(""))
+	assert.Error(t, err)
+
+	_, err = readCSV(strings.NewReader("close,open\n1\n"))
+	assert.Error(t, err)
+
+	res, err := readCSV(strings.NewReader(" Close , Open\n 1, 2 \n"))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"close": "1", "open": "2"}}, res)
+}
+
+func Test_readJSONL(t *testing.T) {
+	_, err := readJSONL(strings.NewReader("{\"close\": 1}\n[1]\n"))
+	assert.Error(t, err)
+
+	res, err := readJSONL(strings.NewReader("{\"Close\": 1.50, \"open\": null}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"close": "1.50"}}, res)
+}
+
+func Test_candles(t *testing.T) {
+	cc := map[string]struct {
+		Records []map[string]string
+		Column  string
+		Result  []indc.Candle
+		Error   error
+	}{
+		"Invalid time": {
+			Records: []map[string]string{{"date": "yesterday", "close": "1"}},
+			Error:   assert.AnError,
+		},
+		"Missing column": {
+			Records: []map[string]string{{"close": "1"}},
+			Column:  "price",
+			Error:   assert.AnError,
+		},
+		"Missing close": {
+			Records: []map[string]string{{"open": "1"}},
+			Error:   assert.AnError,
+		},
+		"Invalid optional field": {
+			Records: []map[string]string{{"close": "1", "volume": "x"}},
+			Error:   assert.AnError,
+		},
+		"Successfully converted column": {
+			Records: []map[string]string{{"price": "2", "close": "3"}},
+			Column:  "Price",
+		},
+		"Successfully converted candles": {
+			Records: []map[string]string{{"close": "2", "high": "3"}},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := candles(c.Records, c.Column)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			require.Len(t, res, 1)
+			assert.Equal(t, "2", res[0].Close.String())
+			assert.Equal(t, "2", res[0].Open.String())
+			assert.Equal(t, "2", res[0].Low.String())
+			assert.True(t, res[0].Volume.IsZero())
+		})
+	}
+}
+
+func Test_specs(t *testing.T) {
+	var s specs
+
+	require.NoError(t, s.Set("sma(2)"))
+	require.NoError(t, s.Set("rsi"))
+	assert.Equal(t, "sma(2); rsi", s.String())
+}
+
+func Test_calculate_BB(t *testing.T) {
+	var sb strings.Builder
+
+	sb.WriteString("Date,Close\n")
+
+	for i := 1; i <= 21; i++ {
+		fmt.Fprintf(&sb, "2021-01-%02d,%d\n", i, i)
+	}
+
+	path := filepath.Join(t.TempDir(), "candles.csv")
+	require.NoError(t, os.WriteFile(path, []byte(sb.String()), 0o600))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	recs, err := readCSV(f)
+	require.NoError(t, err)
+
+	cc, err := candles(recs, "")
+	require.NoError(t, err)
+
+	e, err := expr.Parse("bb(upper,2,20)")
+	require.NoError(t, err)
+
+	tbl, err := calculate(cc, []string{"bb(upper,2,20)"}, []expr.Expr{e})
+	require.NoError(t, err)
+
+	for i := 0; i < 19; i++ {
+		assert.True(t, tbl.warmup[i], "row %d", i+1)
+		assert.Empty(t, tbl.values[i][0], "row %d", i+1)
+	}
+
+	// sma(1..20) + 2 * population standard deviation of 1..20
+	assert.False(t, tbl.warmup[19])
+	for i, exp := range []float64{10.5, 11.5} {
+		res, _ := decimal.RequireFromString(tbl.values[19+i][0]).Float64()
+		assert.InDelta(t, exp+2*math.Sqrt(33.25), res, 1e-9)
+	}
+
+	var stdout bytes.Buffer
+
+	require.NoError(t, run([]string{"--ind", "bb(upper,2,20)", "--out", "csv", path}, strings.NewReader(""), &stdout, io.Discard))
+	assert.True(t, strings.HasPrefix(stdout.String(), "time,close,\"bb(upper,2,20)\",warmup\n"))
+	assert.Contains(t, stdout.String(), "2021-01-21T00:00:00Z,21,"+tbl.values[20][0]+",false\n")
+}
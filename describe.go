@@ -0,0 +1,443 @@
+package indc
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Unit specifies the unit of indicator output values.
+type Unit int
+
+// Available output units.
+const (
+	// UnitPrice specifies that output values are in the same units as
+	// the provided data points.
+	UnitPrice Unit = iota + 1
+
+	// UnitPercent specifies that output values are unbounded
+	// percentages.
+	UnitPercent
+
+	// UnitBounded specifies that output values are bounded by the
+	// minimum and the maximum values of the description.
+	UnitBounded
+
+	// UnitUnbounded specifies that output values have no unit and are
+	// not bounded.
+	UnitUnbounded
+)
+
+// Validate checks whether the unit is one of
+// supported units or not.
+func (u Unit) Validate() error {
+	switch u {
+	case UnitPrice, UnitPercent, UnitBounded, UnitUnbounded:
+		return nil
+	default:
+		return ErrInvalidUnit
+	}
+}
+
+// MarshalText turns unit into appropriate string
+// representation.
+func (u Unit) MarshalText() ([]byte, error) {
+	var v string
+
+	switch u {
+	case UnitPrice:
+		v = "price"
+	case UnitPercent:
+		v = "percent"
+	case UnitBounded:
+		v = "bounded"
+	case UnitUnbounded:
+		v = "unbounded"
+	default:
+		return nil, ErrInvalidUnit
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate unit value.
+func (u *Unit) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "price":
+		*u = UnitPrice
+	case "percent":
+		*u = UnitPercent
+	case "bounded":
+		*u = UnitBounded
+	case "unbounded":
+		*u = UnitUnbounded
+	default:
+		return ErrInvalidUnit
+	}
+
+	return nil
+}
+
+// ParamType specifies the type of indicator configuration parameter.
+type ParamType int
+
+// Available parameter types.
+const (
+	// ParamTypeInt specifies that the parameter is an integer.
+	ParamTypeInt ParamType = iota + 1
+
+	// ParamTypeDecimal specifies that the parameter is a decimal number.
+	ParamTypeDecimal
+
+	// ParamTypeBool specifies that the parameter is a boolean flag.
+	ParamTypeBool
+
+	// ParamTypeTrend specifies that the parameter is a Trend value.
+	ParamTypeTrend
+
+	// ParamTypeBand specifies that the parameter is a Band value.
+	ParamTypeBand
+
+	// ParamTypeMA specifies that the parameter is an MAType value.
+	ParamTypeMA
+)
+
+// Validate checks whether the parameter type is one of
+// supported parameter types or not.
+func (pt ParamType) Validate() error {
+	switch pt {
+	case ParamTypeInt, ParamTypeDecimal, ParamTypeBool, ParamTypeTrend,
+		ParamTypeBand, ParamTypeMA:
+		return nil
+	default:
+		return ErrInvalidParamType
+	}
+}
+
+// MarshalText turns parameter type into appropriate string
+// representation.
+func (pt ParamType) MarshalText() ([]byte, error) {
+	var v string
+
+	switch pt {
+	case ParamTypeInt:
+		v = "int"
+	case ParamTypeDecimal:
+		v = "decimal"
+	case ParamTypeBool:
+		v = "bool"
+	case ParamTypeTrend:
+		v = "trend"
+	case ParamTypeBand:
+		v = "band"
+	case ParamTypeMA:
+		v = "ma"
+	default:
+		return nil, ErrInvalidParamType
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate parameter type value.
+func (pt *ParamType) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "int":
+		*pt = ParamTypeInt
+	case "decimal":
+		*pt = ParamTypeDecimal
+	case "bool":
+		*pt = ParamTypeBool
+	case "trend":
+		*pt = ParamTypeTrend
+	case "band":
+		*pt = ParamTypeBand
+	case "ma":
+		*pt = ParamTypeMA
+	default:
+		return ErrInvalidParamType
+	}
+
+	return nil
+}
+
+// Param describes a single configuration parameter of an indicator.
+type Param struct {
+	// Name specifies the name of the parameter.
+	Name string `json:"name"`
+
+	// Type specifies the type of the parameter.
+	Type ParamType `json:"type"`
+
+	// Default specifies the commonly used value of the parameter.
+	Default interface{} `json:"default"`
+
+	// Min specifies the minimum allowed value of numeric parameters.
+	Min decimal.NullDecimal `json:"min"`
+
+	// Max specifies the maximum allowed value of numeric parameters.
+	Max decimal.NullDecimal `json:"max"`
+
+	// ExclusiveMin specifies whether Min value itself is not allowed.
+	ExclusiveMin bool `json:"exclusive_min,omitempty"`
+
+	// Options specifies all allowed values of enumerated parameters.
+	Options []string `json:"options,omitempty"`
+}
+
+// Description holds metadata of an indicator.
+type Description struct {
+	// Name specifies the canonical name of the indicator.
+	Name string `json:"name"`
+
+	// Params specifies configuration parameters of the indicator, in the
+	// same order as they are accepted by its constructor.
+	Params []Param `json:"params"`
+
+	// Unit specifies the unit of output values.
+	Unit Unit `json:"unit"`
+
+	// Min specifies the lowest possible output value of bounded
+	// indicators.
+	Min decimal.NullDecimal `json:"min"`
+
+	// Max specifies the highest possible output value of bounded
+	// indicators.
+	Max decimal.NullDecimal `json:"max"`
+
+	// Overlay specifies whether output values can be drawn over the
+	// price chart.
+	Overlay bool `json:"overlay"`
+}
+
+// Describer is an interface that every indicator which is able to
+// describe itself should implement.
+type Describer interface {
+	// Describe should return the metadata of the indicator.
+	Describe() Description
+}
+
+// Descriptions returns descriptions of all available indicators, sorted
+// by their names.
+func Descriptions() []Description {
+	dd := []Description{
+		Aroon{}.Describe(),
+		BB{}.Describe(),
+		CCI{}.Describe(),
+		DEMA{}.Describe(),
+		EMA{}.Describe(),
+		HMA{}.Describe(),
+		ROC{}.Describe(),
+		RSI{}.Describe(),
+		SMA{}.Describe(),
+		SRSI{}.Describe(),
+		Stoch{}.Describe(),
+		WMA{}.Describe(),
+	}
+
+	sort.Slice(dd, func(i, j int) bool {
+		return dd[i].Name < dd[j].Name
+	})
+
+	return dd
+}
+
+// Describe returns the metadata of Aroon.
+func (aroon Aroon) Describe() Description {
+	return Description{
+		Name: "aroon",
+		Params: []Param{
+			{
+				Name:    "trend",
+				Type:    ParamTypeTrend,
+				Default: TrendUp,
+				Options: textNames(TrendUp, TrendDown),
+			},
+			lengthParam(25),
+		},
+		Unit: UnitBounded,
+		Min:  nullDecimal(decimal.Zero),
+		Max:  nullDecimal(_hundred),
+	}
+}
+
+// Describe returns the metadata of BB. Output unit depends on band and
+// percent configuration properties.
+func (bb BB) Describe() Description {
+	desc := Description{
+		Name: "bb",
+		Params: []Param{
+			{
+				Name:    "percent",
+				Type:    ParamTypeBool,
+				Default: false,
+			},
+			{
+				Name:    "band",
+				Type:    ParamTypeBand,
+				Default: BandUpper,
+				Options: textNames(BandUpper, BandLower, BandWidth),
+			},
+			{
+				Name:    "std_dev",
+				Type:    ParamTypeDecimal,
+				Default: decimal.NewFromInt(2),
+			},
+			lengthParam(20),
+		},
+		Unit:    UnitPrice,
+		Overlay: true,
+	}
+
+	if bb.percent || bb.band == BandWidth {
+		desc.Unit = UnitPercent
+		desc.Overlay = false
+	}
+
+	return desc
+}
+
+// Describe returns the metadata of CCI.
+func (cci CCI) Describe() Description {
+	return Description{
+		Name: "cci",
+		Params: []Param{
+			{
+				Name:    "ma",
+				Type:    ParamTypeMA,
+				Default: MATypeSMA,
+				Options: textNames(MATypeDEMA, MATypeEMA, MATypeHMA, MATypeSMA, MATypeWMA),
+			},
+			lengthParam(20),
+			{
+				Name:         "factor",
+				Type:         ParamTypeDecimal,
+				Default:      decimal.RequireFromString("0.015"),
+				Min:          nullDecimal(decimal.Zero),
+				ExclusiveMin: true,
+			},
+		},
+		Unit: UnitUnbounded,
+	}
+}
+
+// Describe returns the metadata of DEMA.
+func (dema DEMA) Describe() Description {
+	return maDescription(MATypeDEMA)
+}
+
+// Describe returns the metadata of EMA.
+func (ema EMA) Describe() Description {
+	return maDescription(MATypeEMA)
+}
+
+// Describe returns the metadata of HMA.
+func (h HMA) Describe() Description {
+	return maDescription(MATypeHMA)
+}
+
+// Describe returns the metadata of ROC.
+func (roc ROC) Describe() Description {
+	return Description{
+		Name:   "roc",
+		Params: []Param{lengthParam(12)},
+		Unit:   UnitPercent,
+	}
+}
+
+// Describe returns the metadata of RSI.
+func (rsi RSI) Describe() Description {
+	return Description{
+		Name:   "rsi",
+		Params: []Param{lengthParam(14)},
+		Unit:   UnitBounded,
+		Min:    nullDecimal(decimal.Zero),
+		Max:    nullDecimal(_hundred),
+	}
+}
+
+// Describe returns the metadata of SMA.
+func (sma SMA) Describe() Description {
+	return maDescription(MATypeSMA)
+}
+
+// Describe returns the metadata of SRSI.
+func (srsi SRSI) Describe() Description {
+	return Description{
+		Name:   "srsi",
+		Params: []Param{lengthParam(14)},
+		Unit:   UnitBounded,
+		Min:    nullDecimal(decimal.Zero),
+		Max:    nullDecimal(_one),
+	}
+}
+
+// Describe returns the metadata of Stoch.
+func (stoch Stoch) Describe() Description {
+	return Description{
+		Name:   "stoch",
+		Params: []Param{lengthParam(14)},
+		Unit:   UnitBounded,
+		Min:    nullDecimal(decimal.Zero),
+		Max:    nullDecimal(_hundred),
+	}
+}
+
+// Describe returns the metadata of WMA.
+func (wma WMA) Describe() Description {
+	return maDescription(MATypeWMA)
+}
+
+// Describe returns the metadata of the wrapped indicator. Empty
+// description is returned if the wrapped indicator does not implement
+// Describer interface.
+func (o Ordered) Describe() Description {
+	d, ok := o.ind.(Describer)
+	if !ok {
+		return Description{}
+	}
+
+	return d.Describe()
+}
+
+// maDescription returns the metadata of the moving average.
+func maDescription(mat MAType) Description {
+	return Description{
+		Name:    _maTypeNames[mat],
+		Params:  []Param{lengthParam(20)},
+		Unit:    UnitPrice,
+		Overlay: true,
+	}
+}
+
+// lengthParam returns the description of length parameter.
+func lengthParam(def int) Param {
+	return Param{
+		Name:    "length",
+		Type:    ParamTypeInt,
+		Default: def,
+		Min:     nullDecimal(_one),
+	}
+}
+
+// nullDecimal returns valid decimal.NullDecimal of the provided value.
+func nullDecimal(d decimal.Decimal) decimal.NullDecimal {
+	return decimal.NullDecimal{Decimal: d, Valid: true}
+}
+
+// textNames returns string representations of the provided values.
+func textNames(vv ...interface{ MarshalText() ([]byte, error) }) []string {
+	res := make([]string, 0, len(vv))
+
+	for _, v := range vv {
+		b, err := v.MarshalText()
+		if err != nil {
+			// unlikely to happen
+			continue
+		}
+
+		res = append(res, string(b))
+	}
+
+	return res
+}
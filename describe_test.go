@@ -0,0 +1,458 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Unit Unit
+		Err  error
+	}{
+		"Invalid Unit": {
+			Err: ErrInvalidUnit,
+		},
+		"Successful UnitPrice validation": {
+			Unit: UnitPrice,
+		},
+		"Successful UnitPercent validation": {
+			Unit: UnitPercent,
+		},
+		"Successful UnitBounded validation": {
+			Unit: UnitBounded,
+		},
+		"Successful UnitUnbounded validation": {
+			Unit: UnitUnbounded,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Unit.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Unit_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Unit Unit
+		Text string
+		Err  error
+	}{
+		"Invalid Unit": {
+			Err: ErrInvalidUnit,
+		},
+		"Successful UnitPrice marshal": {
+			Unit: UnitPrice,
+			Text: "price",
+		},
+		"Successful UnitPercent marshal": {
+			Unit: UnitPercent,
+			Text: "percent",
+		},
+		"Successful UnitBounded marshal": {
+			Unit: UnitBounded,
+			Text: "bounded",
+		},
+		"Successful UnitUnbounded marshal": {
+			Unit: UnitUnbounded,
+			Text: "unbounded",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Unit.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Unit_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Unit
+		Err    error
+	}{
+		"Invalid Unit": {
+			Err: ErrInvalidUnit,
+		},
+		"Successful UnitPrice unmarshal": {
+			Text:   "price",
+			Result: UnitPrice,
+		},
+		"Successful UnitPercent unmarshal": {
+			Text:   "percent",
+			Result: UnitPercent,
+		},
+		"Successful UnitBounded unmarshal": {
+			Text:   "bounded",
+			Result: UnitBounded,
+		},
+		"Successful UnitUnbounded unmarshal": {
+			Text:   "unbounded",
+			Result: UnitUnbounded,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var u Unit
+			err := u.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, u)
+		})
+	}
+}
+
+func Test_ParamType_Validate(t *testing.T) {
+	cc := map[string]struct {
+		ParamType ParamType
+		Err       error
+	}{
+		"Invalid ParamType": {
+			Err: ErrInvalidParamType,
+		},
+		"Successful ParamTypeInt validation": {
+			ParamType: ParamTypeInt,
+		},
+		"Successful ParamTypeDecimal validation": {
+			ParamType: ParamTypeDecimal,
+		},
+		"Successful ParamTypeBool validation": {
+			ParamType: ParamTypeBool,
+		},
+		"Successful ParamTypeTrend validation": {
+			ParamType: ParamTypeTrend,
+		},
+		"Successful ParamTypeBand validation": {
+			ParamType: ParamTypeBand,
+		},
+		"Successful ParamTypeMA validation": {
+			ParamType: ParamTypeMA,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.ParamType.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_ParamType_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		ParamType ParamType
+		Text      string
+		Err       error
+	}{
+		"Invalid ParamType": {
+			Err: ErrInvalidParamType,
+		},
+		"Successful ParamTypeInt marshal": {
+			ParamType: ParamTypeInt,
+			Text:      "int",
+		},
+		"Successful ParamTypeDecimal marshal": {
+			ParamType: ParamTypeDecimal,
+			Text:      "decimal",
+		},
+		"Successful ParamTypeBool marshal": {
+			ParamType: ParamTypeBool,
+			Text:      "bool",
+		},
+		"Successful ParamTypeTrend marshal": {
+			ParamType: ParamTypeTrend,
+			Text:      "trend",
+		},
+		"Successful ParamTypeBand marshal": {
+			ParamType: ParamTypeBand,
+			Text:      "band",
+		},
+		"Successful ParamTypeMA marshal": {
+			ParamType: ParamTypeMA,
+			Text:      "ma",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.ParamType.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_ParamType_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result ParamType
+		Err    error
+	}{
+		"Invalid ParamType": {
+			Err: ErrInvalidParamType,
+		},
+		"Successful ParamTypeInt unmarshal": {
+			Text:   "int",
+			Result: ParamTypeInt,
+		},
+		"Successful ParamTypeDecimal unmarshal": {
+			Text:   "decimal",
+			Result: ParamTypeDecimal,
+		},
+		"Successful ParamTypeBool unmarshal": {
+			Text:   "bool",
+			Result: ParamTypeBool,
+		},
+		"Successful ParamTypeTrend unmarshal": {
+			Text:   "trend",
+			Result: ParamTypeTrend,
+		},
+		"Successful ParamTypeBand unmarshal": {
+			Text:   "band",
+			Result: ParamTypeBand,
+		},
+		"Successful ParamTypeMA unmarshal": {
+			Text:   "ma",
+			Result: ParamTypeMA,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var pt ParamType
+			err := pt.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, pt)
+		})
+	}
+}
+
+func Test_Descriptions(t *testing.T) {
+	dd := Descriptions()
+
+	names := make([]string, len(dd))
+	for i := range dd {
+		names[i] = dd[i].Name
+	}
+
+	assert.Equal(t, []string{
+		"aroon", "bb", "cci", "dema", "ema", "hma", "roc", "rsi", "sma",
+		"srsi", "stoch", "wma",
+	}, names)
+}
+
+func Test_Describe(t *testing.T) {
+	cc := map[string]struct {
+		Describer Describer
+		Name      string
+		Params    []string
+		Unit      Unit
+		Overlay   bool
+	}{
+		"Aroon": {
+			Describer: Aroon{},
+			Name:      "aroon",
+			Params:    []string{"trend", "length"},
+			Unit:      UnitBounded,
+		},
+		"BB with price output": {
+			Describer: BB{band: BandLower},
+			Name:      "bb",
+			Params:    []string{"percent", "band", "std_dev", "length"},
+			Unit:      UnitPrice,
+			Overlay:   true,
+		},
+		"BB with percent output": {
+			Describer: BB{percent: true, band: BandLower},
+			Name:      "bb",
+			Params:    []string{"percent", "band", "std_dev", "length"},
+			Unit:      UnitPercent,
+		},
+		"BB with width output": {
+			Describer: BB{band: BandWidth},
+			Name:      "bb",
+			Params:    []string{"percent", "band", "std_dev", "length"},
+			Unit:      UnitPercent,
+		},
+		"CCI": {
+			Describer: CCI{},
+			Name:      "cci",
+			Params:    []string{"ma", "length", "factor"},
+			Unit:      UnitUnbounded,
+		},
+		"DEMA": {
+			Describer: DEMA{},
+			Name:      "dema",
+			Params:    []string{"length"},
+			Unit:      UnitPrice,
+			Overlay:   true,
+		},
+		"EMA": {
+			Describer: EMA{},
+			Name:      "ema",
+			Params:    []string{"length"},
+			Unit:      UnitPrice,
+			Overlay:   true,
+		},
+		"HMA": {
+			Describer: HMA{},
+			Name:      "hma",
+			Params:    []string{"length"},
+			Unit:      UnitPrice,
+			Overlay:   true,
+		},
+		"ROC": {
+			Describer: ROC{},
+			Name:      "roc",
+			Params:    []string{"length"},
+			Unit:      UnitPercent,
+		},
+		"RSI": {
+			Describer: RSI{},
+			Name:      "rsi",
+			Params:    []string{"length"},
+			Unit:      UnitBounded,
+		},
+		"SMA": {
+			Describer: SMA{},
+			Name:      "sma",
+			Params:    []string{"length"},
+			Unit:      UnitPrice,
+			Overlay:   true,
+		},
+		"SRSI": {
+			Describer: SRSI{},
+			Name:      "srsi",
+			Params:    []string{"length"},
+			Unit:      UnitBounded,
+		},
+		"Stoch": {
+			Describer: Stoch{},
+			Name:      "stoch",
+			Params:    []string{"length"},
+			Unit:      UnitBounded,
+		},
+		"WMA": {
+			Describer: WMA{},
+			Name:      "wma",
+			Params:    []string{"length"},
+			Unit:      UnitPrice,
+			Overlay:   true,
+		},
+		"Ordered with describer": {
+			Describer: Ordered{ind: RSI{}},
+			Name:      "rsi",
+			Params:    []string{"length"},
+			Unit:      UnitBounded,
+		},
+		"Ordered without describer": {
+			Describer: Ordered{},
+			Params:    []string{},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := c.Describer.Describe()
+
+			params := make([]string, len(res.Params))
+			for i := range res.Params {
+				params[i] = res.Params[i].Name
+			}
+
+			assert.Equal(t, c.Name, res.Name)
+			assert.Equal(t, c.Params, params)
+			assert.Equal(t, c.Unit, res.Unit)
+			assert.Equal(t, c.Overlay, res.Overlay)
+
+			if res.Unit == UnitBounded {
+				assert.True(t, res.Min.Valid)
+				assert.True(t, res.Max.Valid)
+			}
+		})
+	}
+}
+
+func Test_CCI_Describe_Options(t *testing.T) {
+	desc := CCI{}.Describe()
+
+	assert.Equal(t, []string{"dema", "ema", "hma", "sma", "wma"}, desc.Params[0].Options)
+	assert.Equal(t, MATypeSMA, desc.Params[0].Default)
+	assert.Equal(t, "0.015", desc.Params[2].Default.(decimal.Decimal).String())
+}
+
+func Test_BB_Describe_StdDev(t *testing.T) {
+	desc := BB{}.Describe()
+
+	assert.Equal(t, Param{
+		Name:    "std_dev",
+		Type:    ParamTypeDecimal,
+		Default: decimal.NewFromInt(2),
+	}, desc.Params[2])
+}
+
+func Test_lengthParam(t *testing.T) {
+	assert.Equal(t, Param{
+		Name:    "length",
+		Type:    ParamTypeInt,
+		Default: 5,
+		Min:     decimal.NullDecimal{Decimal: _one, Valid: true},
+	}, lengthParam(5))
+}
+
+func Test_textNames(t *testing.T) {
+	assert.Equal(t, []string{"up", "down"}, textNames(TrendUp, TrendDown))
+	assert.Equal(t, []string{"upper"}, textNames(BandUpper, Band(70)))
+}
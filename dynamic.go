@@ -0,0 +1,273 @@
+package indc
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// LengthSource holds all the necessary information needed to determine
+// the length of an indicator from the value of another indicator, e.g.
+// DominantCycle.
+// The zero value is not usable.
+type LengthSource struct {
+	// valid specifies whether LengthSource paremeters were validated.
+	valid bool
+
+	// ind specifies the indicator that determines the length.
+	ind Indicator
+
+	// min specifies the lowest allowed length.
+	min int
+
+	// max specifies the highest allowed length.
+	max int
+}
+
+// NewLengthSource validates provided configuration options and
+// creates new LengthSource. Values of the indicator are rounded to the
+// nearest integer and clamped between min and max.
+func NewLengthSource(ind Indicator, min, max int) (LengthSource, error) {
+	ls := LengthSource{
+		ind: ind,
+		min: min,
+		max: max,
+	}
+
+	if err := ls.validate(); err != nil {
+		return LengthSource{}, err
+	}
+
+	return ls, nil
+}
+
+// validate checks whether the length source has valid configuration
+// properties.
+func (ls *LengthSource) validate() error {
+	if ls.ind == nil {
+		return &ValidationError{
+			Indicator:  "length source",
+			Param:      "indicator",
+			Value:      ls.ind,
+			Constraint: "must not be nil",
+			Err:        ErrInvalidIndicator,
+		}
+	}
+
+	if ls.min < 1 {
+		return &ValidationError{
+			Indicator:  "length source",
+			Param:      "min",
+			Value:      ls.min,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	if ls.max < ls.min {
+		return &ValidationError{
+			Indicator:  "length source",
+			Param:      "max",
+			Value:      ls.max,
+			Constraint: "must not be lower than min",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	ls.valid = true
+
+	return nil
+}
+
+// Length calculates the length from the newest data points of the
+// provided data points slice. The slice may be longer than Count.
+func (ls LengthSource) Length(dd []decimal.Decimal) (int, error) {
+	if !ls.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	count := ls.Count()
+	if len(dd) < count {
+		return 0, &DataSizeError{Expected: count, Actual: len(dd)}
+	}
+
+	v, err := ls.ind.Calc(dd[len(dd)-count:])
+	if err != nil {
+		return 0, err
+	}
+
+	switch res := v.Round(0); {
+	case res.LessThan(decimal.NewFromInt(int64(ls.min))):
+		return ls.min, nil
+	case res.GreaterThan(decimal.NewFromInt(int64(ls.max))):
+		return ls.max, nil
+	default:
+		return int(res.IntPart()), nil
+	}
+}
+
+// Count determines the total amount of data points needed for the length
+// calculation.
+func (ls LengthSource) Count() int {
+	if !ls.valid {
+		return 0
+	}
+
+	return ls.ind.Count()
+}
+
+// Dynamic holds all the necessary information needed to calculate an
+// indicator whose length is determined by a LengthSource at every
+// calculation.
+// The zero value is not usable.
+type Dynamic struct {
+	// valid specifies whether Dynamic paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// src specifies how the length is determined.
+	src LengthSource
+
+	// build specifies how the indicator of the given length is created
+	// with the provided options.
+	build func(length int, opts []Option) (Indicator, error)
+
+	// count specifies the total amount of data points needed in the
+	// worst case.
+	count int
+}
+
+// NewDynamicSMA validates provided configuration options and
+// creates new SMA indicator with the length determined by the length
+// source.
+func NewDynamicSMA(src LengthSource, opts ...Option) (Dynamic, error) {
+	return newDynamic("sma", src, opts, func(length int, opts []Option) (Indicator, error) {
+		return NewSMA(length, opts...)
+	})
+}
+
+// NewDynamicEMA validates provided configuration options and
+// creates new EMA indicator with the length determined by the length
+// source.
+func NewDynamicEMA(src LengthSource, opts ...Option) (Dynamic, error) {
+	return newDynamic("ema", src, opts, func(length int, opts []Option) (Indicator, error) {
+		return NewEMA(length, opts...)
+	})
+}
+
+// NewDynamicRSI validates provided configuration options and
+// creates new RSI indicator with the length determined by the length
+// source.
+func NewDynamicRSI(src LengthSource, opts ...Option) (Dynamic, error) {
+	return newDynamic("rsi", src, opts, func(length int, opts []Option) (Indicator, error) {
+		return NewRSI(length, opts...)
+	})
+}
+
+// NewDynamicStoch validates provided configuration options and
+// creates new Stoch indicator with the length determined by the length
+// source.
+func NewDynamicStoch(src LengthSource, opts ...Option) (Dynamic, error) {
+	return newDynamic("stoch", src, opts, func(length int, opts []Option) (Indicator, error) {
+		return NewStoch(length, opts...)
+	})
+}
+
+// NewDynamicBB validates provided configuration options and
+// creates new BB indicator with the length determined by the length
+// source. See NewBB for details.
+func NewDynamicBB(percent bool, band Band, stdDev decimal.Decimal, src LengthSource, opts ...Option) (Dynamic, error) {
+	return newDynamic("bb", src, opts, func(length int, opts []Option) (Indicator, error) {
+		return NewBB(percent, band, stdDev, length, opts...)
+	})
+}
+
+// newDynamic validates provided configuration options and creates new
+// Dynamic indicator.
+func newDynamic(name string, src LengthSource, opts []Option, build func(int, []Option) (Indicator, error)) (Dynamic, error) {
+	d := Dynamic{
+		cfg:   newConfig(opts),
+		src:   src,
+		build: build,
+	}
+
+	if err := d.validate(name); err != nil {
+		return Dynamic{}, err
+	}
+
+	return d, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (d *Dynamic) validate(name string) error {
+	if err := d.cfg.validate(name); err != nil {
+		return err
+	}
+
+	if !d.src.valid {
+		return &ValidationError{
+			Indicator:  name,
+			Param:      "length source",
+			Value:      d.src,
+			Constraint: "must be created with NewLengthSource",
+			Err:        ErrInvalidIndicator,
+		}
+	}
+
+	// the indicator with the longest length needs the most data points.
+	ind, err := d.build(d.src.max, d.opts())
+	if err != nil {
+		return err
+	}
+
+	d.count = ind.Count()
+	if c := d.src.Count(); c > d.count {
+		d.count = c
+	}
+
+	d.valid = true
+
+	return nil
+}
+
+// Calc calculates the indicator from the provided data points slice.
+// The length is determined from the newest data points first and the
+// indicator of that length is then calculated from the newest data
+// points as well.
+func (d Dynamic) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !d.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(d.cfg, dd, d.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	length, err := d.src.Length(dd)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	ind, err := d.build(length, d.opts())
+	if err != nil {
+		// unlikely to happen
+		return decimal.Zero, err
+	}
+
+	return ind.Calc(dd[len(dd)-ind.Count():])
+}
+
+// opts returns options that make built indicators use the configuration
+// of the Dynamic, so that all of them share the same previous value of
+// ZeroPolicyPrevious.
+func (d Dynamic) opts() []Option {
+	return []Option{withConfig(d.cfg)}
+}
+
+// Count determines the total amount of data points needed for Dynamic
+// calculation, i.e. the amount needed by the length source or by the
+// indicator with the maximum length, whichever is greater.
+func (d Dynamic) Count() int {
+	return d.count
+}
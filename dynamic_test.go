@@ -0,0 +1,369 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newest returns a length source that uses the newest data point as the
+// length, clamped between min and max.
+func newest(t *testing.T, min, max int) LengthSource {
+	t.Helper()
+
+	sma, err := NewSMA(1)
+	require.NoError(t, err)
+
+	ls, err := NewLengthSource(sma, min, max)
+	require.NoError(t, err)
+
+	return ls
+}
+
+func Test_NewLengthSource(t *testing.T) {
+	sma := SMA{valid: true, length: 1}
+
+	cc := map[string]struct {
+		Indicator Indicator
+		Min       int
+		Max       int
+		Error     error
+	}{
+		"Invalid indicator": {
+			Min: 1,
+			Max: 2,
+			Error: &ValidationError{
+				Indicator:  "length source",
+				Param:      "indicator",
+				Value:      nil,
+				Constraint: "must not be nil",
+				Err:        ErrInvalidIndicator,
+			},
+		},
+		"Invalid min": {
+			Indicator: sma,
+			Max:       2,
+			Error: &ValidationError{
+				Indicator:  "length source",
+				Param:      "min",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Invalid max": {
+			Indicator: sma,
+			Min:       3,
+			Max:       2,
+			Error: &ValidationError{
+				Indicator:  "length source",
+				Param:      "max",
+				Value:      2,
+				Constraint: "must not be lower than min",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully created new LengthSource": {
+			Indicator: sma,
+			Min:       2,
+			Max:       2,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewLengthSource(c.Indicator, c.Min, c.Max)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Indicator, res.ind)
+			assert.Equal(t, c.Min, res.min)
+			assert.Equal(t, c.Max, res.max)
+		})
+	}
+}
+
+func Test_LengthSource_Length(t *testing.T) {
+	cc := map[string]struct {
+		LengthSource LengthSource
+		Data         []decimal.Decimal
+		Result       int
+		Error        error
+	}{
+		"Invalid length source": {
+			Data:  ratios("3"),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			LengthSource: newest(t, 2, 5),
+			Data:         ratios(),
+			Error:        &DataSizeError{Expected: 1, Actual: 0},
+		},
+		"Invalid indicator calculation": {
+			LengthSource: LengthSource{
+				valid: true,
+				ind:   Stoch{valid: true, cfg: config{zero: ZeroPolicyError}, length: 2},
+				min:   2,
+				max:   5,
+			},
+			Data:  ratios("3", "3"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated length lower than min": {
+			LengthSource: newest(t, 2, 5),
+			Data:         ratios("0.4"),
+			Result:       2,
+		},
+		"Successfully calculated length greater than max": {
+			LengthSource: newest(t, 2, 5),
+			Data:         ratios("100"),
+			Result:       5,
+		},
+		"Successfully calculated rounded length": {
+			LengthSource: newest(t, 2, 5),
+			Data:         ratios("1", "3.5"),
+			Result:       4,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.LengthSource.Length(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_LengthSource_Count(t *testing.T) {
+	assert.Zero(t, LengthSource{}.Count())
+	assert.Equal(t, 100, LengthSource{valid: true, ind: DominantCycle{}}.Count())
+}
+
+func Test_NewDynamic(t *testing.T) {
+	src := newest(t, 2, 5)
+
+	cc := map[string]struct {
+		Create func() (Dynamic, error)
+		Count  int
+		Error  error
+	}{
+		"Invalid config": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicSMA(src, WithPrecision(2, 70))
+			},
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid length source": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicRSI(LengthSource{})
+			},
+			Error: &ValidationError{
+				Indicator:  "rsi",
+				Param:      "length source",
+				Value:      LengthSource{},
+				Constraint: "must be created with NewLengthSource",
+				Err:        ErrInvalidIndicator,
+			},
+		},
+		"Invalid BB band": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicBB(true, BandWidth, decimal.NewFromInt(2), src)
+			},
+			Error: &ValidationError{
+				Indicator:  "bb",
+				Param:      "band",
+				Value:      BandWidth,
+				Constraint: "must not be width when percent is enabled",
+				Err:        ErrInvalidBand,
+			},
+		},
+		"Successfully created new dynamic SMA": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicSMA(src)
+			},
+			Count: 5,
+		},
+		"Successfully created new dynamic EMA": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicEMA(src)
+			},
+			Count: 9,
+		},
+		"Successfully created new dynamic RSI": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicRSI(src)
+			},
+			Count: 5,
+		},
+		"Successfully created new dynamic Stoch": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicStoch(src)
+			},
+			Count: 5,
+		},
+		"Successfully created new dynamic BB": {
+			Create: func() (Dynamic, error) {
+				return NewDynamicBB(false, BandUpper, decimal.NewFromInt(2), src)
+			},
+			Count: 5,
+		},
+		"Successfully created new Dynamic with longer length source": {
+			Create: func() (Dynamic, error) {
+				sma, err := NewSMA(7)
+				if err != nil {
+					return Dynamic{}, err
+				}
+
+				ls, err := NewLengthSource(sma, 2, 5)
+				if err != nil {
+					return Dynamic{}, err
+				}
+
+				return NewDynamicStoch(ls)
+			},
+			Count: 7,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Create()
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Count, res.Count())
+		})
+	}
+}
+
+func Test_Dynamic_Calc(t *testing.T) {
+	src := newest(t, 2, 5)
+
+	dynamic := func(d Dynamic, err error) Dynamic {
+		require.NoError(t, err)
+		return d
+	}
+
+	cc := map[string]struct {
+		Dynamic Dynamic
+		Data    []decimal.Decimal
+		Result  float64
+		Error   error
+	}{
+		"Invalid indicator": {
+			Data:  ratios("8", "2", "6", "9", "4"),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Dynamic: dynamic(NewDynamicSMA(src)),
+			Data:    ratios("2", "6", "9", "4"),
+			Error:   &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Invalid length source calculation": {
+			Dynamic: dynamic(NewDynamicSMA(LengthSource{
+				valid: true,
+				ind:   Stoch{valid: true, cfg: config{zero: ZeroPolicyError}, length: 2},
+				min:   2,
+				max:   5,
+			})),
+			Data:  ratios("8", "2", "6", "4", "4"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated dynamic SMA": {
+			Dynamic: dynamic(NewDynamicSMA(src)),
+			Data:    ratios("8", "2", "6", "9", "4"),
+			Result:  5.25,
+		},
+		"Successfully calculated dynamic SMA with lenient window": {
+			Dynamic: dynamic(NewDynamicSMA(src, WithLenient())),
+			Data:    ratios("1", "3", "5", "7", "8", "2", "6", "9", "4"),
+			Result:  5.25,
+		},
+		"Successfully calculated dynamic SMA with the maximum length": {
+			Dynamic: dynamic(NewDynamicSMA(src)),
+			Data:    ratios("8", "2", "6", "9", "40"),
+			Result:  13,
+		},
+		"Successfully calculated dynamic EMA": {
+			Dynamic: dynamic(NewDynamicEMA(src)),
+			Data:    ratios("1", "3", "5", "7", "8", "2", "6", "9", "4"),
+			Result:  5.812,
+		},
+		"Successfully calculated dynamic RSI": {
+			Dynamic: dynamic(NewDynamicRSI(src)),
+			Data:    ratios("8", "2", "6", "9", "4"),
+			Result:  58.333333333333333,
+		},
+		"Successfully calculated dynamic Stoch": {
+			Dynamic: dynamic(NewDynamicStoch(src)),
+			Data:    ratios("8", "2", "6", "9", "4"),
+			Result:  28.571428571428571,
+		},
+		"Successfully calculated dynamic BB": {
+			Dynamic: dynamic(NewDynamicBB(false, BandUpper, decimal.NewFromInt(2), src)),
+			Data:    ratios("8", "2", "6", "9", "4"),
+			Result:  10.422040216394,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Dynamic.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Dynamic_Calc_ZeroPolicyPrevious(t *testing.T) {
+	d, err := NewDynamicStoch(newest(t, 2, 5), WithZeroPolicy(ZeroPolicyPrevious))
+	require.NoError(t, err)
+
+	exp, err := d.Calc(ratios("8", "2", "6", "9", "4"))
+	require.NoError(t, err)
+	assert.False(t, exp.IsZero())
+
+	res, err := d.Calc(ratios("3", "3", "3", "3", "3"))
+	require.NoError(t, err)
+	assert.Equal(t, exp.String(), res.String())
+}
@@ -0,0 +1,743 @@
+package indc
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// Bounds of the dominant cycle period, as used by John Ehlers.
+const (
+	// CycleMin specifies the shortest dominant cycle period.
+	CycleMin = 6
+
+	// CycleMax specifies the longest dominant cycle period.
+	CycleMax = 50
+)
+
+// _hilbertCount specifies how many data points Hilbert transform based
+// indicators need, so that the initial state of their recursive
+// smoothing decays.
+const _hilbertCount = 100
+
+// SuperSmoother holds all the necessary information needed to calculate
+// Ehlers' two-pole Super Smoother filter.
+// The zero value is not usable.
+type SuperSmoother struct {
+	// valid specifies whether SuperSmoother paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// length specifies the critical period of the filter.
+	length int
+}
+
+// NewSuperSmoother validates provided configuration options and
+// creates new SuperSmoother indicator.
+func NewSuperSmoother(length int, opts ...Option) (SuperSmoother, error) {
+	ss := SuperSmoother{
+		cfg:    newConfig(opts),
+		length: length,
+	}
+
+	if err := ss.validate(); err != nil {
+		return SuperSmoother{}, err
+	}
+
+	return ss, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (ss *SuperSmoother) validate() error {
+	if err := ss.cfg.validate("supersmoother"); err != nil {
+		return err
+	}
+
+	if ss.length < 2 {
+		return &ValidationError{
+			Indicator:  "supersmoother",
+			Param:      "length",
+			Value:      ss.length,
+			Constraint: "must be greater than 1",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	ss.valid = true
+
+	return nil
+}
+
+// Calc calculates Super Smoother filter from the provided data points
+// slice. Calculation is based on formula provided by John Ehlers in
+// "Cycle Analytics for Traders".
+func (ss SuperSmoother) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !ss.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(ss.cfg, dd, ss.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	ff := superSmooth(floats(dd), ss.length)
+
+	return ss.cfg.round(decimal.NewFromFloat(ff[len(ff)-1])), nil
+}
+
+// Count determines the total amount of data points needed for
+// SuperSmoother calculation.
+func (ss SuperSmoother) Count() int {
+	return ss.length * 4
+}
+
+// Roofing holds all the necessary information needed to calculate
+// Ehlers' Roofing filter, i.e. a high-pass filter followed by Super
+// Smoother filter.
+// The zero value is not usable.
+type Roofing struct {
+	// valid specifies whether Roofing paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// high specifies the critical period of the high-pass filter.
+	high int
+
+	// low specifies the critical period of Super Smoother filter.
+	low int
+}
+
+// NewRoofing validates provided configuration options and
+// creates new Roofing indicator. Cycles longer than high and shorter
+// than low lengths are filtered out, e.g. 48 and 10.
+func NewRoofing(high, low int, opts ...Option) (Roofing, error) {
+	r := Roofing{
+		cfg:  newConfig(opts),
+		high: high,
+		low:  low,
+	}
+
+	if err := r.validate(); err != nil {
+		return Roofing{}, err
+	}
+
+	return r, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (r *Roofing) validate() error {
+	if err := r.cfg.validate("roofing"); err != nil {
+		return err
+	}
+
+	if r.low < 2 {
+		return &ValidationError{
+			Indicator:  "roofing",
+			Param:      "low length",
+			Value:      r.low,
+			Constraint: "must be greater than 1",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	if r.high <= r.low {
+		return &ValidationError{
+			Indicator:  "roofing",
+			Param:      "high length",
+			Value:      r.high,
+			Constraint: "must be greater than low length",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	r.valid = true
+
+	return nil
+}
+
+// Calc calculates Roofing filter from the provided data points slice.
+// The result oscillates around zero. Calculation is based on formula
+// provided by John Ehlers in "Cycle Analytics for Traders".
+func (r Roofing) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !r.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(r.cfg, dd, r.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	ff := superSmooth(highPass(floats(dd), r.high), r.low)
+
+	return r.cfg.round(decimal.NewFromFloat(ff[len(ff)-1])), nil
+}
+
+// Count determines the total amount of data points needed for Roofing
+// calculation.
+func (r Roofing) Count() int {
+	return r.high * 4
+}
+
+// Trendline holds all the necessary information needed to calculate
+// Ehlers' Instantaneous Trendline.
+// The zero value is not usable.
+type Trendline struct {
+	// valid specifies whether Trendline paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// alpha specifies the smoothing factor.
+	alpha decimal.Decimal
+}
+
+// NewTrendline validates provided configuration options and
+// creates new Trendline indicator. Alpha specifies the smoothing
+// factor, e.g. 0.07.
+func NewTrendline(alpha decimal.Decimal, opts ...Option) (Trendline, error) {
+	t := Trendline{
+		cfg:   newConfig(opts),
+		alpha: alpha,
+	}
+
+	if err := t.validate(); err != nil {
+		return Trendline{}, err
+	}
+
+	return t, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (t *Trendline) validate() error {
+	if err := t.cfg.validate("trendline"); err != nil {
+		return err
+	}
+
+	if !t.alpha.IsPositive() || !t.alpha.LessThan(_one) {
+		return &ValidationError{
+			Indicator:  "trendline",
+			Param:      "alpha",
+			Value:      t.alpha,
+			Constraint: "must be greater than 0 and less than 1",
+			Err:        ErrInvalidFactor,
+		}
+	}
+
+	t.valid = true
+
+	return nil
+}
+
+// Calc calculates Instantaneous Trendline from the provided data points
+// slice. Calculation is based on formula provided by John Ehlers in
+// "Cybernetic Analysis for Stocks and Futures".
+func (t Trendline) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !t.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(t.cfg, dd, t.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	a, _ := t.alpha.Float64()
+	ff := floats(dd)
+	res := make([]float64, len(ff))
+
+	for i := range ff {
+		switch {
+		case i < 2:
+			res[i] = ff[i]
+		case i < 7:
+			res[i] = (ff[i] + 2*ff[i-1] + ff[i-2]) / 4
+		default:
+			res[i] = (a-a*a/4)*ff[i] + a*a/2*ff[i-1] - (a-a*a*3/4)*ff[i-2] +
+				2*(1-a)*res[i-1] - (1-a)*(1-a)*res[i-2]
+		}
+	}
+
+	return t.cfg.round(decimal.NewFromFloat(res[len(res)-1])), nil
+}
+
+// Count determines the total amount of data points needed for Trendline
+// calculation.
+func (t Trendline) Count() int {
+	a, _ := t.alpha.Float64()
+
+	res := int(math.Ceil(4 / a))
+	if res < 7 {
+		return 7
+	}
+
+	return res
+}
+
+// MAMA holds all the necessary information needed to calculate Ehlers'
+// MESA Adaptive Moving Average.
+// The zero value is not usable.
+type MAMA struct {
+	// valid specifies whether MAMA paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// fast specifies the highest smoothing factor.
+	fast decimal.Decimal
+
+	// slow specifies the lowest smoothing factor.
+	slow decimal.Decimal
+}
+
+// NewMAMA validates provided configuration options and
+// creates new MAMA indicator. Fast and slow specify the limits of the
+// adaptive smoothing factor, e.g. 0.5 and 0.05.
+func NewMAMA(fast, slow decimal.Decimal, opts ...Option) (MAMA, error) {
+	m := MAMA{
+		cfg:  newConfig(opts),
+		fast: fast,
+		slow: slow,
+	}
+
+	if err := m.validate(); err != nil {
+		return MAMA{}, err
+	}
+
+	return m, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (m *MAMA) validate() error {
+	if err := m.cfg.validate("mama"); err != nil {
+		return err
+	}
+
+	if !m.slow.IsPositive() {
+		return &ValidationError{
+			Indicator:  "mama",
+			Param:      "slow limit",
+			Value:      m.slow,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidFactor,
+		}
+	}
+
+	if !m.fast.GreaterThan(m.slow) || m.fast.GreaterThan(_one) {
+		return &ValidationError{
+			Indicator:  "mama",
+			Param:      "fast limit",
+			Value:      m.fast,
+			Constraint: "must be greater than slow limit and not greater than 1",
+			Err:        ErrInvalidFactor,
+		}
+	}
+
+	m.valid = true
+
+	return nil
+}
+
+// Calc calculates MAMA from the provided data points slice.
+// Calculation is based on formula provided by John Ehlers in
+// "MESA Adaptive Moving Averages".
+// https://www.mesasoftware.com/papers/MAMA.pdf.
+func (m MAMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !m.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(m.cfg, dd, m.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	res, _ := m.lines(floats(dd))
+
+	return m.cfg.round(decimal.NewFromFloat(res)), nil
+}
+
+// lines calculates the newest values of MAMA and FAMA.
+func (m MAMA) lines(ff []float64) (float64, float64) {
+	fast, _ := m.fast.Float64()
+	slow, _ := m.slow.Float64()
+
+	hh := hilbertTransform(ff)
+	mama, fama := ff[0], ff[0]
+
+	for i := 1; i < len(ff); i++ {
+		delta := hh[i-1].phase - hh[i].phase
+		if delta < 1 {
+			delta = 1
+		}
+
+		alpha := fast / delta
+		if alpha < slow {
+			alpha = slow
+		}
+
+		mama = alpha*ff[i] + (1-alpha)*mama
+		fama = alpha/2*mama + (1-alpha/2)*fama
+	}
+
+	return mama, fama
+}
+
+// Count determines the total amount of data points needed for MAMA
+// calculation.
+func (m MAMA) Count() int {
+	return _hilbertCount
+}
+
+// FAMA holds all the necessary information needed to calculate Ehlers'
+// Following Adaptive Moving Average, i.e. the signal line of MAMA.
+// The zero value is not usable.
+type FAMA struct {
+	// valid specifies whether FAMA paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// mama specifies the adaptive moving average that is followed.
+	mama MAMA
+}
+
+// NewFAMA validates provided configuration options and
+// creates new FAMA indicator. See NewMAMA for details.
+func NewFAMA(fast, slow decimal.Decimal, opts ...Option) (FAMA, error) {
+	mama, err := NewMAMA(fast, slow, opts...)
+	if err != nil {
+		return FAMA{}, relabel(err, "fama")
+	}
+
+	return FAMA{
+		valid: true,
+		cfg:   mama.cfg,
+		mama:  mama,
+	}, nil
+}
+
+// Calc calculates FAMA from the provided data points slice.
+func (f FAMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !f.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(f.cfg, dd, f.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	_, res := f.mama.lines(floats(dd))
+
+	return f.cfg.round(decimal.NewFromFloat(res)), nil
+}
+
+// Count determines the total amount of data points needed for FAMA
+// calculation.
+func (f FAMA) Count() int {
+	return f.mama.Count()
+}
+
+// Fisher holds all the necessary information needed to calculate
+// Ehlers' Fisher Transform.
+// The zero value is not usable.
+type Fisher struct {
+	// valid specifies whether Fisher paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// length specifies how many data points should be used to
+	// normalize the values.
+	length int
+}
+
+// NewFisher validates provided configuration options and
+// creates new Fisher indicator.
+func NewFisher(length int, opts ...Option) (Fisher, error) {
+	f := Fisher{
+		cfg:    newConfig(opts),
+		length: length,
+	}
+
+	if err := f.validate(); err != nil {
+		return Fisher{}, err
+	}
+
+	return f, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (f *Fisher) validate() error {
+	if err := f.cfg.validate("fisher"); err != nil {
+		return err
+	}
+
+	if f.length < 2 {
+		return &ValidationError{
+			Indicator:  "fisher",
+			Param:      "length",
+			Value:      f.length,
+			Constraint: "must be greater than 1",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	f.valid = true
+
+	return nil
+}
+
+// Calc calculates Fisher Transform from the provided data points slice.
+// Values are normalized between the lowest and the highest values of
+// the last length data points; a window of equal values is treated as
+// neutral. Calculation is based on formula provided by John Ehlers in
+// "Using The Fisher Transform".
+// https://www.mesasoftware.com/papers/UsingTheFisherTransform.pdf.
+func (f Fisher) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !f.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(f.cfg, dd, f.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	ff := floats(dd)
+
+	var value, res float64
+
+	for i := f.length - 1; i < len(ff); i++ {
+		lo, hi := math.Inf(1), math.Inf(-1)
+
+		for _, v := range ff[i-f.length+1 : i+1] {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+
+		var norm float64
+		if hi > lo {
+			norm = (ff[i]-lo)/(hi-lo) - 0.5
+		}
+
+		value = math.Max(-0.999, math.Min(0.999, 0.66*norm+0.67*value))
+		res = 0.5*math.Log((1+value)/(1-value)) + 0.5*res
+	}
+
+	return f.cfg.round(decimal.NewFromFloat(res)), nil
+}
+
+// Count determines the total amount of data points needed for Fisher
+// calculation.
+func (f Fisher) Count() int {
+	return f.length * 3
+}
+
+// DominantCycle holds all the necessary information needed to calculate
+// the dominant cycle period with Ehlers' Hilbert transform homodyne
+// discriminator.
+// The zero value is not usable.
+type DominantCycle struct {
+	// valid specifies whether DominantCycle paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+}
+
+// NewDominantCycle validates provided configuration options and
+// creates new DominantCycle indicator.
+func NewDominantCycle(opts ...Option) (DominantCycle, error) {
+	dc := DominantCycle{
+		cfg: newConfig(opts),
+	}
+
+	if err := dc.validate(); err != nil {
+		return DominantCycle{}, err
+	}
+
+	return dc, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (dc *DominantCycle) validate() error {
+	if err := dc.cfg.validate("dcperiod"); err != nil {
+		return err
+	}
+
+	dc.valid = true
+
+	return nil
+}
+
+// Calc calculates the dominant cycle period, between CycleMin and
+// CycleMax, from the provided data points slice.
+// Calculation is based on formula provided by John Ehlers in
+// "MESA Adaptive Moving Averages".
+// https://www.mesasoftware.com/papers/MAMA.pdf.
+func (dc DominantCycle) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !dc.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(dc.cfg, dd, dc.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	hh := hilbertTransform(floats(dd))
+
+	return dc.cfg.round(decimal.NewFromFloat(hh[len(hh)-1].smoothPeriod)), nil
+}
+
+// Length calculates the dominant cycle period from the provided data
+// points slice, rounded to the nearest integer, so that it can be used
+// as the length of other indicators, e.g. NewRSI(length / 2).
+func (dc DominantCycle) Length(dd []decimal.Decimal) (int, error) {
+	res, err := dc.Calc(dd)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(res.Round(0).IntPart()), nil
+}
+
+// Count determines the total amount of data points needed for
+// DominantCycle calculation.
+func (dc DominantCycle) Count() int {
+	return _hilbertCount
+}
+
+// hilbert holds the state of Hilbert transform at a single data point.
+type hilbert struct {
+	smooth       float64
+	detrender    float64
+	i1           float64
+	q1           float64
+	i2           float64
+	q2           float64
+	re           float64
+	im           float64
+	period       float64
+	smoothPeriod float64
+	phase        float64
+}
+
+// hilbertTransform calculates the state of Hilbert transform at every
+// data point. Phase is in degrees.
+func hilbertTransform(ff []float64) []hilbert {
+	hh := make([]hilbert, len(ff))
+
+	for i := range ff {
+		if i < 3 {
+			hh[i].smooth = ff[i]
+			continue
+		}
+
+		hh[i].smooth = (4*ff[i] + 3*ff[i-1] + 2*ff[i-2] + ff[i-3]) / 10
+	}
+
+	for i := 6; i < len(ff); i++ {
+		prev, h := hh[i-1], &hh[i]
+		adj := 0.075*prev.period + 0.54
+
+		h.detrender = quadrature(hh, i, func(h hilbert) float64 { return h.smooth }) * adj
+		h.q1 = quadrature(hh, i, func(h hilbert) float64 { return h.detrender }) * adj
+		h.i1 = hh[i-3].detrender
+
+		// advance the phases of I1 and Q1 by 90 degrees.
+		ji := quadrature(hh, i, func(h hilbert) float64 { return h.i1 }) * adj
+		jq := quadrature(hh, i, func(h hilbert) float64 { return h.q1 }) * adj
+
+		h.i2 = 0.2*(h.i1-jq) + 0.8*prev.i2
+		h.q2 = 0.2*(h.q1+ji) + 0.8*prev.q2
+
+		h.re = 0.2*(h.i2*prev.i2+h.q2*prev.q2) + 0.8*prev.re
+		h.im = 0.2*(h.i2*prev.q2-h.q2*prev.i2) + 0.8*prev.im
+
+		h.period = prev.period
+		if h.im != 0 && h.re != 0 {
+			h.period = 2 * math.Pi / math.Atan(h.im/h.re)
+		}
+
+		h.period = math.Min(h.period, 1.5*prev.period)
+		h.period = math.Max(h.period, 0.67*prev.period)
+		h.period = math.Max(CycleMin, math.Min(CycleMax, h.period))
+		h.period = 0.2*h.period + 0.8*prev.period
+		h.smoothPeriod = 0.33*h.period + 0.67*prev.smoothPeriod
+
+		h.phase = prev.phase
+		if h.i1 != 0 {
+			h.phase = math.Atan(h.q1/h.i1) * 180 / math.Pi
+		}
+	}
+
+	return hh
+}
+
+// quadrature applies Hilbert transform FIR filter to the values of the
+// state, ending at the data point i.
+func quadrature(hh []hilbert, i int, value func(hilbert) float64) float64 {
+	return 0.0962*value(hh[i]) + 0.5769*value(hh[i-2]) -
+		0.5769*value(hh[i-4]) - 0.0962*value(hh[i-6])
+}
+
+// superSmooth applies two-pole Super Smoother filter with the critical
+// period length to the values.
+func superSmooth(ff []float64, length int) []float64 {
+	w := math.Sqrt2 * math.Pi / float64(length)
+	a := math.Exp(-w)
+	c2 := 2 * a * math.Cos(w)
+	c3 := -a * a
+	c1 := 1 - c2 - c3
+
+	res := make([]float64, len(ff))
+
+	for i := range ff {
+		if i < 2 {
+			res[i] = ff[i]
+			continue
+		}
+
+		res[i] = c1*(ff[i]+ff[i-1])/2 + c2*res[i-1] + c3*res[i-2]
+	}
+
+	return res
+}
+
+// highPass applies two-pole high-pass filter with the critical period
+// length to the values.
+func highPass(ff []float64, length int) []float64 {
+	w := math.Sqrt2 * math.Pi / float64(length)
+	a := (math.Cos(w) + math.Sin(w) - 1) / math.Cos(w)
+
+	res := make([]float64, len(ff))
+
+	for i := 2; i < len(ff); i++ {
+		res[i] = (1-a/2)*(1-a/2)*(ff[i]-2*ff[i-1]+ff[i-2]) +
+			2*(1-a)*res[i-1] - (1-a)*(1-a)*res[i-2]
+	}
+
+	return res
+}
+
+// floats converts the data points into float64 values.
+func floats(dd []decimal.Decimal) []float64 {
+	res := make([]float64, len(dd))
+
+	for i, d := range dd {
+		res[i], _ = d.Float64()
+	}
+
+	return res
+}
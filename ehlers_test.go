@@ -0,0 +1,748 @@
+package indc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// cycle returns 100 data points of a sine wave with the period of 20
+// data points on top of a slowly rising trend.
+func cycle() []decimal.Decimal {
+	res := make([]decimal.Decimal, 100)
+
+	for i := range res {
+		v := 100 + 10*math.Sin(2*math.Pi*float64(i)/20) + 0.1*float64(i)
+		res[i] = decimal.NewFromFloat(v)
+	}
+
+	return res
+}
+
+// sine returns 100 data points of a sine wave with the given period.
+func sine(period int) []decimal.Decimal {
+	res := make([]decimal.Decimal, 100)
+
+	for i := range res {
+		v := 100 + 10*math.Sin(2*math.Pi*float64(i)/float64(period))
+		res[i] = decimal.NewFromFloat(v)
+	}
+
+	return res
+}
+
+// constant returns n equal data points.
+func constant(n int) []decimal.Decimal {
+	res := make([]decimal.Decimal, n)
+
+	for i := range res {
+		res[i] = decimal.NewFromInt(5)
+	}
+
+	return res
+}
+
+func Test_NewSuperSmoother(t *testing.T) {
+	cc := map[string]struct {
+		Length  int
+		Options []Option
+		Error   error
+	}{
+		"Invalid config": {
+			Length:  10,
+			Options: []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "supersmoother",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid length": {
+			Length: 1,
+			Error: &ValidationError{
+				Indicator:  "supersmoother",
+				Param:      "length",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully created new SuperSmoother": {
+			Length: 10,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewSuperSmoother(c.Length, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Length, res.length)
+		})
+	}
+}
+
+func Test_SuperSmoother_Calc(t *testing.T) {
+	cc := map[string]struct {
+		SuperSmoother SuperSmoother
+		Data          []decimal.Decimal
+		Result        float64
+		Error         error
+	}{
+		"Invalid indicator": {
+			Data:  cycle(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			SuperSmoother: SuperSmoother{valid: true, length: 10},
+			Data:          cycle(),
+			Error:         &DataSizeError{Expected: 40, Actual: 100},
+		},
+		"Successfully calculated SuperSmoother of equal values": {
+			SuperSmoother: SuperSmoother{valid: true, length: 10},
+			Data:          constant(40),
+			Result:        5,
+		},
+		"Successfully calculated SuperSmoother": {
+			SuperSmoother: SuperSmoother{valid: true, length: 10},
+			Data:          cycle()[60:],
+			Result:        101.93549390261514,
+		},
+		"Successfully calculated SuperSmoother with lenient window": {
+			SuperSmoother: SuperSmoother{valid: true, cfg: config{lenient: true}, length: 10},
+			Data:          cycle(),
+			Result:        101.93549390261514,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.SuperSmoother.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_SuperSmoother_Count(t *testing.T) {
+	assert.Equal(t, 40, SuperSmoother{length: 10}.Count())
+}
+
+func Test_NewRoofing(t *testing.T) {
+	cc := map[string]struct {
+		High    int
+		Low     int
+		Options []Option
+		Error   error
+	}{
+		"Invalid config": {
+			High:    48,
+			Low:     10,
+			Options: []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "roofing",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid low length": {
+			High: 48,
+			Low:  1,
+			Error: &ValidationError{
+				Indicator:  "roofing",
+				Param:      "low length",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Invalid high length": {
+			High: 10,
+			Low:  10,
+			Error: &ValidationError{
+				Indicator:  "roofing",
+				Param:      "high length",
+				Value:      10,
+				Constraint: "must be greater than low length",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully created new Roofing": {
+			High: 48,
+			Low:  10,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewRoofing(c.High, c.Low, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.High, res.high)
+			assert.Equal(t, c.Low, res.low)
+		})
+	}
+}
+
+func Test_Roofing_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Roofing Roofing
+		Data    []decimal.Decimal
+		Result  float64
+		Error   error
+	}{
+		"Invalid indicator": {
+			Data:  cycle(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Roofing: Roofing{valid: true, high: 48, low: 10},
+			Data:    cycle(),
+			Error:   &DataSizeError{Expected: 192, Actual: 100},
+		},
+		"Successfully calculated Roofing of equal values": {
+			Roofing: Roofing{valid: true, high: 20, low: 10},
+			Data:    constant(80),
+		},
+		"Successfully calculated Roofing": {
+			Roofing: Roofing{valid: true, high: 20, low: 10},
+			Data:    cycle()[20:],
+			Result:  1.8502265480993771,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Roofing.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Roofing_Count(t *testing.T) {
+	assert.Equal(t, 192, Roofing{high: 48, low: 10}.Count())
+}
+
+func Test_NewTrendline(t *testing.T) {
+	cc := map[string]struct {
+		Alpha   decimal.Decimal
+		Options []Option
+		Error   error
+	}{
+		"Invalid config": {
+			Alpha:   decimal.NewFromFloat(0.07),
+			Options: []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "trendline",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid alpha": {
+			Alpha: decimal.NewFromInt(1),
+			Error: &ValidationError{
+				Indicator:  "trendline",
+				Param:      "alpha",
+				Value:      decimal.NewFromInt(1),
+				Constraint: "must be greater than 0 and less than 1",
+				Err:        ErrInvalidFactor,
+			},
+		},
+		"Invalid zero alpha": {
+			Alpha: decimal.Zero,
+			Error: &ValidationError{
+				Indicator:  "trendline",
+				Param:      "alpha",
+				Value:      decimal.Zero,
+				Constraint: "must be greater than 0 and less than 1",
+				Err:        ErrInvalidFactor,
+			},
+		},
+		"Successfully created new Trendline": {
+			Alpha: decimal.NewFromFloat(0.07),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewTrendline(c.Alpha, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Alpha.String(), res.alpha.String())
+		})
+	}
+}
+
+func Test_Trendline_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Trendline Trendline
+		Data      []decimal.Decimal
+		Result    float64
+		Error     error
+	}{
+		"Invalid indicator": {
+			Data:  cycle(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Trendline: Trendline{valid: true, alpha: decimal.NewFromFloat(0.07)},
+			Data:      cycle(),
+			Error:     &DataSizeError{Expected: 58, Actual: 100},
+		},
+		"Successfully calculated Trendline of equal values": {
+			Trendline: Trendline{valid: true, alpha: decimal.NewFromFloat(0.5)},
+			Data:      constant(8),
+			Result:    5,
+		},
+		"Successfully calculated Trendline": {
+			Trendline: Trendline{valid: true, alpha: decimal.NewFromFloat(0.07)},
+			Data:      cycle()[42:],
+			Result:    103.6068692632664,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Trendline.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Trendline_Count(t *testing.T) {
+	assert.Equal(t, 58, Trendline{alpha: decimal.NewFromFloat(0.07)}.Count())
+	assert.Equal(t, 7, Trendline{alpha: decimal.NewFromFloat(0.9)}.Count())
+}
+
+func Test_NewMAMA(t *testing.T) {
+	cc := map[string]struct {
+		Fast    decimal.Decimal
+		Slow    decimal.Decimal
+		Options []Option
+		Error   error
+	}{
+		"Invalid config": {
+			Fast:    decimal.NewFromFloat(0.5),
+			Slow:    decimal.NewFromFloat(0.05),
+			Options: []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "mama",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid slow limit": {
+			Fast: decimal.NewFromFloat(0.5),
+			Slow: decimal.Zero,
+			Error: &ValidationError{
+				Indicator:  "mama",
+				Param:      "slow limit",
+				Value:      decimal.Zero,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidFactor,
+			},
+		},
+		"Invalid fast limit lower than slow limit": {
+			Fast: decimal.NewFromFloat(0.05),
+			Slow: decimal.NewFromFloat(0.05),
+			Error: &ValidationError{
+				Indicator:  "mama",
+				Param:      "fast limit",
+				Value:      decimal.NewFromFloat(0.05),
+				Constraint: "must be greater than slow limit and not greater than 1",
+				Err:        ErrInvalidFactor,
+			},
+		},
+		"Invalid fast limit greater than 1": {
+			Fast: decimal.NewFromFloat(1.5),
+			Slow: decimal.NewFromFloat(0.05),
+			Error: &ValidationError{
+				Indicator:  "mama",
+				Param:      "fast limit",
+				Value:      decimal.NewFromFloat(1.5),
+				Constraint: "must be greater than slow limit and not greater than 1",
+				Err:        ErrInvalidFactor,
+			},
+		},
+		"Successfully created new MAMA": {
+			Fast: decimal.NewFromFloat(0.5),
+			Slow: decimal.NewFromFloat(0.05),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewMAMA(c.Fast, c.Slow, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Fast.String(), res.fast.String())
+			assert.Equal(t, c.Slow.String(), res.slow.String())
+		})
+	}
+}
+
+func Test_MAMA_Calc(t *testing.T) {
+	mama := MAMA{valid: true, fast: decimal.NewFromFloat(0.5), slow: decimal.NewFromFloat(0.05)}
+
+	cc := map[string]struct {
+		MAMA   MAMA
+		Data   []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			Data:  cycle(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			MAMA:  mama,
+			Data:  cycle()[1:],
+			Error: &DataSizeError{Expected: 100, Actual: 99},
+		},
+		"Successfully calculated MAMA of equal values": {
+			MAMA:   mama,
+			Data:   constant(100),
+			Result: 5,
+		},
+		"Successfully calculated MAMA": {
+			MAMA:   mama,
+			Data:   cycle(),
+			Result: 104.80745547731321,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.MAMA.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_MAMA_Count(t *testing.T) {
+	assert.Equal(t, 100, MAMA{}.Count())
+}
+
+func Test_NewFAMA(t *testing.T) {
+	_, err := NewFAMA(decimal.NewFromFloat(0.5), decimal.Zero)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "fama",
+		Param:      "slow limit",
+		Value:      decimal.Zero,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidFactor,
+	}, err)
+
+	res, err := NewFAMA(decimal.NewFromFloat(0.5), decimal.NewFromFloat(0.05))
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+	assert.True(t, res.mama.valid)
+}
+
+func Test_FAMA_Calc(t *testing.T) {
+	fama := FAMA{
+		valid: true,
+		mama:  MAMA{valid: true, fast: decimal.NewFromFloat(0.5), slow: decimal.NewFromFloat(0.05)},
+	}
+
+	cc := map[string]struct {
+		FAMA   FAMA
+		Data   []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			Data:  cycle(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			FAMA:  fama,
+			Data:  cycle()[1:],
+			Error: &DataSizeError{Expected: 100, Actual: 99},
+		},
+		"Successfully calculated FAMA": {
+			FAMA:   fama,
+			Data:   cycle(),
+			Result: 106.13347244286521,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.FAMA.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_FAMA_Count(t *testing.T) {
+	assert.Equal(t, 100, FAMA{}.Count())
+}
+
+func Test_NewFisher(t *testing.T) {
+	cc := map[string]struct {
+		Length  int
+		Options []Option
+		Error   error
+	}{
+		"Invalid config": {
+			Length:  10,
+			Options: []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "fisher",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid length": {
+			Length: 1,
+			Error: &ValidationError{
+				Indicator:  "fisher",
+				Param:      "length",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully created new Fisher": {
+			Length: 10,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewFisher(c.Length, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Length, res.length)
+		})
+	}
+}
+
+func Test_Fisher_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Fisher Fisher
+		Data   []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			Data:  cycle(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Fisher: Fisher{valid: true, length: 10},
+			Data:   cycle(),
+			Error:  &DataSizeError{Expected: 30, Actual: 100},
+		},
+		"Successfully calculated Fisher of equal values": {
+			Fisher: Fisher{valid: true, length: 10},
+			Data:   constant(30),
+		},
+		"Successfully calculated Fisher": {
+			Fisher: Fisher{valid: true, length: 10},
+			Data:   cycle()[70:],
+			Result: -1.2793171056422055,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Fisher.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Fisher_Count(t *testing.T) {
+	assert.Equal(t, 30, Fisher{length: 10}.Count())
+}
+
+func Test_NewDominantCycle(t *testing.T) {
+	_, err := NewDominantCycle(WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "dcperiod",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	res, err := NewDominantCycle()
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+}
+
+func Test_DominantCycle_Calc(t *testing.T) {
+	cc := map[string]struct {
+		DominantCycle DominantCycle
+		Data          []decimal.Decimal
+		Result        float64
+		Error         error
+	}{
+		"Invalid indicator": {
+			Data:  cycle(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			DominantCycle: DominantCycle{valid: true},
+			Data:          cycle()[1:],
+			Error:         &DataSizeError{Expected: 100, Actual: 99},
+		},
+		"Successfully calculated dominant cycle of a longer cycle": {
+			DominantCycle: DominantCycle{valid: true},
+			Data:          sine(32),
+			Result:        31.997968839617617,
+		},
+		"Successfully calculated dominant cycle": {
+			DominantCycle: DominantCycle{valid: true},
+			Data:          cycle(),
+			Result:        20.216929702242247,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.DominantCycle.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_DominantCycle_Length(t *testing.T) {
+	res, err := DominantCycle{valid: true}.Length(cycle())
+	assert.NoError(t, err)
+	assert.Equal(t, 20, res)
+
+	_, err = DominantCycle{}.Length(cycle())
+	assertEqualError(t, ErrInvalidIndicator, err)
+}
+
+func Test_DominantCycle_Count(t *testing.T) {
+	assert.Equal(t, 100, DominantCycle{}.Count())
+}
+
+func Test_floats(t *testing.T) {
+	assert.Equal(t, []float64{1.5, -2}, floats(ratios("1.5", "-2")))
+}
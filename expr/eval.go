@@ -0,0 +1,288 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+)
+
+// value holds the result of a node evaluation.
+type value struct {
+	// num specifies the result of numeric nodes.
+	num decimal.Decimal
+
+	// b specifies the result of boolean nodes.
+	b bool
+}
+
+// node is a single element of the expression tree.
+type node interface {
+	// typ should return the type of the node result.
+	typ() Type
+
+	// count should return the amount of candles required to evaluate
+	// the node.
+	count() int
+
+	// eval should evaluate the node at the newest candle. Candles slice
+	// is guaranteed to contain at least count() candles.
+	eval(cc []indc.Candle) (value, error)
+
+	// String should return canonical representation of the node.
+	String() string
+}
+
+// numberNode is a numeric constant.
+type numberNode struct {
+	v decimal.Decimal
+}
+
+func (n numberNode) typ() Type {
+	return TypeNumber
+}
+
+func (n numberNode) count() int {
+	return 1
+}
+
+func (n numberNode) eval(_ []indc.Candle) (value, error) {
+	return value{num: n.v}, nil
+}
+
+func (n numberNode) String() string {
+	return n.v.String()
+}
+
+// _fields holds accessors of all candle fields.
+var _fields = map[string]func(indc.Candle) decimal.Decimal{
+	"open":   func(c indc.Candle) decimal.Decimal { return c.Open },
+	"high":   func(c indc.Candle) decimal.Decimal { return c.High },
+	"low":    func(c indc.Candle) decimal.Decimal { return c.Low },
+	"close":  func(c indc.Candle) decimal.Decimal { return c.Close },
+	"volume": func(c indc.Candle) decimal.Decimal { return c.Volume },
+}
+
+// fieldNode is a field of the newest candle.
+type fieldNode struct {
+	name string
+}
+
+func (n fieldNode) typ() Type {
+	return TypeNumber
+}
+
+func (n fieldNode) count() int {
+	return 1
+}
+
+func (n fieldNode) eval(cc []indc.Candle) (value, error) {
+	return value{num: _fields[n.name](cc[len(cc)-1])}, nil
+}
+
+func (n fieldNode) String() string {
+	return n.name
+}
+
+// indicatorNode is an indicator fed with close prices.
+type indicatorNode struct {
+	name string
+	args []string
+	ind  indc.Indicator
+}
+
+func (n indicatorNode) typ() Type {
+	return TypeNumber
+}
+
+func (n indicatorNode) count() int {
+	return n.ind.Count()
+}
+
+func (n indicatorNode) eval(cc []indc.Candle) (value, error) {
+	v, err := n.ind.Calc(indc.Closes(cc[len(cc)-n.ind.Count():]))
+	if err != nil {
+		return value{}, fmt.Errorf("%s: %w", n, err)
+	}
+
+	return value{num: v}, nil
+}
+
+func (n indicatorNode) String() string {
+	return n.name + "(" + strings.Join(n.args, ", ") + ")"
+}
+
+// unaryNode is a negation of numeric or boolean operand.
+type unaryNode struct {
+	op string
+	x  node
+}
+
+func (n unaryNode) typ() Type {
+	return n.x.typ()
+}
+
+func (n unaryNode) count() int {
+	return n.x.count()
+}
+
+func (n unaryNode) eval(cc []indc.Candle) (value, error) {
+	v, err := n.x.eval(cc)
+	if err != nil {
+		return value{}, err
+	}
+
+	if n.op == "!" {
+		return value{b: !v.b}, nil
+	}
+
+	return value{num: v.num.Neg()}, nil
+}
+
+func (n unaryNode) String() string {
+	return "(" + n.op + n.x.String() + ")"
+}
+
+// binaryNode is an arithmetic, comparison or boolean operation.
+type binaryNode struct {
+	op   string
+	l, r node
+}
+
+func (n binaryNode) typ() Type {
+	switch n.op {
+	case "+", "-", "*", "/":
+		return TypeNumber
+	default:
+		return TypeBool
+	}
+}
+
+func (n binaryNode) count() int {
+	return maxInt(n.l.count(), n.r.count())
+}
+
+func (n binaryNode) eval(cc []indc.Candle) (value, error) {
+	l, err := n.l.eval(cc)
+	if err != nil {
+		return value{}, err
+	}
+
+	// boolean operators are short-circuited.
+	switch {
+	case n.op == "&&" && !l.b:
+		return value{}, nil
+	case n.op == "||" && l.b:
+		return value{b: true}, nil
+	}
+
+	r, err := n.r.eval(cc)
+	if err != nil {
+		return value{}, err
+	}
+
+	switch n.op {
+	case "&&", "||":
+		return value{b: r.b}, nil
+	case "+":
+		return value{num: l.num.Add(r.num)}, nil
+	case "-":
+		return value{num: l.num.Sub(r.num)}, nil
+	case "*":
+		return value{num: l.num.Mul(r.num)}, nil
+	case "/":
+		if r.num.IsZero() {
+			return value{}, fmt.Errorf("%s: %w", n, indc.ErrDivisionByZero)
+		}
+
+		return value{num: l.num.Div(r.num)}, nil
+	}
+
+	c := l.num.Cmp(r.num)
+
+	switch n.op {
+	case "<":
+		return value{b: c < 0}, nil
+	case "<=":
+		return value{b: c <= 0}, nil
+	case ">":
+		return value{b: c > 0}, nil
+	case ">=":
+		return value{b: c >= 0}, nil
+	case "==":
+		return value{b: c == 0}, nil
+	default:
+		return value{b: c != 0}, nil
+	}
+}
+
+func (n binaryNode) String() string {
+	return "(" + n.l.String() + " " + n.op + " " + n.r.String() + ")"
+}
+
+// crossNode checks whether the first operand moved above (below) the
+// second one at the newest candle.
+type crossNode struct {
+	up   bool
+	a, b node
+}
+
+func (n crossNode) typ() Type {
+	return TypeBool
+}
+
+func (n crossNode) count() int {
+	return maxInt(n.a.count(), n.b.count()) + 1
+}
+
+func (n crossNode) eval(cc []indc.Candle) (value, error) {
+	cur, err := n.cmp(cc)
+	if err != nil {
+		return value{}, err
+	}
+
+	prev, err := n.cmp(cc[:len(cc)-1])
+	if err != nil {
+		return value{}, err
+	}
+
+	if n.up {
+		return value{b: cur > 0 && prev <= 0}, nil
+	}
+
+	return value{b: cur < 0 && prev >= 0}, nil
+}
+
+// cmp compares operands at the newest candle.
+func (n crossNode) cmp(cc []indc.Candle) (int, error) {
+	a, err := n.a.eval(cc)
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := n.b.eval(cc)
+	if err != nil {
+		return 0, err
+	}
+
+	return a.num.Cmp(b.num), nil
+}
+
+func (n crossNode) String() string {
+	name := "cross_down"
+	if n.up {
+		name = "cross_up"
+	}
+
+	return name + "(" + n.a.String() + ", " + n.b.String() + ")"
+}
+
+// maxInt returns the larger of the provided integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,189 @@
+package expr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failNode is a numeric node that always fails.
+type failNode struct{}
+
+func (failNode) typ() Type {
+	return TypeNumber
+}
+
+func (failNode) count() int {
+	return 1
+}
+
+func (failNode) eval(_ []indc.Candle) (value, error) {
+	return value{}, assert.AnError
+}
+
+func (failNode) String() string {
+	return "fail"
+}
+
+func Test_node_eval(t *testing.T) {
+	num := func(v int64) node {
+		return numberNode{v: decimal.NewFromInt(v)}
+	}
+
+	cmp := func(op string) node {
+		return binaryNode{op: op, l: fieldNode{name: "close"}, r: num(5)}
+	}
+
+	truth := cmp("==")
+	falsity := cmp("!=")
+
+	cc := map[string]struct {
+		Node    node
+		Candles []indc.Candle
+		Result  value
+		Error   error
+	}{
+		"Field": {
+			Node:    fieldNode{name: "high"},
+			Candles: candles(5),
+			Result:  value{num: decimal.NewFromInt(7)},
+		},
+		"Indicator returns an error": {
+			Node:    indicatorNode{name: "sma", ind: indc.SMA{}},
+			Candles: candles(5),
+			Error:   fmt.Errorf("sma(): %w", indc.ErrInvalidIndicator),
+		},
+		"Unary operand returns an error": {
+			Node:    unaryNode{op: "-", x: failNode{}},
+			Candles: candles(5),
+			Error:   assert.AnError,
+		},
+		"Numeric negation": {
+			Node:    unaryNode{op: "-", x: num(3)},
+			Candles: candles(5),
+			Result:  value{num: decimal.NewFromInt(-3)},
+		},
+		"Boolean negation": {
+			Node:    unaryNode{op: "!", x: falsity},
+			Candles: candles(5),
+			Result:  value{b: true},
+		},
+		"Left operand returns an error": {
+			Node:    binaryNode{op: "+", l: failNode{}, r: num(1)},
+			Candles: candles(5),
+			Error:   assert.AnError,
+		},
+		"Right operand returns an error": {
+			Node:    binaryNode{op: "+", l: num(1), r: failNode{}},
+			Candles: candles(5),
+			Error:   assert.AnError,
+		},
+		"Conjunction is short-circuited": {
+			Node: binaryNode{op: "&&", l: falsity, r: binaryNode{
+				op: ">", l: failNode{}, r: num(1),
+			}},
+			Candles: candles(5),
+		},
+		"Disjunction is short-circuited": {
+			Node: binaryNode{op: "||", l: truth, r: binaryNode{
+				op: ">", l: failNode{}, r: num(1),
+			}},
+			Candles: candles(5),
+			Result:  value{b: true},
+		},
+		"Conjunction": {
+			Node:    binaryNode{op: "&&", l: truth, r: truth},
+			Candles: candles(5),
+			Result:  value{b: true},
+		},
+		"Disjunction": {
+			Node:    binaryNode{op: "||", l: falsity, r: falsity},
+			Candles: candles(5),
+		},
+		"Subtraction": {
+			Node:    binaryNode{op: "-", l: num(1), r: num(3)},
+			Candles: candles(5),
+			Result:  value{num: decimal.NewFromInt(-2)},
+		},
+		"Multiplication": {
+			Node:    binaryNode{op: "*", l: num(2), r: num(3)},
+			Candles: candles(5),
+			Result:  value{num: decimal.NewFromInt(6)},
+		},
+		"Division": {
+			Node:    binaryNode{op: "/", l: num(3), r: num(2)},
+			Candles: candles(5),
+			Result:  value{num: decimal.RequireFromString("1.5")},
+		},
+		"Division by zero": {
+			Node:    binaryNode{op: "/", l: num(3), r: num(0)},
+			Candles: candles(5),
+			Error:   fmt.Errorf("(3 / 0): %w", indc.ErrDivisionByZero),
+		},
+		"Less than": {
+			Node:    cmp("<"),
+			Candles: candles(4),
+			Result:  value{b: true},
+		},
+		"Less than or equal": {
+			Node:    cmp("<="),
+			Candles: candles(5),
+			Result:  value{b: true},
+		},
+		"Greater than": {
+			Node:    cmp(">"),
+			Candles: candles(5),
+		},
+		"Greater than or equal": {
+			Node:    cmp(">="),
+			Candles: candles(6),
+			Result:  value{b: true},
+		},
+		"Cross current comparison returns an error": {
+			Node:    crossNode{up: true, a: fieldNode{name: "close"}, b: failNode{}},
+			Candles: candles(5, 6),
+			Error:   assert.AnError,
+		},
+		"Cross up": {
+			Node:    crossNode{up: true, a: fieldNode{name: "close"}, b: num(5)},
+			Candles: candles(5, 6),
+			Result:  value{b: true},
+		},
+		"No cross up while staying above": {
+			Node:    crossNode{up: true, a: fieldNode{name: "close"}, b: num(5)},
+			Candles: candles(6, 7),
+		},
+		"Cross down": {
+			Node:    crossNode{a: fieldNode{name: "close"}, b: num(5)},
+			Candles: candles(6, 4),
+			Result:  value{b: true},
+		},
+		"No cross down while moving up": {
+			Node:    crossNode{a: fieldNode{name: "close"}, b: num(5)},
+			Candles: candles(4, 6),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			require.LessOrEqual(t, c.Node.count(), len(c.Candles))
+
+			res, err := c.Node.eval(c.Candles)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result.b, res.b)
+			assert.Equal(t, c.Result.num.String(), res.num.String())
+		})
+	}
+}
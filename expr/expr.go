@@ -0,0 +1,240 @@
+// Package expr provides a small expression language that combines
+// indicators, price fields and constants into numeric values or
+// conditions, e.g.:
+//
+//	rsi(14) < 30 and close > sma(200) and cross_up(ema(9), ema(21))
+//
+// Supported operators, from the lowest precedence to the highest, are:
+// "or" ("||"), "and" ("&&"), "not" ("!"), comparisons (<, <=, >, >=, ==,
+// !=), addition and subtraction, multiplication and division, and unary
+// minus. Identifiers open, high, low, close and volume refer to candle
+// fields; other identifiers are indicator names and may be called with
+// arguments, as accepted by indc.Build. Indicators are fed with close
+// prices. Functions cross_up(a, b) and cross_down(a, b) check whether a
+// moved above (below) b at the newest candle.
+// Expressions are type-checked when parsed and every expression
+// determines the amount of candles it needs through Count.
+package expr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidExpression is returned when uninitialized expression is
+	// evaluated.
+	ErrInvalidExpression = errors.New("invalid expression")
+
+	// ErrInvalidSyntax is returned when expression source cannot be
+	// parsed.
+	ErrInvalidSyntax = errors.New("invalid syntax")
+
+	// ErrTypeMismatch is returned when operand or result types do not
+	// match.
+	ErrTypeMismatch = errors.New("type mismatch")
+
+	// ErrInvalidType is returned when type value is invalid.
+	ErrInvalidType = errors.New("invalid type")
+)
+
+// Error holds information about an expression that could not be parsed
+// or type-checked.
+type Error struct {
+	// Pos specifies the byte offset of the problem in the source.
+	Pos int
+
+	// Msg specifies the description of the problem.
+	Msg string
+
+	// Err specifies the underlying error.
+	Err error
+}
+
+// Error returns the formatted error message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v at position %d: %s", e.Err, e.Pos, e.Msg)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Type specifies the type of expression values.
+type Type int
+
+// Available value types.
+const (
+	// TypeNumber specifies decimal values.
+	TypeNumber Type = iota + 1
+
+	// TypeBool specifies boolean values.
+	TypeBool
+)
+
+// Validate checks whether the type is one of supported types.
+func (t Type) Validate() error {
+	switch t {
+	case TypeNumber, TypeBool:
+		return nil
+	default:
+		return ErrInvalidType
+	}
+}
+
+// MarshalText turns type into appropriate string representation.
+func (t Type) MarshalText() ([]byte, error) {
+	var v string
+
+	switch t {
+	case TypeNumber:
+		v = "number"
+	case TypeBool:
+		v = "bool"
+	default:
+		return nil, ErrInvalidType
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate type value.
+func (t *Type) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "number", "n":
+		*t = TypeNumber
+	case "bool", "b":
+		*t = TypeBool
+	default:
+		return ErrInvalidType
+	}
+
+	return nil
+}
+
+// Expr is a parsed and type-checked expression.
+// The zero value is not usable.
+type Expr struct {
+	// root specifies the top node of the expression tree.
+	root node
+}
+
+// Parse parses and type-checks the expression source. Options are
+// passed to every indicator of the expression.
+func Parse(src string, opts ...indc.Option) (Expr, error) {
+	tt, err := lex(src)
+	if err != nil {
+		return Expr{}, err
+	}
+
+	p := parser{tokens: tt, opts: opts}
+
+	root, err := p.parse()
+	if err != nil {
+		return Expr{}, err
+	}
+
+	return Expr{root: root}, nil
+}
+
+// Type returns the type of the expression result.
+func (e Expr) Type() Type {
+	if e.root == nil {
+		return 0
+	}
+
+	return e.root.typ()
+}
+
+// Count returns the amount of candles required to evaluate the
+// expression.
+func (e Expr) Count() int {
+	if e.root == nil {
+		return 0
+	}
+
+	return e.root.count()
+}
+
+// String returns canonical representation of the expression with every
+// operation enclosed in parentheses.
+func (e Expr) String() string {
+	if e.root == nil {
+		return ""
+	}
+
+	return e.root.String()
+}
+
+// Number evaluates numeric expression at the newest candle. Candles are
+// ordered from the oldest to the newest.
+func (e Expr) Number(cc []indc.Candle) (decimal.Decimal, error) {
+	v, err := e.eval(cc, TypeNumber)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return v.num, nil
+}
+
+// Bool evaluates boolean expression at the newest candle. Candles are
+// ordered from the oldest to the newest.
+func (e Expr) Bool(cc []indc.Candle) (bool, error) {
+	v, err := e.eval(cc, TypeBool)
+	if err != nil {
+		return false, err
+	}
+
+	return v.b, nil
+}
+
+// Calc evaluates numeric expression on data points that are used as
+// every price field of the candles, so that the expression can be used
+// as an indicator.
+func (e Expr) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	cc := make([]indc.Candle, len(dd))
+
+	for i := range dd {
+		cc[i] = indc.Candle{
+			Open:  dd[i],
+			High:  dd[i],
+			Low:   dd[i],
+			Close: dd[i],
+		}
+	}
+
+	return e.Number(cc)
+}
+
+// eval evaluates the expression after checking its type and the amount
+// of candles.
+func (e Expr) eval(cc []indc.Candle, t Type) (value, error) {
+	if e.root == nil {
+		return value{}, ErrInvalidExpression
+	}
+
+	if e.root.typ() != t {
+		return value{}, fmt.Errorf("%w: expression is %s", ErrTypeMismatch, typeName(e.root.typ()))
+	}
+
+	if len(cc) < e.root.count() {
+		return value{}, &indc.DataSizeError{Expected: e.root.count(), Actual: len(cc)}
+	}
+
+	return e.root.eval(cc)
+}
+
+// typeName returns textual name of the type.
+func typeName(t Type) string {
+	b, err := t.MarshalText()
+	if err != nil {
+		// unlikely to happen
+		return "unknown"
+	}
+
+	return string(b)
+}
@@ -0,0 +1,298 @@
+package expr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		if exp == assert.AnError { //nolint:goerr113 // direct check is needed
+			assert.Error(t, err)
+			return
+		}
+
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+// candles creates candles with the provided close prices. Other prices
+// are derived from close prices.
+func candles(pp ...int64) []indc.Candle {
+	cc := make([]indc.Candle, len(pp))
+
+	for i, p := range pp {
+		cc[i] = indc.Candle{
+			Open:   decimal.NewFromInt(p - 1),
+			High:   decimal.NewFromInt(p + 2),
+			Low:    decimal.NewFromInt(p - 2),
+			Close:  decimal.NewFromInt(p),
+			Volume: decimal.NewFromInt(p * 10),
+		}
+	}
+
+	return cc
+}
+
+func Test_Error(t *testing.T) {
+	err := &Error{Pos: 3, Msg: "oops", Err: ErrInvalidSyntax}
+
+	assert.Equal(t, "invalid syntax at position 3: oops", err.Error())
+	assert.ErrorIs(t, err, ErrInvalidSyntax)
+}
+
+func Test_Type_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Type Type
+		Err  error
+	}{
+		"Invalid Type": {
+			Err: ErrInvalidType,
+		},
+		"Successful TypeNumber validation": {
+			Type: TypeNumber,
+		},
+		"Successful TypeBool validation": {
+			Type: TypeBool,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Type.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Type_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Type Type
+		Text string
+		Err  error
+	}{
+		"Invalid Type": {
+			Err: ErrInvalidType,
+		},
+		"Successful TypeNumber marshal": {
+			Type: TypeNumber,
+			Text: "number",
+		},
+		"Successful TypeBool marshal": {
+			Type: TypeBool,
+			Text: "bool",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Type.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Type_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Type
+		Err    error
+	}{
+		"Invalid Type": {
+			Err: ErrInvalidType,
+		},
+		"Successful TypeNumber unmarshal (long form)": {
+			Text:   "number",
+			Result: TypeNumber,
+		},
+		"Successful TypeNumber unmarshal (short form)": {
+			Text:   "n",
+			Result: TypeNumber,
+		},
+		"Successful TypeBool unmarshal (long form)": {
+			Text:   "bool",
+			Result: TypeBool,
+		},
+		"Successful TypeBool unmarshal (short form)": {
+			Text:   "b",
+			Result: TypeBool,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var tp Type
+			err := tp.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, tp)
+		})
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	_, err := Parse("close ? 1")
+	assertEqualError(t, &Error{Pos: 6, Msg: "unexpected character '?'", Err: ErrInvalidSyntax}, err)
+
+	_, err = Parse("close and")
+	assertEqualError(t, &Error{Pos: 9, Msg: "unexpected end of expression", Err: ErrInvalidSyntax}, err)
+
+	res, err := Parse("rsi(14) < 30 and close > sma(200) and cross_up(ema(9), ema(21))", indc.WithLenient())
+	require.NoError(t, err)
+	assert.Equal(t, TypeBool, res.Type())
+	assert.Equal(t, 200, res.Count())
+	assert.Equal(t, "(((rsi(14) < 30) && (close > sma(200))) && cross_up(ema(9), ema(21)))", res.String())
+
+	assert.Equal(t, Type(0), Expr{}.Type())
+	assert.Zero(t, Expr{}.Count())
+	assert.Empty(t, Expr{}.String())
+}
+
+func Test_Expr_Number(t *testing.T) {
+	cc := map[string]struct {
+		Expr    Expr
+		Source  string
+		Candles []indc.Candle
+		Result  string
+		Error   error
+	}{
+		"Invalid expression": {
+			Candles: candles(1),
+			Error:   ErrInvalidExpression,
+		},
+		"Invalid expression type": {
+			Source:  "close > 1",
+			Candles: candles(1),
+			Error:   fmt.Errorf("%w: expression is bool", ErrTypeMismatch),
+		},
+		"Invalid data size": {
+			Source:  "sma(3)",
+			Candles: candles(1, 2),
+			Error:   &indc.DataSizeError{Expected: 3, Actual: 2},
+		},
+		"Successful evaluation": {
+			Source:  "(sma(3) - low) * 2 + volume / 10",
+			Candles: candles(100, 1, 2, 3),
+			Result:  "5",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			e := c.Expr
+			if c.Source != "" {
+				var err error
+
+				e, err = Parse(c.Source)
+				require.NoError(t, err)
+			}
+
+			res, err := e.Number(c.Candles)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res.String())
+		})
+	}
+}
+
+func Test_Expr_Bool(t *testing.T) {
+	cc := map[string]struct {
+		Source  string
+		Candles []indc.Candle
+		Result  bool
+		Error   error
+	}{
+		"Invalid expression type": {
+			Source:  "close + 1",
+			Candles: candles(1),
+			Error:   fmt.Errorf("%w: expression is number", ErrTypeMismatch),
+		},
+		"Evaluation returns an error": {
+			Source:  "close / (open - open) > 1",
+			Candles: candles(1),
+			Error:   fmt.Errorf("(close / (open - open)): %w", indc.ErrDivisionByZero),
+		},
+		"Successful evaluation (false)": {
+			Source:  "close > sma(2) and high - low == 4",
+			Candles: candles(3, 2),
+		},
+		"Successful evaluation (true)": {
+			Source:  "close > sma(2) and high - low == 4",
+			Candles: candles(2, 3),
+			Result:  true,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := Parse(c.Source)
+			require.NoError(t, err)
+
+			res, err := e.Bool(c.Candles)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Expr_Calc(t *testing.T) {
+	e, err := Parse("sma(2) - low")
+	require.NoError(t, err)
+
+	vv, err := indc.Series(e, []decimal.Decimal{
+		decimal.NewFromInt(1),
+		decimal.NewFromInt(3),
+		decimal.NewFromInt(8),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, vv, 2)
+	assert.Equal(t, "-1", vv[0].String())
+	assert.Equal(t, "-2.5", vv[1].String())
+}
@@ -0,0 +1,129 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind specifies the kind of a lexical token.
+type tokenKind int
+
+// Available token kinds.
+const (
+	tokenEOF tokenKind = iota + 1
+	tokenNumber
+	tokenIdent
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// token is a single lexical unit of an expression.
+type token struct {
+	// kind specifies the kind of the token.
+	kind tokenKind
+
+	// text specifies the source text of the token. Keyword operators
+	// are normalized to their symbolic forms.
+	text string
+
+	// pos specifies the byte offset of the token in the source.
+	pos int
+}
+
+// _keywords maps keyword operators to their symbolic forms.
+var _keywords = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+}
+
+// _ops holds all symbolic operators, longer ones first.
+var _ops = []string{
+	"&&", "||", "<=", ">=", "==", "!=",
+	"<", ">", "+", "-", "*", "/", "!",
+}
+
+// lex splits the source into tokens. The last token is always of
+// tokenEOF kind.
+func lex(src string) ([]token, error) {
+	var tt []token
+
+	for i := 0; i < len(src); {
+		r := rune(src[i])
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tt = append(tt, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tt = append(tt, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tt = append(tt, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case isDigit(r) || r == '.':
+			j := i
+			for j < len(src) && (isDigit(rune(src[j])) || src[j] == '.') {
+				j++
+			}
+
+			tt = append(tt, token{kind: tokenNumber, text: src[i:j], pos: i})
+			i = j
+		case isIdentStart(r):
+			j := i
+			for j < len(src) && (isIdentStart(rune(src[j])) || isDigit(rune(src[j]))) {
+				j++
+			}
+
+			text := strings.ToLower(src[i:j])
+			if op, ok := _keywords[text]; ok {
+				tt = append(tt, token{kind: tokenOp, text: op, pos: i})
+			} else {
+				tt = append(tt, token{kind: tokenIdent, text: text, pos: i})
+			}
+
+			i = j
+		default:
+			op := matchOp(src[i:])
+			if op == "" {
+				return nil, &Error{
+					Pos: i,
+					Msg: fmt.Sprintf("unexpected character %q", src[i]),
+					Err: ErrInvalidSyntax,
+				}
+			}
+
+			tt = append(tt, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tt, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// matchOp returns the symbolic operator at the start of s or an empty
+// string if there is none.
+func matchOp(s string) string {
+	for _, op := range _ops {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+// isDigit checks whether r is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isIdentStart checks whether r can start an identifier.
+func isIdentStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
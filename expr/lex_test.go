@@ -0,0 +1,79 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lex(t *testing.T) {
+	cc := map[string]struct {
+		Source string
+		Result []token
+		Error  error
+	}{
+		"Unexpected character": {
+			Source: "close # 1",
+			Error: &Error{
+				Pos: 6,
+				Msg: "unexpected character '#'",
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Successfully split empty source": {
+			Result: []token{
+				{kind: tokenEOF},
+			},
+		},
+		"Successfully split source": {
+			Source: "RSI(14) <= 30.5 AND not(a!=b)||-x",
+			Result: []token{
+				{kind: tokenIdent, text: "rsi", pos: 0},
+				{kind: tokenLParen, text: "(", pos: 3},
+				{kind: tokenNumber, text: "14", pos: 4},
+				{kind: tokenRParen, text: ")", pos: 6},
+				{kind: tokenOp, text: "<=", pos: 8},
+				{kind: tokenNumber, text: "30.5", pos: 11},
+				{kind: tokenOp, text: "&&", pos: 16},
+				{kind: tokenOp, text: "!", pos: 20},
+				{kind: tokenLParen, text: "(", pos: 23},
+				{kind: tokenIdent, text: "a", pos: 24},
+				{kind: tokenOp, text: "!=", pos: 25},
+				{kind: tokenIdent, text: "b", pos: 27},
+				{kind: tokenRParen, text: ")", pos: 28},
+				{kind: tokenOp, text: "||", pos: 29},
+				{kind: tokenOp, text: "-", pos: 31},
+				{kind: tokenIdent, text: "x", pos: 32},
+				{kind: tokenEOF, pos: 33},
+			},
+		},
+		"Successfully split identifiers with digits and commas": {
+			Source: "cross_up(ema9,b)",
+			Result: []token{
+				{kind: tokenIdent, text: "cross_up", pos: 0},
+				{kind: tokenLParen, text: "(", pos: 8},
+				{kind: tokenIdent, text: "ema9", pos: 9},
+				{kind: tokenComma, text: ",", pos: 13},
+				{kind: tokenIdent, text: "b", pos: 14},
+				{kind: tokenRParen, text: ")", pos: 15},
+				{kind: tokenEOF, pos: 16},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := lex(c.Source)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package expr
+
+import (
+	"fmt"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+)
+
+// parser builds expression tree from tokens with recursive descent.
+type parser struct {
+	// tokens specifies all tokens of the source.
+	tokens []token
+
+	// i specifies the index of the current token.
+	i int
+
+	// opts specifies options passed to every indicator.
+	opts []indc.Option
+}
+
+// parse parses the whole source.
+func (p *parser) parse() (node, error) {
+	if p.peek().kind == tokenEOF {
+		return nil, p.errorf(p.peek(), ErrInvalidSyntax, "empty expression")
+	}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, ErrInvalidSyntax, "unexpected %q", t.text)
+	}
+
+	return n, nil
+}
+
+// parseOr parses boolean disjunctions.
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, TypeBool, "||")
+}
+
+// parseAnd parses boolean conjunctions.
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseNot, TypeBool, "&&")
+}
+
+// parseNot parses boolean negations.
+func (p *parser) parseNot() (node, error) {
+	if t := p.peek(); t.kind == tokenOp && t.text == "!" {
+		p.i++
+
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(t, x, TypeBool); err != nil {
+			return nil, err
+		}
+
+		return unaryNode{op: "!", x: x}, nil
+	}
+
+	return p.parseComparison()
+}
+
+// parseComparison parses a single, non-associative comparison.
+func (p *parser) parseComparison() (node, error) {
+	l, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	if !p.isOp(t, "<", "<=", ">", ">=", "==", "!=") {
+		return l, nil
+	}
+
+	p.i++
+
+	r, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(t, l, TypeNumber); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(t, r, TypeNumber); err != nil {
+		return nil, err
+	}
+
+	return binaryNode{op: t.text, l: l, r: r}, nil
+}
+
+// parseAdditive parses additions and subtractions.
+func (p *parser) parseAdditive() (node, error) {
+	return p.parseBinary(p.parseMultiplicative, TypeNumber, "+", "-")
+}
+
+// parseMultiplicative parses multiplications and divisions.
+func (p *parser) parseMultiplicative() (node, error) {
+	return p.parseBinary(p.parseUnary, TypeNumber, "*", "/")
+}
+
+// parseBinary parses left-associative operations of the provided
+// operators, whose operands are parsed with the next function and must
+// be of type t.
+func (p *parser) parseBinary(next func() (node, error), t Type, ops ...string) (node, error) {
+	l, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if !p.isOp(op, ops...) {
+			return l, nil
+		}
+
+		p.i++
+
+		r, err := next()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(op, l, t); err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(op, r, t); err != nil {
+			return nil, err
+		}
+
+		l = binaryNode{op: op.text, l: l, r: r}
+	}
+}
+
+// parseUnary parses numeric negations.
+func (p *parser) parseUnary() (node, error) {
+	if t := p.peek(); p.isOp(t, "-") {
+		p.i++
+
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(t, x, TypeNumber); err != nil {
+			return nil, err
+		}
+
+		return unaryNode{op: "-", x: x}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+// parsePrimary parses numbers, identifiers, calls and parenthesized
+// expressions.
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		v, err := decimal.NewFromString(t.text)
+		if err != nil {
+			return nil, p.errorf(t, ErrInvalidSyntax, "invalid number %q", t.text)
+		}
+
+		return numberNode{v: v}, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			p.i++
+			return p.parseCall(t)
+		}
+
+		if _, ok := _fields[t.text]; ok {
+			return fieldNode{name: t.text}, nil
+		}
+
+		return p.indicator(t, nil)
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if c := p.next(); c.kind != tokenRParen {
+			return nil, p.errorf(c, ErrInvalidSyntax, "expected \")\"")
+		}
+
+		return n, nil
+	case tokenEOF:
+		return nil, p.errorf(t, ErrInvalidSyntax, "unexpected end of expression")
+	default:
+		return nil, p.errorf(t, ErrInvalidSyntax, "unexpected %q", t.text)
+	}
+}
+
+// parseCall parses arguments of cross functions and indicators. The
+// opening parenthesis is already consumed.
+func (p *parser) parseCall(name token) (node, error) {
+	switch name.text {
+	case "cross_up", "cross_down":
+		a, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		if c := p.next(); c.kind != tokenComma {
+			return nil, p.errorf(c, ErrInvalidSyntax, "expected \",\"")
+		}
+
+		b, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		if c := p.next(); c.kind != tokenRParen {
+			return nil, p.errorf(c, ErrInvalidSyntax, "expected \")\"")
+		}
+
+		for _, n := range []node{a, b} {
+			if n.typ() != TypeNumber {
+				return nil, p.errorf(name, ErrTypeMismatch, "%s expects number operands, got %s",
+					name.text, typeName(n.typ()))
+			}
+		}
+
+		return crossNode{up: name.text == "cross_up", a: a, b: b}, nil
+	}
+
+	var args []string
+
+	if p.peek().kind == tokenRParen {
+		p.i++
+		return p.indicator(name, args)
+	}
+
+	for {
+		arg := p.next()
+
+		switch {
+		case arg.kind == tokenNumber || arg.kind == tokenIdent:
+			args = append(args, arg.text)
+		case p.isOp(arg, "-") && p.peek().kind == tokenNumber:
+			args = append(args, "-"+p.next().text)
+		default:
+			return nil, p.errorf(arg, ErrInvalidSyntax, "indicator arguments must be numbers or names")
+		}
+
+		switch c := p.next(); c.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return p.indicator(name, args)
+		default:
+			return nil, p.errorf(c, ErrInvalidSyntax, "expected \",\" or \")\"")
+		}
+	}
+}
+
+// indicator creates indicator node from its name and arguments.
+func (p *parser) indicator(name token, args []string) (node, error) {
+	ind, err := indc.Build(name.text, args, p.opts...)
+	if err != nil {
+		return nil, &Error{Pos: name.pos, Msg: "cannot create " + name.text, Err: err}
+	}
+
+	return indicatorNode{name: name.text, args: args, ind: ind}, nil
+}
+
+// expect checks whether the operand of the operator is of type t.
+func (p *parser) expect(op token, n node, t Type) error {
+	if n.typ() == t {
+		return nil
+	}
+
+	return p.errorf(op, ErrTypeMismatch, "operator %q expects %s operand, got %s",
+		op.text, typeName(t), typeName(n.typ()))
+}
+
+// isOp checks whether the token is one of the provided operators.
+func (p *parser) isOp(t token, ops ...string) bool {
+	if t.kind != tokenOp {
+		return false
+	}
+
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// peek returns the current token.
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+// next returns the current token and advances to the next one. The
+// last, EOF token is never passed.
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+
+	return t
+}
+
+// errorf creates new error at the position of the token.
+func (p *parser) errorf(t token, err error, format string, args ...interface{}) error {
+	return &Error{Pos: t.pos, Msg: fmt.Sprintf(format, args...), Err: err}
+}
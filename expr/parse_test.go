@@ -0,0 +1,226 @@
+package expr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jellydator/indc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parser_parse(t *testing.T) {
+	cc := map[string]struct {
+		Source string
+		Result string
+		Type   Type
+		Count  int
+		Error  error
+	}{
+		"Empty expression": {
+			Source: "  ",
+			Error: &Error{
+				Pos: 2,
+				Msg: "empty expression",
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Unexpected trailing token": {
+			Source: "close 1",
+			Error: &Error{
+				Pos: 6,
+				Msg: `unexpected "1"`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Chained comparison": {
+			Source: "1 < 2 < 3",
+			Error: &Error{
+				Pos: 6,
+				Msg: `unexpected "<"`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Invalid number": {
+			Source: "1.2.3",
+			Error: &Error{
+				Pos: 0,
+				Msg: `invalid number "1.2.3"`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Unclosed parenthesis": {
+			Source: "(1 + 2",
+			Error: &Error{
+				Pos: 6,
+				Msg: `expected ")"`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Unexpected operator": {
+			Source: "* 2",
+			Error: &Error{
+				Pos: 0,
+				Msg: `unexpected "*"`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Unknown identifier": {
+			Source: "close > foo",
+			Error: &Error{
+				Pos: 8,
+				Msg: "cannot create foo",
+				Err: fmt.Errorf("%w: %q", indc.ErrUnknownIndicator, "foo"),
+			},
+		},
+		"Invalid indicator argument": {
+			Source: "sma(0)",
+			Error: &Error{
+				Pos: 0,
+				Msg: "cannot create sma",
+				Err: &indc.ValidationError{
+					Indicator:  "sma",
+					Param:      "length",
+					Value:      0,
+					Constraint: "must be greater than 0",
+					Err:        indc.ErrInvalidLength,
+				},
+			},
+		},
+		"Invalid indicator argument expression": {
+			Source: "sma(1 + 2)",
+			Error: &Error{
+				Pos: 6,
+				Msg: `expected "," or ")"`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Invalid indicator argument token": {
+			Source: "sma(()",
+			Error: &Error{
+				Pos: 4,
+				Msg: "indicator arguments must be numbers or names",
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Missing cross comma": {
+			Source: "cross_up(close)",
+			Error: &Error{
+				Pos: 14,
+				Msg: `expected ","`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Missing cross closing parenthesis": {
+			Source: "cross_up(close, open",
+			Error: &Error{
+				Pos: 20,
+				Msg: `expected ")"`,
+				Err: ErrInvalidSyntax,
+			},
+		},
+		"Boolean cross operand": {
+			Source: "cross_down((close > 1), open)",
+			Error: &Error{
+				Pos: 0,
+				Msg: "cross_down expects number operands, got bool",
+				Err: ErrTypeMismatch,
+			},
+		},
+		"Numeric boolean operand": {
+			Source: "close > 1 or 2",
+			Error: &Error{
+				Pos: 10,
+				Msg: `operator "||" expects bool operand, got number`,
+				Err: ErrTypeMismatch,
+			},
+		},
+		"Numeric negation operand": {
+			Source: "not close",
+			Error: &Error{
+				Pos: 0,
+				Msg: `operator "!" expects bool operand, got number`,
+				Err: ErrTypeMismatch,
+			},
+		},
+		"Boolean comparison operand": {
+			Source: "(close > 1) == (open > 1)",
+			Error: &Error{
+				Pos: 12,
+				Msg: `operator "==" expects number operand, got bool`,
+				Err: ErrTypeMismatch,
+			},
+		},
+		"Boolean arithmetic operand": {
+			Source: "1 + (close > 1)",
+			Error: &Error{
+				Pos: 2,
+				Msg: `operator "+" expects number operand, got bool`,
+				Err: ErrTypeMismatch,
+			},
+		},
+		"Boolean unary minus operand": {
+			Source: "-(close > 1)",
+			Error: &Error{
+				Pos: 0,
+				Msg: `operator "-" expects number operand, got bool`,
+				Err: ErrTypeMismatch,
+			},
+		},
+		"Successfully parsed arithmetic precedence": {
+			Source: "1 + 2 * -3 - 4 / 2",
+			Result: "((1 + (2 * (-3))) - (4 / 2))",
+			Type:   TypeNumber,
+			Count:  1,
+		},
+		"Successfully parsed boolean precedence": {
+			Source: "not close > 1 or open < 2 && high >= 3",
+			Result: "((!(close > 1)) || ((open < 2) && (high >= 3)))",
+			Type:   TypeBool,
+			Count:  1,
+		},
+		"Invalid nested indicator argument": {
+			Source: "bb(upper, 2, 20) - rsi + aroon(down, -0) * sma()",
+			Error: &Error{
+				Pos: 25,
+				Msg: "cannot create aroon",
+				Err: &indc.ValidationError{
+					Indicator:  "aroon",
+					Param:      "length",
+					Value:      0,
+					Constraint: "must be greater than 0",
+					Err:        indc.ErrInvalidLength,
+				},
+			},
+		},
+		"Successfully parsed indicators with defaults": {
+			Source: "bb(upper, 2, 20) - rsi + sma()",
+			Result: "((bb(upper, 2, 20) - rsi()) + sma())",
+			Type:   TypeNumber,
+			Count:  20,
+		},
+		"Successfully parsed cross": {
+			Source: "cross_down(ema(9), ema(21) + 1) or cross_up(close, 50)",
+			Result: "(cross_down(ema(9), (ema(21) + 1)) || cross_up(close, 50))",
+			Type:   TypeBool,
+			Count:  42,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Parse(c.Source)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res.String())
+			assert.Equal(t, c.Type, res.Type())
+			assert.Equal(t, c.Count, res.Count())
+		})
+	}
+}
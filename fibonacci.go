@@ -0,0 +1,181 @@
+package indc
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidRatio is returned when Fibonacci ratio is not positive.
+	ErrInvalidRatio = errors.New("invalid ratio")
+
+	// ErrMissingSwing is returned when there is no confirmed swing to
+	// calculate Fibonacci levels from.
+	ErrMissingSwing = errors.New("missing swing")
+)
+
+// DefaultRetracements returns commonly used Fibonacci retracement
+// ratios: 23.6%, 38.2%, 50%, 61.8% and 78.6%.
+func DefaultRetracements() []decimal.Decimal {
+	return []decimal.Decimal{
+		decimal.RequireFromString("0.236"),
+		decimal.RequireFromString("0.382"),
+		decimal.RequireFromString("0.5"),
+		decimal.RequireFromString("0.618"),
+		decimal.RequireFromString("0.786"),
+	}
+}
+
+// DefaultExtensions returns commonly used Fibonacci extension ratios:
+// 127.2% and 161.8%.
+func DefaultExtensions() []decimal.Decimal {
+	return []decimal.Decimal{
+		decimal.RequireFromString("1.272"),
+		decimal.RequireFromString("1.618"),
+	}
+}
+
+// Level holds a single Fibonacci level.
+type Level struct {
+	// Ratio specifies the ratio of the swing the level is at.
+	Ratio decimal.Decimal `json:"ratio"`
+
+	// Price specifies the price of the level.
+	Price decimal.Decimal `json:"price"`
+}
+
+// FibonacciLevels holds Fibonacci levels of a single swing.
+type FibonacciLevels struct {
+	// From specifies the price the swing started at.
+	From decimal.Decimal `json:"from"`
+
+	// To specifies the price the swing ended at.
+	To decimal.Decimal `json:"to"`
+
+	// Retracements specifies levels measured back from the end of the
+	// swing, ordered as their ratios.
+	Retracements []Level `json:"retracements"`
+
+	// Extensions specifies levels measured from the start of the swing
+	// beyond its end, ordered as their ratios.
+	Extensions []Level `json:"extensions"`
+}
+
+// Fibonacci holds all the necessary information needed to calculate
+// Fibonacci retracement and extension levels.
+// The zero value is not usable.
+type Fibonacci struct {
+	// valid specifies whether Fibonacci paremeters were validated.
+	valid bool
+
+	// retracements specifies retracement ratios.
+	retracements []decimal.Decimal
+
+	// extensions specifies extension ratios.
+	extensions []decimal.Decimal
+}
+
+// NewFibonacci validates provided ratios and creates new Fibonacci
+// calculator. Nil ratios are replaced with the default ones; use an
+// empty slice to skip retracements or extensions.
+func NewFibonacci(retracements, extensions []decimal.Decimal) (Fibonacci, error) {
+	if retracements == nil {
+		retracements = DefaultRetracements()
+	}
+
+	if extensions == nil {
+		extensions = DefaultExtensions()
+	}
+
+	f := Fibonacci{
+		retracements: append([]decimal.Decimal{}, retracements...),
+		extensions:   append([]decimal.Decimal{}, extensions...),
+	}
+
+	if err := f.validate(); err != nil {
+		return Fibonacci{}, err
+	}
+
+	return f, nil
+}
+
+// validate checks whether the calculator has valid configuration
+// properties.
+func (f *Fibonacci) validate() error {
+	for _, r := range f.retracements {
+		if !r.IsPositive() {
+			return &ValidationError{
+				Indicator:  "fibonacci",
+				Param:      "retracement ratio",
+				Value:      r,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidRatio,
+			}
+		}
+	}
+
+	for _, r := range f.extensions {
+		if !r.IsPositive() {
+			return &ValidationError{
+				Indicator:  "fibonacci",
+				Param:      "extension ratio",
+				Value:      r,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidRatio,
+			}
+		}
+	}
+
+	f.valid = true
+
+	return nil
+}
+
+// Levels calculates Fibonacci levels of the swing between the two
+// anchor prices. A retracement level is at to - (to - from) * ratio and
+// an extension level is at from + (to - from) * ratio, so both work for
+// upward as well as downward swings.
+func (f Fibonacci) Levels(from, to decimal.Decimal) (FibonacciLevels, error) {
+	if !f.valid {
+		return FibonacciLevels{}, ErrInvalidIndicator
+	}
+
+	move := to.Sub(from)
+
+	res := FibonacciLevels{
+		From:         from,
+		To:           to,
+		Retracements: make([]Level, len(f.retracements)),
+		Extensions:   make([]Level, len(f.extensions)),
+	}
+
+	for i, r := range f.retracements {
+		res.Retracements[i] = Level{Ratio: r, Price: to.Sub(move.Mul(r))}
+	}
+
+	for i, r := range f.extensions {
+		res.Extensions[i] = Level{Ratio: r, Price: from.Add(move.Mul(r))}
+	}
+
+	return res, nil
+}
+
+// Swing calculates Fibonacci levels of the latest swing between the two
+// newest pivots that are confirmed by the ZigZag.
+func (f Fibonacci) Swing(zz ZigZag, cc []Candle) (FibonacciLevels, error) {
+	if !f.valid {
+		return FibonacciLevels{}, ErrInvalidIndicator
+	}
+
+	pp, err := zz.Pivots(cc)
+	if err != nil {
+		return FibonacciLevels{}, err
+	}
+
+	if len(pp) < 2 {
+		return FibonacciLevels{}, ErrMissingSwing
+	}
+
+	return f.Levels(pp[len(pp)-2].Price, pp[len(pp)-1].Price)
+}
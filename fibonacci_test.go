@@ -0,0 +1,160 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ratios is a shorthand for creating decimal ratios.
+func ratios(rr ...string) []decimal.Decimal {
+	res := make([]decimal.Decimal, len(rr))
+	for i, r := range rr {
+		res[i] = decimal.RequireFromString(r)
+	}
+
+	return res
+}
+
+func Test_NewFibonacci(t *testing.T) {
+	cc := map[string]struct {
+		Retracements []decimal.Decimal
+		Extensions   []decimal.Decimal
+		ResultR      []decimal.Decimal
+		ResultE      []decimal.Decimal
+		Error        error
+	}{
+		"Invalid retracement ratio": {
+			Retracements: ratios("0.5", "0"),
+			Error: &ValidationError{
+				Indicator:  "fibonacci",
+				Param:      "retracement ratio",
+				Value:      decimal.RequireFromString("0"),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidRatio,
+			},
+		},
+		"Invalid extension ratio": {
+			Extensions: ratios("-1"),
+			Error: &ValidationError{
+				Indicator:  "fibonacci",
+				Param:      "extension ratio",
+				Value:      decimal.RequireFromString("-1"),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidRatio,
+			},
+		},
+		"Successfully created new Fibonacci with default ratios": {
+			ResultR: DefaultRetracements(),
+			ResultE: DefaultExtensions(),
+		},
+		"Successfully created new Fibonacci with custom ratios": {
+			Retracements: ratios("0.5"),
+			Extensions:   []decimal.Decimal{},
+			ResultR:      ratios("0.5"),
+			ResultE:      []decimal.Decimal{},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewFibonacci(c.Retracements, c.Extensions)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.ResultR, res.retracements)
+			assert.Equal(t, c.ResultE, res.extensions)
+		})
+	}
+}
+
+func Test_Fibonacci_Levels(t *testing.T) {
+	cc := map[string]struct {
+		From         string
+		To           string
+		Retracements []string
+		Extensions   []string
+	}{
+		"Successfully calculated levels of upward swing": {
+			From:         "10",
+			To:           "20",
+			Retracements: []string{"17.64", "16.18", "15", "13.82", "12.14"},
+			Extensions:   []string{"22.72", "26.18"},
+		},
+		"Successfully calculated levels of downward swing": {
+			From:         "20",
+			To:           "10",
+			Retracements: []string{"12.36", "13.82", "15", "16.18", "17.86"},
+			Extensions:   []string{"7.28", "3.82"},
+		},
+	}
+
+	f, err := NewFibonacci(nil, nil)
+	require.NoError(t, err)
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := f.Levels(decimal.RequireFromString(c.From), decimal.RequireFromString(c.To))
+			require.NoError(t, err)
+
+			assert.Equal(t, c.From, res.From.String())
+			assert.Equal(t, c.To, res.To.String())
+			assertEqualLevels(t, DefaultRetracements(), c.Retracements, res.Retracements)
+			assertEqualLevels(t, DefaultExtensions(), c.Extensions, res.Extensions)
+		})
+	}
+}
+
+func Test_Fibonacci_Swing(t *testing.T) {
+	f, err := NewFibonacci(nil, nil)
+	require.NoError(t, err)
+
+	zz, err := NewZigZag(ThresholdAbsolute, decimal.NewFromInt(2), 0)
+	require.NoError(t, err)
+
+	res, err := f.Swing(zz, swings())
+	require.NoError(t, err)
+
+	assert.Equal(t, "13", res.From.String())
+	assert.Equal(t, "9", res.To.String())
+	assertEqualLevels(t, DefaultRetracements(), []string{"9.944", "10.528", "11", "11.472", "12.144"}, res.Retracements)
+	assertEqualLevels(t, DefaultExtensions(), []string{"7.912", "6.528"}, res.Extensions)
+
+	_, err = f.Swing(zz, swings()[:3])
+	assertEqualError(t, ErrMissingSwing, err)
+
+	_, err = f.Swing(ZigZag{}, swings())
+	assertEqualError(t, ErrInvalidIndicator, err)
+}
+
+func Test_Fibonacci_Invalid(t *testing.T) {
+	_, err := Fibonacci{}.Levels(decimal.Zero, decimal.NewFromInt(1))
+	assertEqualError(t, ErrInvalidIndicator, err)
+
+	_, err = Fibonacci{}.Swing(ZigZag{}, nil)
+	assertEqualError(t, ErrInvalidIndicator, err)
+}
+
+func assertEqualLevels(t *testing.T, rr []decimal.Decimal, exp []string, res []Level) {
+	t.Helper()
+
+	require.Len(t, res, len(exp))
+
+	for i := range exp {
+		assert.Equal(t, rr[i].String(), res[i].Ratio.String(), "ratio %d", i)
+		assert.Equal(t, exp[i], res[i].Price.String(), "price %d", i)
+	}
+}
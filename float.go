@@ -0,0 +1,417 @@
+package indc
+
+import (
+	"math"
+)
+
+// floating is a constraint that permits any floating-point type.
+type floating interface {
+	~float32 | ~float64
+}
+
+// FloatIndicator is an interface that every indicator with float64
+// fast path implementation should implement.
+// Float calculations share configuration and validation with decimal
+// calculations, however, they do not apply precision and rounding options
+// and results may differ from decimal calculations within float64
+// rounding error.
+type FloatIndicator interface {
+	// CalcFloat should return calculation results based on provided
+	// float64 data points slice.
+	CalcFloat([]float64) (float64, error)
+
+	// Count should determine the total amount data points required for
+	// the calculation.
+	Count() int
+}
+
+// avgFloat is a helper function that calculates average number of given
+// slice.
+func avgFloat[T floating](dd []T) T {
+	var sum T
+
+	for i := range dd {
+		sum += dd[i]
+	}
+
+	return sum / T(len(dd))
+}
+
+// mdevFloat calculates mean deviation of given slice.
+func mdevFloat[T floating](dd []T) T {
+	if len(dd) == 0 {
+		return 0
+	}
+
+	var res T
+
+	mean := avgFloat(dd)
+
+	for i := range dd {
+		res += T(math.Abs(float64(dd[i] - mean)))
+	}
+
+	return res / T(len(dd))
+}
+
+// sdevFloat calculates standart deviation of given slice.
+func sdevFloat[T floating](dd []T) T {
+	if len(dd) == 0 {
+		return 0
+	}
+
+	var res T
+
+	mean := avgFloat(dd)
+
+	for i := range dd {
+		res += (dd[i] - mean) * (dd[i] - mean)
+	}
+
+	return T(math.Sqrt(float64(res / T(len(dd)))))
+}
+
+// wmaFloat calculates weighted moving average of given slice.
+func wmaFloat[T floating](dd []T) T {
+	var res T
+
+	weight := T(len(dd)*(len(dd)+1)) / 2
+
+	for i := range dd {
+		res += dd[i] * T(i+1) / weight
+	}
+
+	return res
+}
+
+// rsiFloat calculates relative strength index of given slice.
+func rsiFloat[T floating](dd []T, length int) T {
+	var ag, al T
+
+	for i := 1; i < len(dd); i++ {
+		if diff := dd[i] - dd[i-1]; diff < 0 {
+			al -= diff
+		} else {
+			ag += diff
+		}
+	}
+
+	if ag == 0 {
+		return 0
+	}
+
+	if al == 0 {
+		return 100
+	}
+
+	ag /= T(length)
+	al /= T(length)
+
+	return 100 - 100/(1+ag/al)
+}
+
+// CalcFloat calculates Aroon from the provided float64 data points slice.
+func (aroon Aroon) CalcFloat(dd []float64) (float64, error) {
+	if !aroon.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(aroon.cfg, dd, aroon.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	res := dd[0]
+	prd := 0
+
+	for i := 0; i < len(dd); i++ {
+		if (aroon.trend == TrendDown && res >= dd[i]) ||
+			(aroon.trend != TrendDown && res <= dd[i]) {
+			res = dd[i]
+			prd = aroon.length - i - 1
+		}
+	}
+
+	return float64(aroon.length-prd) * 100 / float64(aroon.length), nil
+}
+
+// CalcFloat calculates BB from the provided float64 data points slice.
+func (bb BB) CalcFloat(dd []float64) (float64, error) {
+	if !bb.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(bb.cfg, dd, bb.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := bb.sma.CalcFloat(dd)
+	if err != nil {
+		// unlikely to happen
+		return 0, err
+	}
+
+	stdDev, _ := bb.stdDev.Float64()
+	sdev := sdevFloat(dd) * stdDev
+
+	if res == 0 && (bb.percent || bb.band == BandWidth) {
+		return bb.cfg.divByZeroFloat()
+	}
+
+	switch bb.band {
+	case BandUpper:
+		if bb.percent {
+			return bb.cfg.keepFloat(((res+sdev)/res - 1) * 100)
+		}
+
+		return bb.cfg.keepFloat(res + sdev)
+	case BandLower:
+		if bb.percent {
+			return bb.cfg.keepFloat(((res-sdev)/res - 1) * 100)
+		}
+
+		return bb.cfg.keepFloat(res - sdev)
+	default: // BB is validated, only BandWidth is left.
+		return bb.cfg.keepFloat(((res + sdev) - (res - sdev)) / res * 100)
+	}
+}
+
+// CalcFloat calculates CCI from the provided float64 data points slice.
+func (cci CCI) CalcFloat(dd []float64) (float64, error) {
+	if !cci.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	ma, ok := cci.ma.(FloatIndicator)
+	if !ok {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(cci.cfg, dd, cci.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := ma.CalcFloat(dd)
+	if err != nil {
+		return 0, err
+	}
+
+	factor, _ := cci.factor.Float64()
+	dnm := factor * mdevFloat(dd)
+
+	if dnm == 0 {
+		return cci.cfg.divByZeroFloat()
+	}
+
+	return cci.cfg.keepFloat((dd[len(dd)-1] - res) / dnm)
+}
+
+// CalcFloat calculates DEMA from the provided float64 data points slice.
+func (dema DEMA) CalcFloat(dd []float64) (float64, error) {
+	if !dema.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(dema.cfg, dd, dema.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	length := dema.ema.sma.length
+	pres := make([]float64, length)
+	pres[0] = avgFloat(dd[:length])
+
+	for i := length; i < len(dd); i++ {
+		pres[i-length+1] = dema.ema.calcNextFloat(pres[i-length], dd[i])
+	}
+
+	res := pres[0]
+
+	for i := 0; i < len(pres); i++ {
+		res = dema.ema.calcNextFloat(res, pres[i])
+	}
+
+	return res, nil
+}
+
+// CalcFloat calculates EMA from the provided float64 data points slice.
+func (ema EMA) CalcFloat(dd []float64) (float64, error) {
+	if !ema.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(ema.cfg, dd, ema.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	res := avgFloat(dd[:ema.sma.length])
+
+	for i := ema.sma.length; i < len(dd); i++ {
+		res = ema.calcNextFloat(res, dd[i])
+	}
+
+	return res, nil
+}
+
+// CalcNextFloat calculates sequential EMA by using previous float64 EMA.
+func (ema EMA) CalcNextFloat(lres, dec float64) (float64, error) {
+	if !ema.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	return ema.calcNextFloat(lres, dec), nil
+}
+
+// calcNextFloat calculates sequential EMA without validating the
+// indicator.
+func (ema EMA) calcNextFloat(lres, dec float64) float64 {
+	mtp := 2 / float64(ema.sma.length+1)
+
+	return dec*mtp + lres*(1-mtp)
+}
+
+// CalcFloat calculates HMA from the provided float64 data points slice.
+func (h HMA) CalcFloat(dd []float64) (float64, error) {
+	if !h.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(h.cfg, dd, h.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	l1 := h.wma.length / 2
+	l2 := int(math.Sqrt(float64(h.wma.length)))
+
+	res := make([]float64, l2)
+
+	for i := 0; i < l2; i++ {
+		res[i] = wmaFloat(dd[i:l1+i])*2 - wmaFloat(dd[i:h.wma.length+i])
+	}
+
+	return wmaFloat(res), nil
+}
+
+// CalcFloat calculates ROC from the provided float64 data points slice.
+func (roc ROC) CalcFloat(dd []float64) (float64, error) {
+	if !roc.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(roc.cfg, dd, roc.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	if dd[0] == 0 {
+		return roc.cfg.divByZeroFloat()
+	}
+
+	return roc.cfg.keepFloat((dd[len(dd)-1]/dd[0] - 1) * 100)
+}
+
+// CalcFloat calculates RSI from the provided float64 data points slice.
+func (rsi RSI) CalcFloat(dd []float64) (float64, error) {
+	if !rsi.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(rsi.cfg, dd, rsi.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	return rsiFloat(dd, rsi.length), nil
+}
+
+// CalcFloat calculates SMA from the provided float64 data points slice.
+func (sma SMA) CalcFloat(dd []float64) (float64, error) {
+	if !sma.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(sma.cfg, dd, sma.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	return avgFloat(dd), nil
+}
+
+// CalcFloat calculates SRSI from the provided float64 data points slice.
+func (srsi SRSI) CalcFloat(dd []float64) (float64, error) {
+	if !srsi.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(srsi.cfg, dd, srsi.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	res := make([]float64, srsi.rsi.length)
+
+	for i := 0; i < srsi.rsi.length; i++ {
+		res[i] = rsiFloat(dd[i:srsi.rsi.length+i], srsi.rsi.length)
+	}
+
+	curr := res[len(res)-1]
+	max := res[0]
+	min := res[0]
+
+	for i := 1; i < len(res); i++ {
+		max = math.Max(max, res[i])
+		min = math.Min(min, res[i])
+	}
+
+	if max == min {
+		return srsi.cfg.divByZeroFloat()
+	}
+
+	return srsi.cfg.keepFloat((curr - min) / (max - min))
+}
+
+// CalcFloat calculates Stoch from the provided float64 data points slice.
+func (stoch Stoch) CalcFloat(dd []float64) (float64, error) {
+	if !stoch.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(stoch.cfg, dd, stoch.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	low := dd[0]
+	high := dd[0]
+
+	for i := 0; i < len(dd); i++ {
+		low = math.Min(low, dd[i])
+		high = math.Max(high, dd[i])
+	}
+
+	dnm := high - low
+	if dnm == 0 {
+		return stoch.cfg.divByZeroFloat()
+	}
+
+	return stoch.cfg.keepFloat((dd[len(dd)-1] - low) / dnm * 100)
+}
+
+// CalcFloat calculates WMA from the provided float64 data points slice.
+func (wma WMA) CalcFloat(dd []float64) (float64, error) {
+	if !wma.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	dd, err := window(wma.cfg, dd, wma.Count())
+	if err != nil {
+		return 0, err
+	}
+
+	return wmaFloat(dd), nil
+}
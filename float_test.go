@@ -0,0 +1,320 @@
+package indc
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_avgFloat(t *testing.T) {
+	assert.InDelta(t, 5.0, avgFloat([]float64{2, 5, 8}), 1e-12)
+	assert.InDelta(t, float32(1.5), avgFloat([]float32{1, 2}), 1e-6)
+}
+
+func Test_mdevFloat(t *testing.T) {
+	assert.Equal(t, 0.0, mdevFloat([]float64{}))
+	assert.InDelta(t, 2.0, mdevFloat([]float64{2, 5, 8}), 1e-12)
+}
+
+func Test_sdevFloat(t *testing.T) {
+	assert.Equal(t, 0.0, sdevFloat([]float64{}))
+	assert.InDelta(t, 2.449489742783178, sdevFloat([]float64{2, 5, 8}), 1e-12)
+}
+
+func Test_EMA_CalcNextFloat(t *testing.T) {
+	_, err := EMA{}.CalcNextFloat(1, 2)
+	assertEqualError(t, ErrInvalidIndicator, err)
+
+	ema, err := NewEMA(3)
+	require.NoError(t, err)
+
+	res, err := ema.CalcNextFloat(10, 20)
+	require.NoError(t, err)
+	assert.InDelta(t, 15.0, res, 1e-12)
+}
+
+func Test_CalcFloat_Errors(t *testing.T) {
+	cc := map[string]FloatIndicator{
+		"Aroon": Aroon{},
+		"BB":    BB{},
+		"CCI":   CCI{},
+		"DEMA":  DEMA{},
+		"EMA":   EMA{},
+		"HMA":   HMA{},
+		"ROC":   ROC{},
+		"RSI":   RSI{},
+		"SMA":   SMA{},
+		"SRSI":  SRSI{},
+		"Stoch": Stoch{},
+		"WMA":   WMA{},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn+" invalid indicator", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := c.CalcFloat([]float64{1})
+			assertEqualError(t, ErrInvalidIndicator, err)
+		})
+	}
+
+	for cn, c := range floatIndicators(t) {
+		c := c
+
+		t.Run(cn+" invalid data size", func(t *testing.T) {
+			t.Parallel()
+
+			_, err := c.(FloatIndicator).CalcFloat(make([]float64, c.Count()+1))
+			assertEqualError(t, &DataSizeError{Expected: c.Count(), Actual: c.Count() + 1}, err)
+		})
+	}
+}
+
+func Test_CalcFloat_ZeroPolicy(t *testing.T) {
+	must := func(ind FloatIndicator, err error) FloatIndicator {
+		require.NoError(t, err)
+		return ind
+	}
+
+	cc := map[string]struct {
+		Indicator FloatIndicator
+		Data      []float64
+	}{
+		"BB": {
+			Indicator: must(NewBB(true, BandUpper, decimal.NewFromInt(2), 2, WithZeroPolicy(ZeroPolicyError))),
+			Data:      []float64{0, 0},
+		},
+		"CCI": {
+			Indicator: must(NewCCI(MATypeSMA, 2, decimal.Zero, WithZeroPolicy(ZeroPolicyError))),
+			Data:      []float64{1, 1},
+		},
+		"ROC": {
+			Indicator: must(NewROC(2, WithZeroPolicy(ZeroPolicyError))),
+			Data:      []float64{0, 1},
+		},
+		"SRSI": {
+			Indicator: must(NewSRSI(2, WithZeroPolicy(ZeroPolicyError))),
+			Data:      []float64{1, 1, 1},
+		},
+		"Stoch": {
+			Indicator: must(NewStoch(2, WithZeroPolicy(ZeroPolicyError))),
+			Data:      []float64{1, 1},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := c.Indicator.CalcFloat(c.Data)
+			assertEqualError(t, ErrDivisionByZero, err)
+
+			dd := make([]decimal.Decimal, len(c.Data))
+			for i := range c.Data {
+				dd[i] = decimal.NewFromFloat(c.Data[i])
+			}
+
+			_, err = c.Indicator.(Indicator).Calc(dd)
+			assertEqualError(t, ErrDivisionByZero, err)
+		})
+	}
+}
+
+func Test_ZeroPolicyPrevious(t *testing.T) {
+	must := func(ind FloatIndicator, err error) FloatIndicator {
+		require.NoError(t, err)
+		return ind
+	}
+
+	opt := WithZeroPolicy(ZeroPolicyPrevious)
+
+	cc := map[string]struct {
+		Indicator FloatIndicator
+		Data      []float64
+		Zero      []float64
+	}{
+		"BB": {
+			Indicator: must(NewBB(true, BandUpper, decimal.NewFromInt(2), 2, opt)),
+			Data:      []float64{1, 3},
+			Zero:      []float64{0, 0},
+		},
+		"CCI": {
+			Indicator: must(NewCCI(MATypeSMA, 2, decimal.RequireFromString("0.015"), opt)),
+			Data:      []float64{1, 3},
+			Zero:      []float64{1, 1},
+		},
+		"ROC": {
+			Indicator: must(NewROC(2, opt)),
+			Data:      []float64{1, 2},
+			Zero:      []float64{0, 1},
+		},
+		"SRSI": {
+			Indicator: must(NewSRSI(2, opt)),
+			Data:      []float64{2, 1, 2},
+			Zero:      []float64{1, 1, 1},
+		},
+		"Stoch": {
+			Indicator: must(NewStoch(2, opt)),
+			Data:      []float64{1, 3},
+			Zero:      []float64{1, 1},
+		},
+	}
+
+	decimals := func(ff []float64) []decimal.Decimal {
+		dd := make([]decimal.Decimal, len(ff))
+		for i := range ff {
+			dd[i] = decimal.NewFromFloat(ff[i])
+		}
+
+		return dd
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Indicator.CalcFloat(c.Zero)
+			require.NoError(t, err)
+			assert.Zero(t, res)
+
+			exp, err := c.Indicator.CalcFloat(c.Data)
+			require.NoError(t, err)
+			assert.NotZero(t, exp)
+
+			res, err = c.Indicator.CalcFloat(c.Zero)
+			require.NoError(t, err)
+			assert.Equal(t, exp, res)
+
+			ind := c.Indicator.(Indicator)
+
+			dexp, err := ind.Calc(decimals(c.Data))
+			require.NoError(t, err)
+
+			dres, err := ind.Calc(decimals(c.Zero))
+			require.NoError(t, err)
+			assert.Equal(t, dexp.String(), dres.String())
+		})
+	}
+}
+
+func Test_ZeroPolicyPrevious_PerSeries(t *testing.T) {
+	newROC := func() ROC {
+		roc, err := NewROC(2, WithZeroPolicy(ZeroPolicyPrevious))
+		require.NoError(t, err)
+
+		return roc
+	}
+
+	zero := ratios("0", "1")
+
+	// separate indicators remember values of their own series.
+	a, b := newROC(), newROC()
+
+	res, err := a.Calc(ratios("1", "2"))
+	require.NoError(t, err)
+	assert.Equal(t, "100", res.String())
+
+	res, err = b.Calc(ratios("2", "1"))
+	require.NoError(t, err)
+	assert.Equal(t, "-50", res.String())
+
+	res, err = a.Calc(zero)
+	require.NoError(t, err)
+	assert.Equal(t, "100", res.String())
+
+	res, err = b.Calc(zero)
+	require.NoError(t, err)
+	assert.Equal(t, "-50", res.String())
+
+	// copies share the remembered value, so a single indicator must not
+	// be used with several series.
+	c := a
+
+	_, err = c.Calc(ratios("2", "1"))
+	require.NoError(t, err)
+
+	res, err = a.Calc(zero)
+	require.NoError(t, err)
+	assert.Equal(t, "-50", res.String())
+
+	// values are shared between decimal and float64 calculations.
+	f, err := a.CalcFloat([]float64{0, 1})
+	require.NoError(t, err)
+	assert.Equal(t, -50.0, f)
+}
+
+func Test_CalcFloat_MatchesCalc(t *testing.T) {
+	for cn, c := range floatIndicators(t) {
+		cn, c := cn, c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			rnd := rand.New(rand.NewSource(int64(len(cn)))) //nolint:gosec // deterministic data is needed
+
+			for i := 0; i < 50; i++ {
+				ff := make([]float64, c.Count())
+				dd := make([]decimal.Decimal, c.Count())
+
+				for j := range ff {
+					dd[j] = decimal.NewFromFloatWithExponent(1+rnd.Float64()*100, -4)
+					ff[j], _ = dd[j].Float64()
+				}
+
+				exp, err := c.Calc(dd)
+				require.NoError(t, err)
+
+				res, err := c.(FloatIndicator).CalcFloat(ff)
+				require.NoError(t, err)
+
+				expf, _ := exp.Float64()
+				assert.InDelta(t, expf, res, 1e-9*math.Max(1, math.Abs(expf)))
+			}
+		})
+	}
+}
+
+// floatIndicators returns valid indicators that implement float64
+// calculations.
+func floatIndicators(t *testing.T) map[string]Indicator {
+	t.Helper()
+
+	must := func(ind Indicator, err error) Indicator {
+		require.NoError(t, err)
+		return ind
+	}
+
+	return map[string]Indicator{
+		"Aroon up":        must(NewAroon(TrendUp, 7)),
+		"Aroon down":      must(NewAroon(TrendDown, 7)),
+		"BB upper":        must(NewBB(false, BandUpper, decimal.NewFromInt(2), 9)),
+		"BB lower":        must(NewBB(true, BandLower, decimal.NewFromInt(2), 9)),
+		"BB width":        must(NewBB(false, BandWidth, decimal.RequireFromString("1.5"), 9)),
+		"CCI with SMA":    must(NewCCI(MATypeSMA, 8, decimal.Zero)),
+		"CCI with HMA":    must(NewCCI(MATypeHMA, 9, decimal.Zero)),
+		"DEMA":            must(NewDEMA(6)),
+		"EMA":             must(NewEMA(6)),
+		"HMA":             must(NewHMA(9)),
+		"ROC":             must(NewROC(5)),
+		"RSI":             must(NewRSI(10)),
+		"SMA":             must(NewSMA(5)),
+		"SRSI":            must(NewSRSI(6)),
+		"Stoch":           must(NewStoch(8)),
+		"WMA":             must(NewWMA(7)),
+		"CCI with WMA":    must(NewCCI(MATypeWMA, 4, decimal.Zero)),
+		"CCI with EMA":    must(NewCCI(MATypeEMA, 4, decimal.Zero)),
+		"CCI with DEMA":   must(NewCCI(MATypeDEMA, 4, decimal.Zero)),
+		"EMA with len 1":  must(NewEMA(1)),
+		"HMA with len 16": must(NewHMA(16)),
+	}
+}
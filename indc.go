@@ -1,5 +1,9 @@
 // Package indc provides types and functions to calculate values of various
 // market indicators.
+//
+// All indicators expect data points to be ordered from the oldest to the
+// newest, i.e. the last element of a slice is the most recent value.
+// Ordered wrapper can be used to supply data points in the reverse order.
 package indc
 
 import (
@@ -15,6 +19,9 @@ type Aroon struct {
 	// valid specifies whether Aroon paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// trend specifies which Aroon trend to use during the
 	// calculation process.
 	trend Trend
@@ -26,8 +33,9 @@ type Aroon struct {
 
 // NewAroon validates provided configuration options and
 // creates new Aroon indicator instance.
-func NewAroon(trend Trend, length int) (Aroon, error) {
+func NewAroon(trend Trend, length int, opts ...Option) (Aroon, error) {
 	aroon := Aroon{
+		cfg:    newConfig(opts),
 		trend:  trend,
 		length: length,
 	}
@@ -41,12 +49,28 @@ func NewAroon(trend Trend, length int) (Aroon, error) {
 
 // validate checks whether the indicator has valid configuration properties.
 func (aroon *Aroon) validate() error {
-	if err := aroon.trend.Validate(); err != nil {
+	if err := aroon.cfg.validate("aroon"); err != nil {
 		return err
 	}
 
+	if err := aroon.trend.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  "aroon",
+			Param:      "trend",
+			Value:      aroon.trend,
+			Constraint: "must be a valid trend",
+			Err:        err,
+		}
+	}
+
 	if aroon.length < 1 {
-		return ErrInvalidLength
+		return &ValidationError{
+			Indicator:  "aroon",
+			Param:      "length",
+			Value:      aroon.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
 	}
 
 	aroon.valid = true
@@ -63,8 +87,9 @@ func (aroon Aroon) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != aroon.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(aroon.cfg, dd, aroon.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	res := dd[0]
@@ -86,8 +111,8 @@ func (aroon Aroon) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		}
 	}
 
-	return decimal.NewFromInt(int64(aroon.length)).Sub(prd).
-		Mul(_hundred).Div(decimal.NewFromInt(int64(aroon.length))), nil
+	return aroon.cfg.div(decimal.NewFromInt(int64(aroon.length)).Sub(prd).
+		Mul(_hundred), decimal.NewFromInt(int64(aroon.length))), nil
 }
 
 // Count determines the total amount of data points needed for Aroon
@@ -102,6 +127,9 @@ type BB struct {
 	// valid specifies whether BB paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// percent specifies whether returned number should be in units (if false)
 	// or percent (true).
 	percent bool
@@ -118,13 +146,14 @@ type BB struct {
 
 // NewBB validates provided configuration options and creates
 // new BB indicator.
-func NewBB(percent bool, band Band, stdDev decimal.Decimal, length int) (BB, error) {
-	sma, err := NewSMA(length)
+func NewBB(percent bool, band Band, stdDev decimal.Decimal, length int, opts ...Option) (BB, error) {
+	sma, err := NewSMA(length, opts...)
 	if err != nil {
-		return BB{}, err
+		return BB{}, relabel(err, "bb")
 	}
 
 	bb := BB{
+		cfg:     sma.cfg,
 		percent: percent,
 		band:    band,
 		stdDev:  stdDev,
@@ -141,11 +170,23 @@ func NewBB(percent bool, band Band, stdDev decimal.Decimal, length int) (BB, err
 // validate checks whether the indicator has valid configuration properties.
 func (bb *BB) validate() error {
 	if err := bb.band.Validate(); err != nil {
-		return err
+		return &ValidationError{
+			Indicator:  "bb",
+			Param:      "band",
+			Value:      bb.band,
+			Constraint: "must be a valid band",
+			Err:        err,
+		}
 	}
 
 	if bb.percent && bb.band == BandWidth {
-		return errors.New("invalid bb configuration")
+		return &ValidationError{
+			Indicator:  "bb",
+			Param:      "band",
+			Value:      bb.band,
+			Constraint: "must not be width when percent is enabled",
+			Err:        ErrInvalidBand,
+		}
 	}
 
 	bb.valid = true
@@ -162,8 +203,9 @@ func (bb BB) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != bb.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(bb.cfg, dd, bb.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	res, err := bb.sma.Calc(dd)
@@ -172,23 +214,27 @@ func (bb BB) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, err
 	}
 
-	sdev := sdev(dd).Mul(bb.stdDev)
+	sdev := bb.cfg.sdev(dd).Mul(bb.stdDev)
+
+	if res.IsZero() && (bb.percent || bb.band == BandWidth) {
+		return bb.cfg.divByZero()
+	}
 
 	switch bb.band {
 	case BandUpper:
 		if bb.percent {
-			return res.Add(sdev).Div(res).Sub(_one).Mul(_hundred), nil
+			return bb.cfg.keep(bb.cfg.round(bb.cfg.div(res.Add(sdev), res).Sub(_one).Mul(_hundred)))
 		}
 
-		return res.Add(sdev), nil
+		return bb.cfg.keep(bb.cfg.round(res.Add(sdev)))
 	case BandLower:
 		if bb.percent {
-			return res.Sub(sdev).Div(res).Sub(_one).Mul(_hundred), nil
+			return bb.cfg.keep(bb.cfg.round(bb.cfg.div(res.Sub(sdev), res).Sub(_one).Mul(_hundred)))
 		}
 
-		return res.Sub(sdev), nil
+		return bb.cfg.keep(bb.cfg.round(res.Sub(sdev)))
 	default: // BB is validated, only BandWidth is left.
-		return res.Add(sdev).Sub(res.Sub(sdev)).Div(res).Mul(_hundred), nil
+		return bb.cfg.keep(bb.cfg.round(bb.cfg.div(res.Add(sdev).Sub(res.Sub(sdev)), res).Mul(_hundred)))
 	}
 }
 
@@ -205,6 +251,9 @@ type CCI struct {
 	// valid specifies whether CCI paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// ma specifies moving average indicator configuration.
 	ma Indicator
 
@@ -216,17 +265,28 @@ type CCI struct {
 // NewCCI validates provided configuration options and creates
 // new CCI indicator.
 // If provided factor is zero, default value is going to be used (0.015f).
-func NewCCI(mat MAType, length int, factor decimal.Decimal) (CCI, error) {
+func NewCCI(mat MAType, length int, factor decimal.Decimal, opts ...Option) (CCI, error) {
 	if factor.Equal(decimal.Zero) {
 		factor = decimal.RequireFromString("0.015")
 	}
 
-	ma, err := mat.Initialize(length)
+	ma, err := mat.Initialize(length, opts...)
 	if err != nil {
-		return CCI{}, err
+		if errors.Is(err, ErrInvalidMA) {
+			return CCI{}, &ValidationError{
+				Indicator:  "cci",
+				Param:      "ma",
+				Value:      mat,
+				Constraint: "must be a valid moving average type",
+				Err:        err,
+			}
+		}
+
+		return CCI{}, relabel(err, "cci")
 	}
 
 	cci := CCI{
+		cfg:    newConfig(opts),
 		ma:     ma,
 		factor: factor,
 	}
@@ -240,8 +300,18 @@ func NewCCI(mat MAType, length int, factor decimal.Decimal) (CCI, error) {
 
 // validate checks whether the indicator has valid configuration properties.
 func (cci *CCI) validate() error {
+	if err := cci.cfg.validate("cci"); err != nil {
+		return err
+	}
+
 	if cci.factor.LessThanOrEqual(decimal.Zero) {
-		return errors.New("invalid factor")
+		return &ValidationError{
+			Indicator:  "cci",
+			Param:      "factor",
+			Value:      cci.factor,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidFactor,
+		}
 	}
 
 	cci.valid = true
@@ -258,8 +328,9 @@ func (cci CCI) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != cci.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(cci.cfg, dd, cci.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	res, err := cci.ma.Calc(dd)
@@ -267,13 +338,13 @@ func (cci CCI) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, err
 	}
 
-	dnm := cci.factor.Mul(mdev(dd))
+	dnm := cci.factor.Mul(cci.cfg.mdev(dd))
 
 	if dnm.Equal(decimal.Zero) {
-		return decimal.Zero, nil
+		return cci.cfg.divByZero()
 	}
 
-	return dd[len(dd)-1].Sub(res).Div(dnm), nil
+	return cci.cfg.keep(cci.cfg.div(dd[len(dd)-1].Sub(res), dnm))
 }
 
 // Count determines the total amount of data points needed for CCI
@@ -289,20 +360,24 @@ type DEMA struct {
 	// valid specifies whether DEMA paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// ema specifies what ema should be used for dema calculations.
 	ema EMA
 }
 
 // NewDEMA validates provided configuration options and creates
 // new DEMA indicator.
-func NewDEMA(length int) (DEMA, error) {
-	ema, err := NewEMA(length)
+func NewDEMA(length int, opts ...Option) (DEMA, error) {
+	ema, err := NewEMA(length, opts...)
 	if err != nil {
-		return DEMA{}, err
+		return DEMA{}, relabel(err, "dema")
 	}
 
 	return DEMA{
 		valid: true,
+		cfg:   ema.cfg,
 		ema:   ema,
 	}, nil
 }
@@ -316,14 +391,13 @@ func (dema DEMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != dema.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(dema.cfg, dd, dema.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	pres := make([]decimal.Decimal, dema.ema.sma.length)
 
-	var err error
-
 	pres[0], err = dema.ema.sma.Calc(dd[:dema.ema.sma.length])
 	if err != nil {
 		// unlikely to happen
@@ -364,20 +438,24 @@ type EMA struct {
 	// valid specifies whether DEMA paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// sma specifies what sma should be used for ema calculations.
 	sma SMA
 }
 
 // NewEMA validates provided configuration options and
 // creates new EMA indicator.
-func NewEMA(length int) (EMA, error) {
-	sma, err := NewSMA(length)
+func NewEMA(length int, opts ...Option) (EMA, error) {
+	sma, err := NewSMA(length, opts...)
 	if err != nil {
-		return EMA{}, err
+		return EMA{}, relabel(err, "ema")
 	}
 
 	return EMA{
 		valid: true,
+		cfg:   sma.cfg,
 		sma:   sma,
 	}, nil
 }
@@ -390,8 +468,9 @@ func (ema EMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != ema.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(ema.cfg, dd, ema.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	res, err := ema.sma.Calc(dd[:ema.sma.length])
@@ -419,12 +498,12 @@ func (ema EMA) CalcNext(lres, dec decimal.Decimal) (decimal.Decimal, error) {
 
 	mtp := ema.multiplier()
 
-	return dec.Mul(mtp).Add(lres.Mul(decimal.NewFromInt(1).Sub(mtp))), nil
+	return ema.cfg.round(dec.Mul(mtp).Add(lres.Mul(decimal.NewFromInt(1).Sub(mtp)))), nil
 }
 
 // multiplier calculates EMA multiplier.
 func (ema EMA) multiplier() decimal.Decimal {
-	return decimal.NewFromInt(2).Div(decimal.NewFromInt(int64(ema.sma.length) + 1))
+	return ema.cfg.div(decimal.NewFromInt(2), decimal.NewFromInt(int64(ema.sma.length)+1))
 }
 
 // Count determines the total amount of data points needed for EMA
@@ -440,20 +519,24 @@ type HMA struct {
 	// valid specifies whether HMA paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// wma specifies the base moving average.
 	wma WMA
 }
 
 // NewHMA validates provided configuration options and
 // creates new HMA indicator.
-func NewHMA(length int) (HMA, error) {
-	wma, err := NewWMA(length)
+func NewHMA(length int, opts ...Option) (HMA, error) {
+	wma, err := NewWMA(length, opts...)
 	if err != nil {
-		return HMA{}, err
+		return HMA{}, relabel(err, "hma")
 	}
 
 	return HMA{
 		valid: true,
+		cfg:   wma.cfg,
 		wma:   wma,
 	}, nil
 }
@@ -467,12 +550,13 @@ func (h HMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != h.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(h.cfg, dd, h.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
-	wma1 := WMA{length: h.wma.length / 2, valid: true}
-	wma2 := WMA{length: int(math.Sqrt(float64(h.wma.length))), valid: true}
+	wma1 := WMA{length: h.wma.length / 2, valid: true, cfg: h.cfg}
+	wma2 := WMA{length: int(math.Sqrt(float64(h.wma.length))), valid: true, cfg: h.cfg}
 
 	res := make([]decimal.Decimal, wma2.length)
 
@@ -508,6 +592,9 @@ type ROC struct {
 	// valid specifies whether ROC paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// length specifies how many data points should be used
 	// during the calculations.
 	length int
@@ -515,8 +602,11 @@ type ROC struct {
 
 // NewROC validates provided configuration options and
 // creates new ROC indicator.
-func NewROC(length int) (ROC, error) {
-	roc := ROC{length: length}
+func NewROC(length int, opts ...Option) (ROC, error) {
+	roc := ROC{
+		cfg:    newConfig(opts),
+		length: length,
+	}
 
 	if err := roc.validate(); err != nil {
 		return ROC{}, err
@@ -527,8 +617,18 @@ func NewROC(length int) (ROC, error) {
 
 // validate checks whether the indicator has valid configuration properties.
 func (roc *ROC) validate() error {
+	if err := roc.cfg.validate("roc"); err != nil {
+		return err
+	}
+
 	if roc.length < 1 {
-		return ErrInvalidLength
+		return &ValidationError{
+			Indicator:  "roc",
+			Param:      "length",
+			Value:      roc.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
 	}
 
 	roc.valid = true
@@ -544,14 +644,19 @@ func (roc ROC) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != roc.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(roc.cfg, dd, roc.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
-	curr := dd[0]
-	last := dd[len(dd)-1]
+	prev := dd[0]
+	curr := dd[len(dd)-1]
+
+	if prev.IsZero() {
+		return roc.cfg.divByZero()
+	}
 
-	return curr.Div(last).Sub(_one).Mul(_hundred), nil
+	return roc.cfg.keep(roc.cfg.round(roc.cfg.div(curr, prev).Sub(_one).Mul(_hundred)))
 }
 
 // Count determines the total amount of data points needed for ROC
@@ -567,6 +672,9 @@ type RSI struct {
 	// valid specifies whether RSI paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// length specifies how many data points should be used
 	// during the calculations.
 	length int
@@ -574,8 +682,9 @@ type RSI struct {
 
 // NewRSI validates provided configuration options and
 // creates new RSI indicator.
-func NewRSI(length int) (RSI, error) {
+func NewRSI(length int, opts ...Option) (RSI, error) {
 	rsi := RSI{
+		cfg:    newConfig(opts),
 		length: length,
 	}
 
@@ -588,8 +697,18 @@ func NewRSI(length int) (RSI, error) {
 
 // validate checks whether the indicator has valid configuration properties.
 func (rsi *RSI) validate() error {
+	if err := rsi.cfg.validate("rsi"); err != nil {
+		return err
+	}
+
 	if rsi.length < 1 {
-		return ErrInvalidLength
+		return &ValidationError{
+			Indicator:  "rsi",
+			Param:      "length",
+			Value:      rsi.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
 	}
 
 	rsi.valid = true
@@ -606,8 +725,9 @@ func (rsi RSI) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != rsi.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(rsi.cfg, dd, rsi.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	ag := decimal.Zero
@@ -630,11 +750,11 @@ func (rsi RSI) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return _hundred, nil
 	}
 
-	ag = ag.Div(length)
+	ag = rsi.cfg.div(ag, length)
 
-	al = al.Div(length)
+	al = rsi.cfg.div(al, length)
 
-	return _hundred.Sub(_hundred.Div(decimal.NewFromInt(1).Add(ag.Div(al)))), nil
+	return _hundred.Sub(rsi.cfg.div(_hundred, decimal.NewFromInt(1).Add(rsi.cfg.div(ag, al)))), nil
 }
 
 // Count determines the total amount of data points needed for RSI
@@ -650,6 +770,9 @@ type SMA struct {
 	// valid specifies whether SMA paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// length specifies how many data points should be used
 	// during the calculations.
 	length int
@@ -657,8 +780,9 @@ type SMA struct {
 
 // NewSMA validates provided configuration options and
 // creates new SMA indicator.
-func NewSMA(length int) (SMA, error) {
+func NewSMA(length int, opts ...Option) (SMA, error) {
 	sma := SMA{
+		cfg:    newConfig(opts),
 		length: length,
 	}
 
@@ -671,8 +795,18 @@ func NewSMA(length int) (SMA, error) {
 
 // validate checks whether the indicator has valid configuration properties.
 func (sma *SMA) validate() error {
+	if err := sma.cfg.validate("sma"); err != nil {
+		return err
+	}
+
 	if sma.length < 1 {
-		return ErrInvalidLength
+		return &ValidationError{
+			Indicator:  "sma",
+			Param:      "length",
+			Value:      sma.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
 	}
 
 	sma.valid = true
@@ -688,8 +822,9 @@ func (sma SMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != sma.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(sma.cfg, dd, sma.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	res := decimal.Zero
@@ -697,7 +832,7 @@ func (sma SMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		res = res.Add(dd[i])
 	}
 
-	return res.Div(decimal.NewFromInt(int64(sma.length))), nil
+	return sma.cfg.div(res, decimal.NewFromInt(int64(sma.length))), nil
 }
 
 // Count determines the total amount of data points needed for SMA
@@ -713,20 +848,24 @@ type SRSI struct {
 	// valid specifies whether SRSI paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// rsi specifies the base relative strength index.
 	rsi RSI
 }
 
 // NewSRSI validates provided configuration options and
 // creates new SRSI indicator.
-func NewSRSI(length int) (SRSI, error) {
-	rsi, err := NewRSI(length)
+func NewSRSI(length int, opts ...Option) (SRSI, error) {
+	rsi, err := NewRSI(length, opts...)
 	if err != nil {
-		return SRSI{}, err
+		return SRSI{}, relabel(err, "srsi")
 	}
 
 	return SRSI{
 		valid: true,
+		cfg:   rsi.cfg,
 		rsi:   rsi,
 	}, nil
 }
@@ -739,13 +878,13 @@ func (srsi SRSI) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != srsi.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(srsi.cfg, dd, srsi.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	res := make([]decimal.Decimal, srsi.rsi.length)
 
-	var err error
 	for i := 0; i < srsi.rsi.length; i++ {
 		res[i], err = srsi.rsi.Calc(dd[i : srsi.rsi.length+i])
 		if err != nil {
@@ -754,7 +893,7 @@ func (srsi SRSI) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		}
 	}
 
-	curr := res[0]
+	curr := res[len(res)-1]
 	max := res[0]
 	min := res[0]
 
@@ -769,10 +908,10 @@ func (srsi SRSI) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 	}
 
 	if max.Equal(min) {
-		return decimal.Zero, nil
+		return srsi.cfg.divByZero()
 	}
 
-	return curr.Sub(min).Div(max.Sub(min)), nil
+	return srsi.cfg.keep(srsi.cfg.div(curr.Sub(min), max.Sub(min)))
 }
 
 // Count determines the total amount of data needed for SRSI
@@ -788,6 +927,9 @@ type Stoch struct {
 	// valid specifies whether Stoch paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// length specifies how many data points should be used
 	// during the calculations.
 	length int
@@ -795,8 +937,9 @@ type Stoch struct {
 
 // NewStoch validates provided configuration options and
 // creates new Stoch indicator.
-func NewStoch(length int) (Stoch, error) {
+func NewStoch(length int, opts ...Option) (Stoch, error) {
 	stoch := Stoch{
+		cfg:    newConfig(opts),
 		length: length,
 	}
 
@@ -809,8 +952,18 @@ func NewStoch(length int) (Stoch, error) {
 
 // validate checks whether the indicator has valid configuration properties.
 func (stoch *Stoch) validate() error {
+	if err := stoch.cfg.validate("stoch"); err != nil {
+		return err
+	}
+
 	if stoch.length < 1 {
-		return ErrInvalidLength
+		return &ValidationError{
+			Indicator:  "stoch",
+			Param:      "length",
+			Value:      stoch.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
 	}
 
 	stoch.valid = true
@@ -826,8 +979,9 @@ func (stoch Stoch) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != stoch.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(stoch.cfg, dd, stoch.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	low := dd[0]
@@ -845,10 +999,10 @@ func (stoch Stoch) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 
 	dnm := high.Sub(low)
 	if dnm.Equal(decimal.Zero) {
-		return decimal.Zero, nil
+		return stoch.cfg.divByZero()
 	}
 
-	return dd[len(dd)-1].Sub(low).Div(dnm).Mul(_hundred), nil
+	return stoch.cfg.keep(stoch.cfg.round(stoch.cfg.div(dd[len(dd)-1].Sub(low), dnm).Mul(_hundred)))
 }
 
 // Count determines the total amount of data points needed for Stoch
@@ -864,6 +1018,9 @@ type WMA struct {
 	// valid specifies whether WMA paremeters were validated.
 	valid bool
 
+	// cfg specifies optional configuration properties.
+	cfg config
+
 	// length specifies how many data points should be used
 	// during the calculations.
 	length int
@@ -871,8 +1028,9 @@ type WMA struct {
 
 // NewWMA validates provided configuration options and
 // creates new WMA indicator.
-func NewWMA(length int) (WMA, error) {
+func NewWMA(length int, opts ...Option) (WMA, error) {
 	wma := WMA{
+		cfg:    newConfig(opts),
 		length: length,
 	}
 
@@ -885,8 +1043,18 @@ func NewWMA(length int) (WMA, error) {
 
 // validate checks whether the indicator has valid configuration properties.
 func (wma *WMA) validate() error {
+	if err := wma.cfg.validate("wma"); err != nil {
+		return err
+	}
+
 	if wma.length < 1 {
-		return ErrInvalidLength
+		return &ValidationError{
+			Indicator:  "wma",
+			Param:      "length",
+			Value:      wma.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
 	}
 
 	wma.valid = true
@@ -902,19 +1070,20 @@ func (wma WMA) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
 		return decimal.Zero, ErrInvalidIndicator
 	}
 
-	if len(dd) != wma.Count() {
-		return decimal.Zero, ErrInvalidDataSize
+	dd, err := window(wma.cfg, dd, wma.Count())
+	if err != nil {
+		return decimal.Zero, err
 	}
 
 	res := decimal.Zero
 
-	weight := decimal.NewFromInt(int64(wma.length * (wma.length + 1))).Div(decimal.NewFromInt(2))
+	weight := wma.cfg.div(decimal.NewFromInt(int64(wma.length*(wma.length+1))), decimal.NewFromInt(2))
 
 	for i := 0; i < len(dd); i++ {
-		res = res.Add(dd[i].Mul(decimal.NewFromInt(int64(i + 1)).Div(weight)))
+		res = res.Add(dd[i].Mul(wma.cfg.div(decimal.NewFromInt(int64(i+1)), weight)))
 	}
 
-	return res, nil
+	return wma.cfg.round(res), nil
 }
 
 // Count determines the total amount of data points needed for WMA
@@ -1,7 +1,6 @@
 package indc
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -53,13 +52,25 @@ func Test_Aroon_validate(t *testing.T) {
 				trend:  70,
 				length: 5,
 			},
-			Error: ErrInvalidTrend,
+			Error: &ValidationError{
+				Indicator:  "aroon",
+				Param:      "trend",
+				Value:      Trend(70),
+				Constraint: "must be a valid trend",
+				Err:        ErrInvalidTrend,
+			},
 		},
 		"Invalid length": {
 			Aroon: Aroon{
 				trend: TrendDown,
 			},
-			Error: ErrInvalidLength,
+			Error: &ValidationError{
+				Indicator:  "aroon",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
 		},
 		"Successfully validated": {
 			Aroon: Aroon{
@@ -105,7 +116,7 @@ func Test_Aroon_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 5, Actual: 1},
 		},
 		"Successful calculation with TrendUp": {
 			Aroon: Aroon{
@@ -172,11 +183,23 @@ func Test_NewBB(t *testing.T) {
 		Error   error
 	}{
 		"NewSMA returns an error": {
-			Error: assert.AnError,
+			Error: &ValidationError{
+				Indicator:  "bb",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
 		},
 		"Validate returns an error": {
 			Length: 1,
-			Error:  ErrInvalidBand,
+			Error: &ValidationError{
+				Indicator:  "bb",
+				Param:      "band",
+				Value:      Band(0),
+				Constraint: "must be a valid band",
+				Err:        ErrInvalidBand,
+			},
 		},
 		"Successfully created new BB": {
 			Percent: true,
@@ -222,7 +245,13 @@ func Test_BB_validate(t *testing.T) {
 					length: 5,
 				},
 			},
-			Error: ErrInvalidBand,
+			Error: &ValidationError{
+				Indicator:  "bb",
+				Param:      "band",
+				Value:      Band(70),
+				Constraint: "must be a valid band",
+				Err:        ErrInvalidBand,
+			},
 		},
 		"Invalid BB band width configuration": {
 			BB: BB{
@@ -233,7 +262,13 @@ func Test_BB_validate(t *testing.T) {
 					length: 1,
 				},
 			},
-			Error: errors.New("invalid bb configuration"),
+			Error: &ValidationError{
+				Indicator:  "bb",
+				Param:      "band",
+				Value:      BandWidth,
+				Constraint: "must not be width when percent is enabled",
+				Err:        ErrInvalidBand,
+			},
 		},
 		"Successfully validated": {
 			BB: BB{
@@ -285,7 +320,7 @@ func Test_BB_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 5, Actual: 1},
 		},
 		"Successful calculation with BandUpper": {
 			BB: BB{
@@ -435,13 +470,25 @@ func Test_NewCCI(t *testing.T) {
 		},
 		"Invalid provided moving average type": {
 			Length: 1,
-			Error:  errors.New("invalid moving average"),
+			Error: &ValidationError{
+				Indicator:  "cci",
+				Param:      "ma",
+				Value:      MAType(0),
+				Constraint: "must be a valid moving average type",
+				Err:        ErrInvalidMA,
+			},
 		},
 		"Invalid factor": {
 			Type:   MATypeSMA,
 			Length: 1,
 			Factor: decimal.RequireFromString("-1"),
-			Error:  errors.New("invalid factor"),
+			Error: &ValidationError{
+				Indicator:  "cci",
+				Param:      "factor",
+				Value:      decimal.RequireFromString("-1"),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidFactor,
+			},
 		},
 		"Successfully created new CCI with default factor": {
 			Type:   MATypeSMA,
@@ -497,7 +544,13 @@ func Test_CCI_validate(t *testing.T) {
 				},
 				factor: decimal.NewFromInt(-1),
 			},
-			Error: errors.New("invalid factor"),
+			Error: &ValidationError{
+				Indicator:  "cci",
+				Param:      "factor",
+				Value:      decimal.NewFromInt(-1),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidFactor,
+			},
 		},
 		"Successfully validated": {
 			CCI: CCI{
@@ -546,7 +599,7 @@ func Test_CCI_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 31, Actual: 1},
 		},
 		"Invalid SMA calc": {
 			CCI: CCI{
@@ -678,7 +731,7 @@ func Test_DEMA_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 5, Actual: 1},
 		},
 		"Successful calculation": {
 			DEMA: DEMA{
@@ -786,7 +839,7 @@ func Test_EMA_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 5, Actual: 1},
 		},
 		"Successful calculation": {
 			EMA: EMA{
@@ -939,7 +992,7 @@ func Test_HMA_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 6, Actual: 1},
 		},
 		"Successful calculation": {
 			HMA: HMA{
@@ -1025,7 +1078,13 @@ func Test_ROC_validate(t *testing.T) {
 			ROC: ROC{
 				length: -1,
 			},
-			Error: ErrInvalidLength,
+			Error: &ValidationError{
+				Indicator:  "roc",
+				Param:      "length",
+				Value:      -1,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
 		},
 		"Successfully validated": {
 			ROC: ROC{
@@ -1067,7 +1126,7 @@ func Test_ROC_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 3, Actual: 1},
 		},
 		"Successful calculation": {
 			ROC: ROC{
@@ -1075,14 +1134,37 @@ func Test_ROC_Calc(t *testing.T) {
 				length: 5,
 			},
 			Data: []decimal.Decimal{
-				decimal.NewFromInt(7),
+				decimal.NewFromInt(10),
 				decimal.NewFromInt(16),
 				decimal.NewFromInt(24),
 				decimal.NewFromInt(16),
-				decimal.NewFromInt(10),
+				decimal.NewFromInt(7),
 			},
 			Result: decimal.RequireFromString("-30"),
 		},
+		"Zero denominator with default policy": {
+			ROC: ROC{
+				valid:  true,
+				length: 2,
+			},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(0),
+				decimal.NewFromInt(7),
+			},
+			Result: decimal.Zero,
+		},
+		"Zero denominator with ZeroPolicyError": {
+			ROC: ROC{
+				valid:  true,
+				cfg:    config{zero: ZeroPolicyError},
+				length: 2,
+			},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(0),
+				decimal.NewFromInt(7),
+			},
+			Error: ErrDivisionByZero,
+		},
 	}
 
 	for cn, c := range cc {
@@ -1148,7 +1230,13 @@ func Test_RSI_validate(t *testing.T) {
 			RSI: RSI{
 				length: 0,
 			},
-			Error: ErrInvalidLength,
+			Error: &ValidationError{
+				Indicator:  "rsi",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
 		},
 		"Successfully validated": {
 			RSI: RSI{
@@ -1190,7 +1278,7 @@ func Test_RSI_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 3, Actual: 1},
 		},
 		"Successful calculation when average gain 0": {
 			RSI: RSI{
@@ -1255,9 +1343,10 @@ func Test_RSI_Count(t *testing.T) {
 
 func Test_NewSMA(t *testing.T) {
 	cc := map[string]struct {
-		Length int
-		Result SMA
-		Error  error
+		Length  int
+		Options []Option
+		Result  SMA
+		Error   error
 	}{
 		"Validate returns an error": {
 			Error: assert.AnError,
@@ -1269,6 +1358,15 @@ func Test_NewSMA(t *testing.T) {
 				length: 1,
 			},
 		},
+		"Successfully created new SMA with options": {
+			Length:  1,
+			Options: []Option{WithPrecision(3, RoundingDown)},
+			Result: SMA{
+				valid:  true,
+				cfg:    config{places: 3, rounding: RoundingDown},
+				length: 1,
+			},
+		},
 	}
 
 	for cn, c := range cc {
@@ -1277,7 +1375,7 @@ func Test_NewSMA(t *testing.T) {
 		t.Run(cn, func(t *testing.T) {
 			t.Parallel()
 
-			res, err := NewSMA(c.Length)
+			res, err := NewSMA(c.Length, c.Options...)
 			assertEqualError(t, c.Error, err)
 			assert.Equal(t, c.Result, res)
 		})
@@ -1289,11 +1387,30 @@ func Test_SMA_validate(t *testing.T) {
 		SMA   SMA
 		Error error
 	}{
+		"Invalid rounding mode": {
+			SMA: SMA{
+				cfg:    config{rounding: 70},
+				length: 1,
+			},
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
 		"Invalid length": {
 			SMA: SMA{
 				length: 0,
 			},
-			Error: ErrInvalidLength,
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
 		},
 		"Successfully validated": {
 			SMA: SMA{
@@ -1335,7 +1452,21 @@ func Test_SMA_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 3, Actual: 1},
+		},
+		"Successful calculation with lenient config": {
+			SMA: SMA{
+				valid:  true,
+				cfg:    config{lenient: true},
+				length: 3,
+			},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(10),
+				decimal.NewFromInt(30),
+				decimal.NewFromInt(31),
+				decimal.NewFromInt(32),
+			},
+			Result: decimal.NewFromInt(31),
 		},
 		"Successful calculation": {
 			SMA: SMA{
@@ -1349,6 +1480,19 @@ func Test_SMA_Calc(t *testing.T) {
 			},
 			Result: decimal.NewFromInt(31),
 		},
+		"Successful calculation with precision": {
+			SMA: SMA{
+				valid:  true,
+				cfg:    config{places: 2, rounding: RoundingHalfEven},
+				length: 3,
+			},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(30),
+				decimal.NewFromInt(31),
+				decimal.NewFromInt(33),
+			},
+			Result: decimal.RequireFromString("31.33"),
+		},
 	}
 
 	for cn, c := range cc {
@@ -1464,7 +1608,7 @@ func Test_SRSI_Calc(t *testing.T) {
 				decimal.NewFromInt(11),
 				decimal.NewFromInt(11),
 			},
-			Result: decimal.RequireFromString("0.5"),
+			Result: decimal.RequireFromString("1"),
 		},
 	}
 
@@ -1534,7 +1678,13 @@ func Test_Stoch_validate(t *testing.T) {
 			Stoch: Stoch{
 				length: 0,
 			},
-			Error: ErrInvalidLength,
+			Error: &ValidationError{
+				Indicator:  "stoch",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
 		},
 		"Successfully validated": {
 			Stoch: Stoch{
@@ -1576,7 +1726,7 @@ func Test_Stoch_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 3, Actual: 1},
 		},
 		"Successful calculation when new lows are reached": {
 			Stoch: Stoch{
@@ -1673,7 +1823,13 @@ func Test_WMA_validate(t *testing.T) {
 			WMA: WMA{
 				length: 0,
 			},
-			Error: ErrInvalidLength,
+			Error: &ValidationError{
+				Indicator:  "wma",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
 		},
 		"Successfully validated": {
 			WMA: WMA{
@@ -1715,7 +1871,7 @@ func Test_WMA_Calc(t *testing.T) {
 			Data: []decimal.Decimal{
 				decimal.NewFromInt(30),
 			},
-			Error: ErrInvalidDataSize,
+			Error: &DataSizeError{Expected: 3, Actual: 1},
 		},
 		"Successful calculation": {
 			WMA: WMA{
@@ -0,0 +1,522 @@
+// Package optimize provides types and functions to search for indicator
+// configurations that maximize user-supplied objective functions.
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidSpace is returned when parameter space is empty or
+	// contains dimensions without values.
+	ErrInvalidSpace = errors.New("invalid parameter space")
+
+	// ErrInvalidConstructor is returned when constructor is not
+	// provided.
+	ErrInvalidConstructor = errors.New("invalid constructor")
+
+	// ErrInvalidObjective is returned when objective function is not
+	// provided.
+	ErrInvalidObjective = errors.New("invalid objective")
+
+	// ErrInvalidWorkers is returned when negative number of workers is
+	// provided.
+	ErrInvalidWorkers = errors.New("invalid number of workers")
+
+	// ErrInvalidSplit is returned when walk-forward split sizes do not
+	// fit the provided data.
+	ErrInvalidSplit = errors.New("invalid walk-forward split")
+
+	// ErrNoResults is returned when none of the configurations could be
+	// evaluated.
+	ErrNoResults = errors.New("no configuration could be evaluated")
+)
+
+// Dimension holds all values of a single parameter that should be
+// explored.
+type Dimension struct {
+	// Name specifies the name of the parameter.
+	Name string
+
+	// Values specifies all values of the parameter.
+	Values []interface{}
+}
+
+// IntRange creates new dimension of integer values from min to max
+// (inclusive), incremented by step.
+func IntRange(name string, min, max, step int) Dimension {
+	dim := Dimension{Name: name}
+
+	for v := min; step > 0 && v <= max; v += step {
+		dim.Values = append(dim.Values, v)
+	}
+
+	return dim
+}
+
+// DecimalRange creates new dimension of decimal values from min to max
+// (inclusive), incremented by step.
+func DecimalRange(name string, min, max, step decimal.Decimal) Dimension {
+	dim := Dimension{Name: name}
+
+	for v := min; step.IsPositive() && v.LessThanOrEqual(max); v = v.Add(step) {
+		dim.Values = append(dim.Values, v)
+	}
+
+	return dim
+}
+
+// Choice creates new dimension of the provided values.
+func Choice(name string, vv ...interface{}) Dimension {
+	return Dimension{
+		Name:   name,
+		Values: vv,
+	}
+}
+
+// Space is a cartesian product of dimensions.
+type Space []Dimension
+
+// validate checks whether the space can be explored.
+func (s Space) validate() error {
+	if len(s) == 0 {
+		return ErrInvalidSpace
+	}
+
+	for _, dim := range s {
+		if len(dim.Values) == 0 {
+			return ErrInvalidSpace
+		}
+	}
+
+	return nil
+}
+
+// config creates configuration from the provided value indexes of every
+// dimension.
+func (s Space) config(idx []int) Config {
+	cfg := make(Config, len(s))
+
+	for i, dim := range s {
+		cfg[dim.Name] = dim.Values[idx[i]]
+	}
+
+	return cfg
+}
+
+// Config holds values of all parameters of a single point in the
+// parameter space.
+type Config map[string]interface{}
+
+// Int returns integer value of the named parameter. Zero is returned if
+// the parameter is missing or is not an integer.
+func (cfg Config) Int(name string) int {
+	v, _ := cfg[name].(int)
+	return v
+}
+
+// Decimal returns decimal value of the named parameter. Zero is returned
+// if the parameter is missing or is not a decimal.
+func (cfg Config) Decimal(name string) decimal.Decimal {
+	v, _ := cfg[name].(decimal.Decimal)
+	return v
+}
+
+// String returns string representation of the configuration with
+// parameters sorted by their names.
+func (cfg Config) String() string {
+	names := make([]string, 0, len(cfg))
+	for name := range cfg {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var b strings.Builder
+
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+
+		fmt.Fprintf(&b, "%s=%v", name, cfg[name])
+	}
+
+	return b.String()
+}
+
+// Constructor creates new indicator from the provided configuration.
+type Constructor func(Config) (indc.Indicator, error)
+
+// Objective scores the indicator on the provided data points. Higher
+// scores are better.
+type Objective func(indc.Indicator, []decimal.Decimal) (decimal.Decimal, error)
+
+// Result holds the score of a single configuration.
+type Result struct {
+	// Config specifies the evaluated configuration.
+	Config Config
+
+	// Score specifies the value returned by the objective function.
+	Score decimal.Decimal
+}
+
+// Search explores the parameter space by evaluating configurations with
+// the provided function and returns all evaluated results.
+type Search func(space Space, evaluate func([]Config) []Result) []Result
+
+// Grid creates new search that evaluates every configuration of the
+// parameter space.
+func Grid() Search {
+	return func(space Space, evaluate func([]Config) []Result) []Result {
+		var cfgs []Config
+
+		idx := make([]int, len(space))
+
+		for {
+			cfgs = append(cfgs, space.config(idx))
+
+			i := len(idx) - 1
+			for ; i >= 0; i-- {
+				idx[i]++
+				if idx[i] < len(space[i].Values) {
+					break
+				}
+
+				idx[i] = 0
+			}
+
+			if i < 0 {
+				break
+			}
+		}
+
+		return evaluate(cfgs)
+	}
+}
+
+// Random creates new search that evaluates n configurations sampled
+// uniformly from the parameter space. Seed makes the sampling
+// reproducible.
+func Random(n int, seed int64) Search {
+	return func(space Space, evaluate func([]Config) []Result) []Result {
+		rnd := rand.New(rand.NewSource(seed)) //nolint:gosec // reproducible sampling is needed
+
+		var cfgs []Config
+
+		seen := make(map[string]struct{})
+
+		for i := 0; i < n*10 && len(cfgs) < n; i++ {
+			idx := make([]int, len(space))
+			for j := range space {
+				idx[j] = rnd.Intn(len(space[j].Values))
+			}
+
+			cfg := space.config(idx)
+
+			if _, ok := seen[cfg.String()]; ok {
+				continue
+			}
+
+			seen[cfg.String()] = struct{}{}
+			cfgs = append(cfgs, cfg)
+		}
+
+		return evaluate(cfgs)
+	}
+}
+
+// CoordinateDescent creates new search that starts at the middle of
+// every dimension and repeatedly optimizes one parameter at a time,
+// while keeping the others fixed. Search stops when no parameter can be
+// improved or after the provided amount of rounds.
+func CoordinateDescent(rounds int) Search {
+	return func(space Space, evaluate func([]Config) []Result) []Result {
+		idx := make([]int, len(space))
+		for i := range space {
+			idx[i] = len(space[i].Values) / 2
+		}
+
+		var (
+			all  []Result
+			best *Result
+		)
+
+		for r := 0; r < rounds; r++ {
+			improved := false
+
+			for i := range space {
+				cfgs := make([]Config, len(space[i].Values))
+				pos := make(map[string]int, len(cfgs))
+
+				for j := range space[i].Values {
+					cidx := append([]int(nil), idx...)
+					cidx[i] = j
+					cfgs[j] = space.config(cidx)
+					pos[cfgs[j].String()] = j
+				}
+
+				res := evaluate(cfgs)
+				all = append(all, res...)
+
+				if len(res) == 0 {
+					continue
+				}
+
+				rank(res)
+
+				if best == nil || res[0].Score.GreaterThan(best.Score) {
+					best = &res[0]
+					idx[i] = pos[res[0].Config.String()]
+					improved = true
+				}
+			}
+
+			if !improved {
+				break
+			}
+		}
+
+		return all
+	}
+}
+
+// Fold holds the results of a single walk-forward step.
+type Fold struct {
+	// InSample specifies ranked results of the search on the training
+	// data points.
+	InSample []Result
+
+	// OutOfSample specifies the score of the best in-sample
+	// configuration on the following, unseen data points.
+	OutOfSample Result
+}
+
+// Optimizer holds all the necessary information needed to search for the
+// best indicator configurations.
+// The zero value is not usable.
+type Optimizer struct {
+	// valid specifies whether Optimizer paremeters were validated.
+	valid bool
+
+	// space specifies all configurations that can be explored.
+	space Space
+
+	// ctor specifies how indicators are created from configurations.
+	ctor Constructor
+
+	// obj specifies how indicators are scored.
+	obj Objective
+
+	// workers specifies how many configurations can be evaluated in
+	// parallel.
+	workers int
+}
+
+// NewOptimizer validates provided configuration options and creates
+// new Optimizer. If workers is zero, the number of CPUs is used.
+func NewOptimizer(space Space, ctor Constructor, obj Objective, workers int) (Optimizer, error) {
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+
+	o := Optimizer{
+		space:   space,
+		ctor:    ctor,
+		obj:     obj,
+		workers: workers,
+	}
+
+	if err := o.validate(); err != nil {
+		return Optimizer{}, err
+	}
+
+	return o, nil
+}
+
+// validate checks whether the optimizer has valid configuration
+// properties.
+func (o *Optimizer) validate() error {
+	if err := o.space.validate(); err != nil {
+		return err
+	}
+
+	if o.ctor == nil {
+		return ErrInvalidConstructor
+	}
+
+	if o.obj == nil {
+		return ErrInvalidObjective
+	}
+
+	if o.workers < 1 {
+		return ErrInvalidWorkers
+	}
+
+	o.valid = true
+
+	return nil
+}
+
+// Run explores the parameter space with the provided search and returns
+// the results ranked from the best to the worst. Configurations that
+// cannot be constructed or scored are skipped.
+func (o Optimizer) Run(search Search, dd []decimal.Decimal) ([]Result, error) {
+	if !o.valid {
+		return nil, indc.ErrInvalidIndicator
+	}
+
+	res := search(o.space, func(cfgs []Config) []Result {
+		return o.evaluate(cfgs, dd)
+	})
+
+	res = unique(res)
+	if len(res) == 0 {
+		return nil, ErrNoResults
+	}
+
+	rank(res)
+
+	return res, nil
+}
+
+// WalkForward splits the data points into consecutive folds of train
+// data points followed by test data points, runs the search on the
+// train part of every fold and scores the best configuration on the
+// test part. Each next fold is shifted by test data points.
+// Test part passed to the objective function is preceded by Count()-1
+// train data points, so that indicator values are available for every
+// test data point.
+func (o Optimizer) WalkForward(search Search, dd []decimal.Decimal, train, test int) ([]Fold, error) {
+	if !o.valid {
+		return nil, indc.ErrInvalidIndicator
+	}
+
+	if train < 1 || test < 1 || train+test > len(dd) {
+		return nil, ErrInvalidSplit
+	}
+
+	var folds []Fold
+
+	for start := 0; start+train+test <= len(dd); start += test {
+		in, err := o.Run(search, dd[start:start+train])
+		if err != nil {
+			return nil, err
+		}
+
+		ind, err := o.ctor(in[0].Config)
+		if err != nil {
+			// unlikely to happen
+			return nil, err
+		}
+
+		from := start + train - (ind.Count() - 1)
+		if from < start {
+			from = start
+		}
+
+		score, err := o.obj(ind, dd[from:start+train+test])
+		if err != nil {
+			return nil, err
+		}
+
+		folds = append(folds, Fold{
+			InSample: in,
+			OutOfSample: Result{
+				Config: in[0].Config,
+				Score:  score,
+			},
+		})
+	}
+
+	return folds, nil
+}
+
+// evaluate scores the provided configurations in parallel.
+func (o Optimizer) evaluate(cfgs []Config, dd []decimal.Decimal) []Result {
+	res := make([]*Result, len(cfgs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < o.workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				ind, err := o.ctor(cfgs[i])
+				if err != nil {
+					continue
+				}
+
+				score, err := o.obj(ind, dd)
+				if err != nil {
+					continue
+				}
+
+				res[i] = &Result{
+					Config: cfgs[i],
+					Score:  score,
+				}
+			}
+		}()
+	}
+
+	for i := range cfgs {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	out := make([]Result, 0, len(res))
+
+	for _, r := range res {
+		if r != nil {
+			out = append(out, *r)
+		}
+	}
+
+	return out
+}
+
+// rank sorts results from the highest score to the lowest. Results with
+// equal scores are sorted by their configurations.
+func rank(rr []Result) {
+	sort.SliceStable(rr, func(i, j int) bool {
+		if c := rr[i].Score.Cmp(rr[j].Score); c != 0 {
+			return c > 0
+		}
+
+		return rr[i].Config.String() < rr[j].Config.String()
+	})
+}
+
+// unique removes results of repeatedly evaluated configurations.
+func unique(rr []Result) []Result {
+	seen := make(map[string]struct{}, len(rr))
+	res := rr[:0]
+
+	for _, r := range rr {
+		key := r.Config.String()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		res = append(res, r)
+	}
+
+	return res
+}
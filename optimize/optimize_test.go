@@ -0,0 +1,300 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// _two is 2 in decimal format.
+var _two = decimal.NewFromInt(2)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		if exp == assert.AnError { //nolint:goerr113 // direct check is needed
+			assert.Error(t, err)
+			return
+		}
+
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+// bbConstructor creates BB indicator from length, std_dev and band
+// parameters.
+func bbConstructor(cfg Config) (indc.Indicator, error) {
+	band, _ := cfg["band"].(indc.Band)
+	return indc.NewBB(false, band, cfg.Decimal("std_dev"), cfg.Int("length"))
+}
+
+// distanceObjective scores indicators by how close their count is to 7.
+func distanceObjective(ind indc.Indicator, _ []decimal.Decimal) (decimal.Decimal, error) {
+	return decimal.NewFromInt(int64(ind.Count() - 7)).Abs().Neg(), nil
+}
+
+// series creates data points slice of n increasing values.
+func series(n int) []decimal.Decimal {
+	dd := make([]decimal.Decimal, n)
+	for i := range dd {
+		dd[i] = decimal.NewFromInt(int64(i + 1))
+	}
+
+	return dd
+}
+
+func Test_IntRange(t *testing.T) {
+	assert.Equal(t, Dimension{
+		Name:   "length",
+		Values: []interface{}{10, 15, 20},
+	}, IntRange("length", 10, 20, 5))
+	assert.Empty(t, IntRange("length", 10, 20, 0).Values)
+}
+
+func Test_DecimalRange(t *testing.T) {
+	dim := DecimalRange("std_dev", decimal.RequireFromString("1.5"),
+		decimal.NewFromInt(3), decimal.RequireFromString("0.5"))
+
+	assert.Equal(t, "std_dev", dim.Name)
+	assert.Len(t, dim.Values, 4)
+	assert.Equal(t, "3", dim.Values[3].(decimal.Decimal).String())
+	assert.Empty(t, DecimalRange("std_dev", decimal.Zero, _two, decimal.Zero).Values)
+}
+
+func Test_Choice(t *testing.T) {
+	assert.Equal(t, Dimension{
+		Name:   "band",
+		Values: []interface{}{indc.BandUpper, indc.BandLower},
+	}, Choice("band", indc.BandUpper, indc.BandLower))
+}
+
+func Test_Space_validate(t *testing.T) {
+	assertEqualError(t, ErrInvalidSpace, Space{}.validate())
+	assertEqualError(t, ErrInvalidSpace, Space{Choice("band")}.validate())
+	assert.NoError(t, Space{IntRange("length", 1, 2, 1)}.validate())
+}
+
+func Test_Config(t *testing.T) {
+	cfg := Config{
+		"length":  5,
+		"std_dev": _two,
+		"band":    "upper",
+	}
+
+	assert.Equal(t, 5, cfg.Int("length"))
+	assert.Equal(t, 0, cfg.Int("band"))
+	assert.Equal(t, "2", cfg.Decimal("std_dev").String())
+	assert.True(t, cfg.Decimal("length").IsZero())
+	assert.Equal(t, "band=upper length=5 std_dev=2", cfg.String())
+}
+
+func Test_Grid(t *testing.T) {
+	space := Space{
+		IntRange("length", 1, 3, 1),
+		Choice("band", indc.BandUpper, indc.BandLower),
+	}
+
+	var evaluated []Config
+
+	Grid()(space, func(cfgs []Config) []Result {
+		evaluated = cfgs
+		return nil
+	})
+
+	require.Len(t, evaluated, 6)
+	assert.Equal(t, Config{"length": 1, "band": indc.BandUpper}, evaluated[0])
+	assert.Equal(t, Config{"length": 3, "band": indc.BandLower}, evaluated[5])
+}
+
+func Test_Random(t *testing.T) {
+	space := Space{
+		IntRange("length", 1, 100, 1),
+		Choice("band", indc.BandUpper, indc.BandLower),
+	}
+
+	run := func() []Config {
+		var evaluated []Config
+
+		Random(10, 7)(space, func(cfgs []Config) []Result {
+			evaluated = cfgs
+			return nil
+		})
+
+		return evaluated
+	}
+
+	res := run()
+	assert.Len(t, res, 10)
+	assert.Equal(t, res, run())
+
+	seen := make(map[string]struct{})
+	for _, cfg := range res {
+		seen[cfg.String()] = struct{}{}
+	}
+
+	assert.Len(t, seen, 10)
+}
+
+func Test_CoordinateDescent(t *testing.T) {
+	o, err := NewOptimizer(Space{
+		IntRange("length", 2, 40, 1),
+		DecimalRange("std_dev", decimal.NewFromInt(1), decimal.NewFromInt(3), decimal.NewFromInt(1)),
+		Choice("band", indc.BandUpper, indc.BandLower),
+	}, bbConstructor, distanceObjective, 2)
+	require.NoError(t, err)
+
+	res, err := o.Run(CoordinateDescent(5), series(50))
+	require.NoError(t, err)
+	assert.Equal(t, 7, res[0].Config.Int("length"))
+	assert.True(t, res[0].Score.IsZero())
+}
+
+func Test_NewOptimizer(t *testing.T) {
+	space := Space{IntRange("length", 1, 2, 1)}
+
+	cc := map[string]struct {
+		Space       Space
+		Constructor Constructor
+		Objective   Objective
+		Workers     int
+		Error       error
+	}{
+		"Invalid space": {
+			Constructor: bbConstructor,
+			Objective:   distanceObjective,
+			Error:       ErrInvalidSpace,
+		},
+		"Invalid constructor": {
+			Space:     space,
+			Objective: distanceObjective,
+			Error:     ErrInvalidConstructor,
+		},
+		"Invalid objective": {
+			Space:       space,
+			Constructor: bbConstructor,
+			Error:       ErrInvalidObjective,
+		},
+		"Invalid workers": {
+			Space:       space,
+			Constructor: bbConstructor,
+			Objective:   distanceObjective,
+			Workers:     -1,
+			Error:       ErrInvalidWorkers,
+		},
+		"Successfully created new Optimizer": {
+			Space:       space,
+			Constructor: bbConstructor,
+			Objective:   distanceObjective,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewOptimizer(c.Space, c.Constructor, c.Objective, c.Workers)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Positive(t, res.workers)
+		})
+	}
+}
+
+func Test_Optimizer_Run(t *testing.T) {
+	o, err := NewOptimizer(Space{
+		IntRange("length", 0, 10, 1),
+		Choice("std_dev", _two),
+		Choice("band", indc.BandUpper, indc.Band(70)),
+	}, bbConstructor, distanceObjective, 3)
+	require.NoError(t, err)
+
+	_, err = Optimizer{}.Run(Grid(), series(10))
+	assertEqualError(t, indc.ErrInvalidIndicator, err)
+
+	res, err := o.Run(Grid(), series(10))
+	require.NoError(t, err)
+
+	// configurations with zero length and invalid band are skipped.
+	require.Len(t, res, 10)
+	assert.Equal(t, Config{"length": 7, "std_dev": _two, "band": indc.BandUpper}, res[0].Config)
+	assert.Equal(t, 1, res[len(res)-1].Config.Int("length"))
+
+	for i := 1; i < len(res); i++ {
+		assert.True(t, res[i-1].Score.GreaterThanOrEqual(res[i].Score))
+	}
+
+	o.obj = func(indc.Indicator, []decimal.Decimal) (decimal.Decimal, error) {
+		return decimal.Zero, assert.AnError
+	}
+
+	_, err = o.Run(Grid(), series(10))
+	assertEqualError(t, ErrNoResults, err)
+}
+
+func Test_Optimizer_WalkForward(t *testing.T) {
+	var sizes []int
+
+	o, err := NewOptimizer(Space{
+		IntRange("length", 2, 4, 1),
+	}, func(cfg Config) (indc.Indicator, error) {
+		return indc.NewSMA(cfg.Int("length"))
+	}, func(ind indc.Indicator, dd []decimal.Decimal) (decimal.Decimal, error) {
+		vv, err := indc.Series(ind, dd)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		return vv[len(vv)-1].Sub(dd[len(dd)-1]), nil
+	}, 1)
+	require.NoError(t, err)
+
+	_, err = Optimizer{}.WalkForward(Grid(), series(10), 5, 2)
+	assertEqualError(t, indc.ErrInvalidIndicator, err)
+
+	_, err = o.WalkForward(Grid(), series(10), 9, 2)
+	assertEqualError(t, ErrInvalidSplit, err)
+
+	_, err = o.WalkForward(Grid(), series(10), 0, 2)
+	assertEqualError(t, ErrInvalidSplit, err)
+
+	folds, err := o.WalkForward(Grid(), series(20), 10, 5)
+	require.NoError(t, err)
+	require.Len(t, folds, 2)
+
+	for _, f := range folds {
+		sizes = append(sizes, len(f.InSample))
+
+		// the shortest SMA lags the increasing series the least.
+		assert.Equal(t, 2, f.OutOfSample.Config.Int("length"))
+		assert.Equal(t, "-0.5", f.OutOfSample.Score.String())
+	}
+
+	assert.Equal(t, []int{3, 3}, sizes)
+}
+
+func Test_unique(t *testing.T) {
+	res := unique([]Result{
+		{Config: Config{"length": 1}},
+		{Config: Config{"length": 2}},
+		{Config: Config{"length": 1}},
+	})
+
+	assert.Equal(t, []Result{
+		{Config: Config{"length": 1}},
+		{Config: Config{"length": 2}},
+	}, res)
+}
@@ -0,0 +1,417 @@
+package indc
+
+import (
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// RoundingMode specifies how decimal numbers should be rounded once
+// configured precision is reached.
+type RoundingMode int
+
+// Available rounding modes.
+const (
+	// RoundingHalfUp rounds half away from zero.
+	RoundingHalfUp RoundingMode = iota + 1
+
+	// RoundingHalfEven rounds half to the nearest even digit (banker's
+	// rounding).
+	RoundingHalfEven
+
+	// RoundingDown rounds towards zero (truncates).
+	RoundingDown
+
+	// RoundingUp rounds away from zero.
+	RoundingUp
+
+	// RoundingCeil rounds towards positive infinity.
+	RoundingCeil
+
+	// RoundingFloor rounds towards negative infinity.
+	RoundingFloor
+)
+
+// Validate checks whether the rounding mode is one of
+// supported rounding modes or not.
+func (rm RoundingMode) Validate() error {
+	switch rm {
+	case RoundingHalfUp, RoundingHalfEven, RoundingDown, RoundingUp,
+		RoundingCeil, RoundingFloor:
+		return nil
+	default:
+		return ErrInvalidRounding
+	}
+}
+
+// MarshalText turns rounding mode into appropriate string
+// representation.
+func (rm RoundingMode) MarshalText() ([]byte, error) {
+	var v string
+
+	switch rm {
+	case RoundingHalfUp:
+		v = "half_up"
+	case RoundingHalfEven:
+		v = "half_even"
+	case RoundingDown:
+		v = "down"
+	case RoundingUp:
+		v = "up"
+	case RoundingCeil:
+		v = "ceil"
+	case RoundingFloor:
+		v = "floor"
+	default:
+		return nil, ErrInvalidRounding
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate rounding mode value.
+func (rm *RoundingMode) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "half_up":
+		*rm = RoundingHalfUp
+	case "half_even", "bank":
+		*rm = RoundingHalfEven
+	case "down":
+		*rm = RoundingDown
+	case "up":
+		*rm = RoundingUp
+	case "ceil":
+		*rm = RoundingCeil
+	case "floor":
+		*rm = RoundingFloor
+	default:
+		return ErrInvalidRounding
+	}
+
+	return nil
+}
+
+// ZeroPolicy specifies how indicators should behave when a denominator
+// of their formula is zero, e.g. when ROC receives zero price or when
+// the highest and the lowest values of Stoch are equal.
+// RSI is not affected, since its formula has well defined limits when
+// there are no gains or losses.
+type ZeroPolicy int
+
+// Available zero denominator policies.
+const (
+	// ZeroPolicyZero makes indicators return zero. It is the default
+	// policy.
+	ZeroPolicyZero ZeroPolicy = iota + 1
+
+	// ZeroPolicyError makes indicators return ErrDivisionByZero.
+	ZeroPolicyError
+
+	// ZeroPolicyPrevious makes indicators return the last value they
+	// have successfully calculated, or zero if there is none yet.
+	//
+	// Unlike every other option, it makes Calc depend on previous calls,
+	// so such an indicator must be used with a single data series only.
+	// The remembered value is created by the constructor and is shared
+	// by all copies of the returned indicator value: using one indicator
+	// for several series, e.g. from goroutines handling different
+	// symbols, returns the last value of whichever series was calculated
+	// before. A separate indicator should be created for every series.
+	ZeroPolicyPrevious
+)
+
+// Validate checks whether the zero policy is one of
+// supported zero policies or not.
+func (zp ZeroPolicy) Validate() error {
+	switch zp {
+	case ZeroPolicyZero, ZeroPolicyError, ZeroPolicyPrevious:
+		return nil
+	default:
+		return ErrInvalidZeroPolicy
+	}
+}
+
+// MarshalText turns zero policy into appropriate string
+// representation.
+func (zp ZeroPolicy) MarshalText() ([]byte, error) {
+	var v string
+
+	switch zp {
+	case ZeroPolicyZero:
+		v = "zero"
+	case ZeroPolicyError:
+		v = "error"
+	case ZeroPolicyPrevious:
+		v = "previous"
+	default:
+		return nil, ErrInvalidZeroPolicy
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate zero policy value.
+func (zp *ZeroPolicy) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "zero", "z":
+		*zp = ZeroPolicyZero
+	case "error", "e":
+		*zp = ZeroPolicyError
+	case "previous", "p":
+		*zp = ZeroPolicyPrevious
+	default:
+		return ErrInvalidZeroPolicy
+	}
+
+	return nil
+}
+
+// Option is used to modify optional indicator configuration properties
+// during its creation.
+type Option func(*config)
+
+// WithPrecision sets the number of digits after the decimal point that
+// every intermediate division and the final result of an indicator are
+// rounded to, using the provided rounding mode.
+// Negative places round to the left of the decimal point.
+func WithPrecision(places int32, mode RoundingMode) Option {
+	return func(c *config) {
+		c.places = places
+		c.rounding = mode
+	}
+}
+
+// WithZeroPolicy sets the policy that is applied when a denominator of
+// an indicator's formula is zero.
+func WithZeroPolicy(policy ZeroPolicy) Option {
+	return func(c *config) {
+		c.zero = policy
+	}
+}
+
+// WithLenient makes the indicator accept data points slices that are
+// longer than its Count. Only the trailing (the most recent) data points
+// are used in such case.
+func WithLenient() Option {
+	return func(c *config) {
+		c.lenient = true
+	}
+}
+
+// withConfig replaces all configuration properties with the provided
+// ones. The previous value of ZeroPolicyPrevious is shared with them.
+func withConfig(src config) Option {
+	return func(c *config) {
+		*c = src
+	}
+}
+
+// config holds optional indicator configuration properties.
+// The zero value is usable and keeps the default behaviour.
+type config struct {
+	// places specifies how many digits after the decimal point should
+	// be kept.
+	places int32
+
+	// rounding specifies how numbers should be rounded. Zero value
+	// means that no rounding is done and decimal.DivisionPrecision is
+	// used for divisions.
+	rounding RoundingMode
+
+	// zero specifies how zero denominators should be handled. Zero
+	// value means that ZeroPolicyZero is used.
+	zero ZeroPolicy
+
+	// lenient specifies whether data points slices longer than needed
+	// should be accepted.
+	lenient bool
+
+	// prev specifies the last successfully calculated value. It is set
+	// only when ZeroPolicyPrevious is used and is shared by all copies of
+	// the config.
+	prev *previous
+}
+
+// previous holds the last successfully calculated values of an
+// indicator.
+type previous struct {
+	// mu protects the values below.
+	mu sync.Mutex
+
+	// dec specifies the last decimal value.
+	dec decimal.Decimal
+
+	// flt specifies the last float64 value.
+	flt float64
+}
+
+// newConfig applies all of the provided options to the default
+// configuration.
+func newConfig(opts []Option) config {
+	var c config
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.zero == ZeroPolicyPrevious && c.prev == nil {
+		c.prev = &previous{}
+	}
+
+	return c
+}
+
+// validate checks whether the configuration properties of the named
+// indicator are valid.
+func (c config) validate(name string) error {
+	if c.rounding != 0 {
+		if err := c.rounding.Validate(); err != nil {
+			return &ValidationError{
+				Indicator:  name,
+				Param:      "rounding",
+				Value:      c.rounding,
+				Constraint: "must be a valid rounding mode",
+				Err:        err,
+			}
+		}
+	}
+
+	if c.zero != 0 {
+		if err := c.zero.Validate(); err != nil {
+			return &ValidationError{
+				Indicator:  name,
+				Param:      "zero policy",
+				Value:      c.zero,
+				Constraint: "must be a valid zero policy",
+				Err:        err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// window checks whether the data points slice has a valid size for the
+// calculation that needs count data points and returns the data points
+// that should be used.
+func window[T any](c config, dd []T, count int) ([]T, error) {
+	if len(dd) == count || (c.lenient && len(dd) > count) {
+		return dd[len(dd)-count:], nil
+	}
+
+	return nil, &DataSizeError{Expected: count, Actual: len(dd)}
+}
+
+// divByZero returns the result of a calculation that has zero
+// denominator, based on the configured zero policy.
+func (c config) divByZero() (decimal.Decimal, error) {
+	switch {
+	case c.zero == ZeroPolicyError:
+		return decimal.Zero, ErrDivisionByZero
+	case c.prev != nil:
+		c.prev.mu.Lock()
+		defer c.prev.mu.Unlock()
+
+		return c.prev.dec, nil
+	default:
+		return decimal.Zero, nil
+	}
+}
+
+// divByZeroFloat returns the result of a float64 calculation that has
+// zero denominator, based on the configured zero policy.
+func (c config) divByZeroFloat() (float64, error) {
+	switch {
+	case c.zero == ZeroPolicyError:
+		return 0, ErrDivisionByZero
+	case c.prev != nil:
+		c.prev.mu.Lock()
+		defer c.prev.mu.Unlock()
+
+		return c.prev.flt, nil
+	default:
+		return 0, nil
+	}
+}
+
+// keep remembers the successfully calculated value, so that it could be
+// returned by divByZero or divByZeroFloat when ZeroPolicyPrevious is
+// used.
+func (c config) keep(v decimal.Decimal) (decimal.Decimal, error) {
+	if c.prev != nil {
+		c.prev.mu.Lock()
+		c.prev.dec = v
+		c.prev.flt, _ = v.Float64()
+		c.prev.mu.Unlock()
+	}
+
+	return v, nil
+}
+
+// keepFloat remembers the successfully calculated float64 value, so that
+// it could be returned by divByZero or divByZeroFloat when
+// ZeroPolicyPrevious is used.
+func (c config) keepFloat(v float64) (float64, error) {
+	if c.prev != nil {
+		c.prev.mu.Lock()
+		c.prev.dec = decimal.NewFromFloat(v)
+		c.prev.flt = v
+		c.prev.mu.Unlock()
+	}
+
+	return v, nil
+}
+
+// div divides a by b and rounds the quotient according to the configured
+// precision and rounding mode.
+func (c config) div(a, b decimal.Decimal) decimal.Decimal {
+	if c.rounding == 0 {
+		return a.Div(b)
+	}
+
+	q, r := a.QuoRem(b, c.places)
+	if r.IsZero() {
+		return q
+	}
+
+	sign := int64(a.Sign() * b.Sign())
+	unit := decimal.New(sign, -c.places)
+
+	// half compares discarded remainder with the half of the last
+	// kept digit.
+	half := r.Abs().Mul(decimal.NewFromInt(2)).Shift(c.places).Cmp(b.Abs())
+
+	switch c.rounding {
+	case RoundingHalfUp:
+		if half >= 0 {
+			q = q.Add(unit)
+		}
+	case RoundingHalfEven:
+		odd := !q.Shift(c.places).Mod(decimal.NewFromInt(2)).IsZero()
+		if half > 0 || (half == 0 && odd) {
+			q = q.Add(unit)
+		}
+	case RoundingUp:
+		q = q.Add(unit)
+	case RoundingCeil:
+		if sign > 0 {
+			q = q.Add(unit)
+		}
+	case RoundingFloor:
+		if sign < 0 {
+			q = q.Add(unit)
+		}
+	}
+
+	return q
+}
+
+// round rounds d according to the configured precision and rounding
+// mode.
+func (c config) round(d decimal.Decimal) decimal.Decimal {
+	if c.rounding == 0 {
+		return d
+	}
+
+	return c.div(d, _one)
+}
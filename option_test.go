@@ -0,0 +1,596 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RoundingMode_Validate(t *testing.T) {
+	cc := map[string]struct {
+		RoundingMode RoundingMode
+		Err          error
+	}{
+		"Invalid RoundingMode": {
+			Err: ErrInvalidRounding,
+		},
+		"Successful RoundingHalfUp validation": {
+			RoundingMode: RoundingHalfUp,
+		},
+		"Successful RoundingHalfEven validation": {
+			RoundingMode: RoundingHalfEven,
+		},
+		"Successful RoundingDown validation": {
+			RoundingMode: RoundingDown,
+		},
+		"Successful RoundingUp validation": {
+			RoundingMode: RoundingUp,
+		},
+		"Successful RoundingCeil validation": {
+			RoundingMode: RoundingCeil,
+		},
+		"Successful RoundingFloor validation": {
+			RoundingMode: RoundingFloor,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.RoundingMode.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_RoundingMode_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		RoundingMode RoundingMode
+		Text         string
+		Err          error
+	}{
+		"Invalid RoundingMode": {
+			Err: ErrInvalidRounding,
+		},
+		"Successful RoundingHalfUp marshal": {
+			RoundingMode: RoundingHalfUp,
+			Text:         "half_up",
+		},
+		"Successful RoundingHalfEven marshal": {
+			RoundingMode: RoundingHalfEven,
+			Text:         "half_even",
+		},
+		"Successful RoundingDown marshal": {
+			RoundingMode: RoundingDown,
+			Text:         "down",
+		},
+		"Successful RoundingUp marshal": {
+			RoundingMode: RoundingUp,
+			Text:         "up",
+		},
+		"Successful RoundingCeil marshal": {
+			RoundingMode: RoundingCeil,
+			Text:         "ceil",
+		},
+		"Successful RoundingFloor marshal": {
+			RoundingMode: RoundingFloor,
+			Text:         "floor",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.RoundingMode.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_RoundingMode_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result RoundingMode
+		Err    error
+	}{
+		"Invalid RoundingMode": {
+			Err: ErrInvalidRounding,
+		},
+		"Successful RoundingHalfUp unmarshal": {
+			Text:   "half_up",
+			Result: RoundingHalfUp,
+		},
+		"Successful RoundingHalfEven unmarshal (long form)": {
+			Text:   "half_even",
+			Result: RoundingHalfEven,
+		},
+		"Successful RoundingHalfEven unmarshal (short form)": {
+			Text:   "bank",
+			Result: RoundingHalfEven,
+		},
+		"Successful RoundingDown unmarshal": {
+			Text:   "down",
+			Result: RoundingDown,
+		},
+		"Successful RoundingUp unmarshal": {
+			Text:   "up",
+			Result: RoundingUp,
+		},
+		"Successful RoundingCeil unmarshal": {
+			Text:   "ceil",
+			Result: RoundingCeil,
+		},
+		"Successful RoundingFloor unmarshal": {
+			Text:   "floor",
+			Result: RoundingFloor,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var rm RoundingMode
+			err := rm.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, rm)
+		})
+	}
+}
+
+func Test_ZeroPolicy_Validate(t *testing.T) {
+	cc := map[string]struct {
+		ZeroPolicy ZeroPolicy
+		Err        error
+	}{
+		"Invalid ZeroPolicy": {
+			Err: ErrInvalidZeroPolicy,
+		},
+		"Successful ZeroPolicyZero validation": {
+			ZeroPolicy: ZeroPolicyZero,
+		},
+		"Successful ZeroPolicyError validation": {
+			ZeroPolicy: ZeroPolicyError,
+		},
+		"Successful ZeroPolicyPrevious validation": {
+			ZeroPolicy: ZeroPolicyPrevious,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.ZeroPolicy.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_ZeroPolicy_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		ZeroPolicy ZeroPolicy
+		Text       string
+		Err        error
+	}{
+		"Invalid ZeroPolicy": {
+			Err: ErrInvalidZeroPolicy,
+		},
+		"Successful ZeroPolicyZero marshal": {
+			ZeroPolicy: ZeroPolicyZero,
+			Text:       "zero",
+		},
+		"Successful ZeroPolicyError marshal": {
+			ZeroPolicy: ZeroPolicyError,
+			Text:       "error",
+		},
+		"Successful ZeroPolicyPrevious marshal": {
+			ZeroPolicy: ZeroPolicyPrevious,
+			Text:       "previous",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.ZeroPolicy.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_ZeroPolicy_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result ZeroPolicy
+		Err    error
+	}{
+		"Invalid ZeroPolicy": {
+			Err: ErrInvalidZeroPolicy,
+		},
+		"Successful ZeroPolicyZero unmarshal (long form)": {
+			Text:   "zero",
+			Result: ZeroPolicyZero,
+		},
+		"Successful ZeroPolicyZero unmarshal (short form)": {
+			Text:   "z",
+			Result: ZeroPolicyZero,
+		},
+		"Successful ZeroPolicyError unmarshal (long form)": {
+			Text:   "error",
+			Result: ZeroPolicyError,
+		},
+		"Successful ZeroPolicyError unmarshal (short form)": {
+			Text:   "e",
+			Result: ZeroPolicyError,
+		},
+		"Successful ZeroPolicyPrevious unmarshal (long form)": {
+			Text:   "previous",
+			Result: ZeroPolicyPrevious,
+		},
+		"Successful ZeroPolicyPrevious unmarshal (short form)": {
+			Text:   "p",
+			Result: ZeroPolicyPrevious,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var zp ZeroPolicy
+			err := zp.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, zp)
+		})
+	}
+}
+
+func Test_WithPrecision(t *testing.T) {
+	var c config
+
+	WithPrecision(4, RoundingHalfEven)(&c)
+
+	assert.Equal(t, config{places: 4, rounding: RoundingHalfEven}, c)
+}
+
+func Test_WithZeroPolicy(t *testing.T) {
+	var c config
+
+	WithZeroPolicy(ZeroPolicyError)(&c)
+
+	assert.Equal(t, config{zero: ZeroPolicyError}, c)
+}
+
+func Test_WithLenient(t *testing.T) {
+	var c config
+
+	WithLenient()(&c)
+
+	assert.Equal(t, config{lenient: true}, c)
+}
+
+func Test_newConfig(t *testing.T) {
+	assert.Equal(t, config{}, newConfig(nil))
+	assert.Equal(t, config{places: 2, rounding: RoundingDown}, newConfig([]Option{
+		WithPrecision(5, RoundingUp),
+		WithPrecision(2, RoundingDown),
+	}))
+	assert.Equal(t, config{zero: ZeroPolicyPrevious, prev: &previous{}}, newConfig([]Option{
+		WithZeroPolicy(ZeroPolicyPrevious),
+	}))
+
+	src := newConfig([]Option{WithZeroPolicy(ZeroPolicyPrevious), WithLenient()})
+	res := newConfig([]Option{withConfig(src)})
+	assert.Equal(t, src, res)
+	assert.Same(t, src.prev, res.prev)
+}
+
+func Test_config_validate(t *testing.T) {
+	cc := map[string]struct {
+		Config config
+		Error  error
+	}{
+		"Invalid rounding mode": {
+			Config: config{rounding: 70},
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid zero policy": {
+			Config: config{zero: 70},
+			Error: &ValidationError{
+				Indicator:  "sma",
+				Param:      "zero policy",
+				Value:      ZeroPolicy(70),
+				Constraint: "must be a valid zero policy",
+				Err:        ErrInvalidZeroPolicy,
+			},
+		},
+		"Successfully validated default config": {},
+		"Successfully validated config with rounding": {
+			Config: config{places: 2, rounding: RoundingHalfUp, zero: ZeroPolicyError},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assertEqualError(t, c.Error, c.Config.validate("sma"))
+		})
+	}
+}
+
+func Test_window(t *testing.T) {
+	cc := map[string]struct {
+		Config config
+		Data   []int
+		Count  int
+		Result []int
+		Error  error
+	}{
+		"Too few data points": {
+			Data:  []int{1, 2},
+			Count: 3,
+			Error: &DataSizeError{Expected: 3, Actual: 2},
+		},
+		"Too few data points with lenient config": {
+			Config: config{lenient: true},
+			Data:   []int{1, 2},
+			Count:  3,
+			Error:  &DataSizeError{Expected: 3, Actual: 2},
+		},
+		"Too many data points": {
+			Data:  []int{1, 2, 3, 4},
+			Count: 3,
+			Error: &DataSizeError{Expected: 3, Actual: 4},
+		},
+		"Successfully selected trailing data points with lenient config": {
+			Config: config{lenient: true},
+			Data:   []int{1, 2, 3, 4},
+			Count:  3,
+			Result: []int{2, 3, 4},
+		},
+		"Successfully selected all data points": {
+			Data:   []int{1, 2, 3},
+			Count:  3,
+			Result: []int{1, 2, 3},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := window(c.Config, c.Data, c.Count)
+			assertEqualError(t, c.Error, err)
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_config_divByZero(t *testing.T) {
+	res, err := config{}.divByZero()
+	assert.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	res, err = config{zero: ZeroPolicyZero}.divByZero()
+	assert.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	_, err = config{zero: ZeroPolicyError}.divByZero()
+	assertEqualError(t, ErrDivisionByZero, err)
+
+	res, err = config{zero: ZeroPolicyPrevious, prev: &previous{}}.divByZero()
+	assert.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	res, err = config{
+		zero: ZeroPolicyPrevious,
+		prev: &previous{dec: decimal.NewFromInt(3)},
+	}.divByZero()
+	assert.NoError(t, err)
+	assert.Equal(t, decimal.NewFromInt(3), res)
+}
+
+func Test_config_divByZeroFloat(t *testing.T) {
+	res, err := config{}.divByZeroFloat()
+	assert.NoError(t, err)
+	assert.Zero(t, res)
+
+	_, err = config{zero: ZeroPolicyError}.divByZeroFloat()
+	assertEqualError(t, ErrDivisionByZero, err)
+
+	res, err = config{zero: ZeroPolicyPrevious, prev: &previous{flt: 3}}.divByZeroFloat()
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, res)
+}
+
+func Test_config_keep(t *testing.T) {
+	res, err := config{}.keep(decimal.NewFromInt(3))
+	assert.NoError(t, err)
+	assert.Equal(t, decimal.NewFromInt(3), res)
+
+	c := newConfig([]Option{WithZeroPolicy(ZeroPolicyPrevious)})
+
+	res, err = c.keep(decimal.NewFromInt(4))
+	assert.NoError(t, err)
+	assert.Equal(t, decimal.NewFromInt(4), res)
+	assert.Equal(t, decimal.NewFromInt(4), c.prev.dec)
+	assert.Equal(t, 4.0, c.prev.flt)
+}
+
+func Test_config_keepFloat(t *testing.T) {
+	res, err := config{}.keepFloat(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, res)
+
+	c := newConfig([]Option{WithZeroPolicy(ZeroPolicyPrevious)})
+
+	res, err = c.keepFloat(4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, res)
+	assert.Equal(t, 4.0, c.prev.flt)
+	assert.Equal(t, "4", c.prev.dec.String())
+}
+
+func Test_config_div(t *testing.T) {
+	cc := map[string]struct {
+		Config config
+		A      decimal.Decimal
+		B      decimal.Decimal
+		Result string
+	}{
+		"Default division precision": {
+			A:      decimal.NewFromInt(2),
+			B:      decimal.NewFromInt(3),
+			Result: "0.6666666666666667",
+		},
+		"Exact division": {
+			Config: config{places: 2, rounding: RoundingUp},
+			A:      decimal.NewFromInt(3),
+			B:      decimal.NewFromInt(4),
+			Result: "0.75",
+		},
+		"RoundingHalfUp with positive tie": {
+			Config: config{places: 1, rounding: RoundingHalfUp},
+			A:      decimal.NewFromInt(1),
+			B:      decimal.NewFromInt(4),
+			Result: "0.3",
+		},
+		"RoundingHalfUp with negative tie": {
+			Config: config{places: 1, rounding: RoundingHalfUp},
+			A:      decimal.NewFromInt(-1),
+			B:      decimal.NewFromInt(4),
+			Result: "-0.3",
+		},
+		"RoundingHalfUp below half": {
+			Config: config{places: 2, rounding: RoundingHalfUp},
+			A:      decimal.NewFromInt(1),
+			B:      decimal.NewFromInt(3),
+			Result: "0.33",
+		},
+		"RoundingHalfEven with tie to even": {
+			Config: config{places: 1, rounding: RoundingHalfEven},
+			A:      decimal.NewFromInt(1),
+			B:      decimal.NewFromInt(4),
+			Result: "0.2",
+		},
+		"RoundingHalfEven with tie to odd": {
+			Config: config{places: 1, rounding: RoundingHalfEven},
+			A:      decimal.NewFromInt(3),
+			B:      decimal.NewFromInt(4),
+			Result: "0.8",
+		},
+		"RoundingHalfEven above half": {
+			Config: config{places: 2, rounding: RoundingHalfEven},
+			A:      decimal.NewFromInt(2),
+			B:      decimal.NewFromInt(3),
+			Result: "0.67",
+		},
+		"RoundingDown": {
+			Config: config{places: 2, rounding: RoundingDown},
+			A:      decimal.NewFromInt(-2),
+			B:      decimal.NewFromInt(3),
+			Result: "-0.66",
+		},
+		"RoundingUp": {
+			Config: config{places: 2, rounding: RoundingUp},
+			A:      decimal.NewFromInt(-1),
+			B:      decimal.NewFromInt(3),
+			Result: "-0.34",
+		},
+		"RoundingCeil with positive quotient": {
+			Config: config{places: 2, rounding: RoundingCeil},
+			A:      decimal.NewFromInt(1),
+			B:      decimal.NewFromInt(3),
+			Result: "0.34",
+		},
+		"RoundingCeil with negative quotient": {
+			Config: config{places: 2, rounding: RoundingCeil},
+			A:      decimal.NewFromInt(1),
+			B:      decimal.NewFromInt(-3),
+			Result: "-0.33",
+		},
+		"RoundingFloor with positive quotient": {
+			Config: config{places: 2, rounding: RoundingFloor},
+			A:      decimal.NewFromInt(1),
+			B:      decimal.NewFromInt(3),
+			Result: "0.33",
+		},
+		"RoundingFloor with negative quotient": {
+			Config: config{places: 2, rounding: RoundingFloor},
+			A:      decimal.NewFromInt(1),
+			B:      decimal.NewFromInt(-3),
+			Result: "-0.34",
+		},
+		"Negative places": {
+			Config: config{places: -1, rounding: RoundingHalfUp},
+			A:      decimal.NewFromInt(100),
+			B:      decimal.NewFromInt(3),
+			Result: "30",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := c.Config.div(c.A, c.B)
+			assert.Equal(t, c.Result, res.String())
+		})
+	}
+}
+
+func Test_config_round(t *testing.T) {
+	assert.Equal(t, "1.23456", config{}.round(decimal.RequireFromString("1.23456")).String())
+	assert.Equal(t, "1.234", config{places: 3, rounding: RoundingHalfEven}.
+		round(decimal.RequireFromString("1.2345")).String())
+	assert.Equal(t, "-1.24", config{places: 2, rounding: RoundingFloor}.
+		round(decimal.RequireFromString("-1.231")).String())
+}
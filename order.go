@@ -0,0 +1,172 @@
+package indc
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Order specifies in which order data points are supplied.
+type Order int
+
+// Available data point orders.
+const (
+	// OrderOldestFirst specifies that the first data point is the oldest
+	// one. It is the order expected by all indicators.
+	OrderOldestFirst Order = iota + 1
+
+	// OrderNewestFirst specifies that the first data point is the most
+	// recent one.
+	OrderNewestFirst
+)
+
+// Validate checks whether the order is one of
+// supported order types or not.
+func (o Order) Validate() error {
+	switch o {
+	case OrderOldestFirst, OrderNewestFirst:
+		return nil
+	default:
+		return ErrInvalidOrder
+	}
+}
+
+// MarshalText turns order into appropriate string
+// representation.
+func (o Order) MarshalText() ([]byte, error) {
+	var v string
+
+	switch o {
+	case OrderOldestFirst:
+		v = "oldest_first"
+	case OrderNewestFirst:
+		v = "newest_first"
+	default:
+		return nil, ErrInvalidOrder
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate order value.
+func (o *Order) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "oldest_first", "o":
+		*o = OrderOldestFirst
+	case "newest_first", "n":
+		*o = OrderNewestFirst
+	default:
+		return ErrInvalidOrder
+	}
+
+	return nil
+}
+
+// Ordered holds all the necessary information needed to calculate
+// values of an indicator from data points supplied in an explicit order.
+// The zero value is not usable.
+type Ordered struct {
+	// valid specifies whether Ordered paremeters were validated.
+	valid bool
+
+	// order specifies in which order data points are supplied.
+	order Order
+
+	// ind specifies the wrapped indicator.
+	ind Indicator
+}
+
+// NewOrdered validates provided configuration options and
+// creates new Ordered indicator wrapper.
+func NewOrdered(order Order, ind Indicator) (Ordered, error) {
+	o := Ordered{
+		order: order,
+		ind:   ind,
+	}
+
+	if err := o.validate(); err != nil {
+		return Ordered{}, err
+	}
+
+	return o, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (o *Ordered) validate() error {
+	if err := o.order.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  "ordered",
+			Param:      "order",
+			Value:      o.order,
+			Constraint: "must be a valid order",
+			Err:        err,
+		}
+	}
+
+	if o.ind == nil {
+		return &ValidationError{
+			Indicator:  "ordered",
+			Param:      "indicator",
+			Value:      o.ind,
+			Constraint: "must not be nil",
+			Err:        ErrInvalidIndicator,
+		}
+	}
+
+	o.valid = true
+
+	return nil
+}
+
+// Calc calculates value of the wrapped indicator from the provided
+// data points slice, ordered according to the configured order.
+func (o Ordered) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !o.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	if o.order == OrderNewestFirst {
+		dd = reverse(dd)
+	}
+
+	return o.ind.Calc(dd)
+}
+
+// CalcFloat calculates value of the wrapped indicator from the provided
+// float64 data points slice, ordered according to the configured order.
+func (o Ordered) CalcFloat(dd []float64) (float64, error) {
+	if !o.valid {
+		return 0, ErrInvalidIndicator
+	}
+
+	ind, ok := o.ind.(FloatIndicator)
+	if !ok {
+		return 0, ErrInvalidIndicator
+	}
+
+	if o.order == OrderNewestFirst {
+		dd = reverse(dd)
+	}
+
+	return ind.CalcFloat(dd)
+}
+
+// Count determines the total amount of data points needed for the
+// wrapped indicator calculation.
+func (o Ordered) Count() int {
+	if !o.valid {
+		return 0
+	}
+
+	return o.ind.Count()
+}
+
+// reverse returns a copy of the provided slice with elements in the
+// reverse order.
+func reverse[T any](dd []T) []T {
+	res := make([]T, len(dd))
+
+	for i := range dd {
+		res[len(dd)-1-i] = dd[i]
+	}
+
+	return res
+}
@@ -0,0 +1,327 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Order_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Order Order
+		Err   error
+	}{
+		"Invalid Order": {
+			Err: ErrInvalidOrder,
+		},
+		"Successful OrderOldestFirst validation": {
+			Order: OrderOldestFirst,
+		},
+		"Successful OrderNewestFirst validation": {
+			Order: OrderNewestFirst,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Order.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Order_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Order Order
+		Text  string
+		Err   error
+	}{
+		"Invalid Order": {
+			Err: ErrInvalidOrder,
+		},
+		"Successful OrderOldestFirst marshal": {
+			Order: OrderOldestFirst,
+			Text:  "oldest_first",
+		},
+		"Successful OrderNewestFirst marshal": {
+			Order: OrderNewestFirst,
+			Text:  "newest_first",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Order.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Order_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Order
+		Err    error
+	}{
+		"Invalid Order": {
+			Err: ErrInvalidOrder,
+		},
+		"Successful OrderOldestFirst unmarshal (long form)": {
+			Text:   "oldest_first",
+			Result: OrderOldestFirst,
+		},
+		"Successful OrderOldestFirst unmarshal (short form)": {
+			Text:   "o",
+			Result: OrderOldestFirst,
+		},
+		"Successful OrderNewestFirst unmarshal (long form)": {
+			Text:   "newest_first",
+			Result: OrderNewestFirst,
+		},
+		"Successful OrderNewestFirst unmarshal (short form)": {
+			Text:   "n",
+			Result: OrderNewestFirst,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var o Order
+			err := o.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, o)
+		})
+	}
+}
+
+func Test_NewOrdered(t *testing.T) {
+	cc := map[string]struct {
+		Order     Order
+		Indicator Indicator
+		Result    Ordered
+		Error     error
+	}{
+		"Validate returns an error": {
+			Error: assert.AnError,
+		},
+		"Successfully created new Ordered": {
+			Order:     OrderNewestFirst,
+			Indicator: ROC{valid: true, length: 2},
+			Result: Ordered{
+				valid: true,
+				order: OrderNewestFirst,
+				ind:   ROC{valid: true, length: 2},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewOrdered(c.Order, c.Indicator)
+			assertEqualError(t, c.Error, err)
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Ordered_validate(t *testing.T) {
+	cc := map[string]struct {
+		Ordered Ordered
+		Error   error
+	}{
+		"Invalid order": {
+			Ordered: Ordered{
+				order: 70,
+				ind:   ROC{},
+			},
+			Error: &ValidationError{
+				Indicator:  "ordered",
+				Param:      "order",
+				Value:      Order(70),
+				Constraint: "must be a valid order",
+				Err:        ErrInvalidOrder,
+			},
+		},
+		"Invalid indicator": {
+			Ordered: Ordered{
+				order: OrderOldestFirst,
+			},
+			Error: &ValidationError{
+				Indicator:  "ordered",
+				Param:      "indicator",
+				Value:      nil,
+				Constraint: "must not be nil",
+				Err:        ErrInvalidIndicator,
+			},
+		},
+		"Successfully validated": {
+			Ordered: Ordered{
+				order: OrderOldestFirst,
+				ind:   ROC{},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assertEqualError(t, c.Error, c.Ordered.validate())
+			if c.Error == nil {
+				assert.True(t, c.Ordered.valid)
+			}
+		})
+	}
+}
+
+func Test_Ordered_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Ordered Ordered
+		Data    []decimal.Decimal
+		Result  decimal.Decimal
+		Error   error
+	}{
+		"Invalid indicator": {
+			Error: ErrInvalidIndicator,
+		},
+		"Successful calculation with OrderOldestFirst": {
+			Ordered: Ordered{
+				valid: true,
+				order: OrderOldestFirst,
+				ind:   ROC{valid: true, length: 2},
+			},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(10),
+				decimal.NewFromInt(12),
+			},
+			Result: decimal.NewFromInt(20),
+		},
+		"Successful calculation with OrderNewestFirst": {
+			Ordered: Ordered{
+				valid: true,
+				order: OrderNewestFirst,
+				ind:   ROC{valid: true, length: 2},
+			},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(12),
+				decimal.NewFromInt(10),
+			},
+			Result: decimal.NewFromInt(20),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Ordered.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result.String(), res.String())
+		})
+	}
+}
+
+func Test_Ordered_CalcFloat(t *testing.T) {
+	cc := map[string]struct {
+		Ordered Ordered
+		Data    []float64
+		Result  float64
+		Error   error
+	}{
+		"Invalid indicator": {
+			Error: ErrInvalidIndicator,
+		},
+		"Indicator without float implementation": {
+			Ordered: Ordered{
+				valid: true,
+				order: OrderOldestFirst,
+				ind:   Ordered{},
+			},
+			Error: ErrInvalidIndicator,
+		},
+		"Successful calculation with OrderOldestFirst": {
+			Ordered: Ordered{
+				valid: true,
+				order: OrderOldestFirst,
+				ind:   ROC{valid: true, length: 2},
+			},
+			Data:   []float64{10, 12},
+			Result: 20,
+		},
+		"Successful calculation with OrderNewestFirst": {
+			Ordered: Ordered{
+				valid: true,
+				order: OrderNewestFirst,
+				ind:   ROC{valid: true, length: 2},
+			},
+			Data:   []float64{12, 10},
+			Result: 20,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Ordered.CalcFloat(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.InDelta(t, c.Result, res, 1e-9)
+		})
+	}
+}
+
+func Test_Ordered_Count(t *testing.T) {
+	assert.Zero(t, Ordered{}.Count())
+	assert.Equal(t, 5, Ordered{
+		valid: true,
+		ind:   SMA{length: 5},
+	}.Count())
+}
+
+func Test_reverse(t *testing.T) {
+	dd := []int{1, 2, 3}
+
+	assert.Equal(t, []int{3, 2, 1}, reverse(dd))
+	assert.Equal(t, []int{1, 2, 3}, dd)
+	assert.Empty(t, reverse([]int{}))
+}
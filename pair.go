@@ -0,0 +1,565 @@
+package indc
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidCorrelation is returned when correlation method doesn't
+	// match any of the available correlation methods.
+	ErrInvalidCorrelation = errors.New("invalid correlation method")
+)
+
+// CorrelationMethod specifies how correlation is calculated.
+type CorrelationMethod int
+
+// Available correlation methods.
+const (
+	// CorrelationPearson specifies Pearson correlation coefficient that
+	// measures linear dependence of the values.
+	CorrelationPearson CorrelationMethod = iota + 1
+
+	// CorrelationSpearman specifies Spearman rank correlation
+	// coefficient that measures monotonic dependence of the values.
+	CorrelationSpearman
+)
+
+// Validate checks whether the correlation method is one of
+// supported correlation methods or not.
+func (cm CorrelationMethod) Validate() error {
+	switch cm {
+	case CorrelationPearson, CorrelationSpearman:
+		return nil
+	default:
+		return ErrInvalidCorrelation
+	}
+}
+
+// MarshalText turns correlation method into appropriate string
+// representation.
+func (cm CorrelationMethod) MarshalText() ([]byte, error) {
+	var v string
+
+	switch cm {
+	case CorrelationPearson:
+		v = "pearson"
+	case CorrelationSpearman:
+		v = "spearman"
+	default:
+		return nil, ErrInvalidCorrelation
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate correlation method value.
+func (cm *CorrelationMethod) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "pearson", "p":
+		*cm = CorrelationPearson
+	case "spearman", "s":
+		*cm = CorrelationSpearman
+	default:
+		return ErrInvalidCorrelation
+	}
+
+	return nil
+}
+
+// Correlation holds all the necessary information needed to calculate
+// correlation coefficient of two series.
+// The zero value is not usable.
+type Correlation struct {
+	// valid specifies whether Correlation paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+
+	// method specifies how correlation is calculated.
+	method CorrelationMethod
+}
+
+// NewCorrelation validates provided configuration options and
+// creates new Correlation indicator.
+func NewCorrelation(method CorrelationMethod, source Source, length int, opts ...Option) (Correlation, error) {
+	c := Correlation{
+		cfg:    newConfig(opts),
+		in:     returns{source: source, length: length},
+		method: method,
+	}
+
+	if err := c.validate(); err != nil {
+		return Correlation{}, err
+	}
+
+	return c, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (c *Correlation) validate() error {
+	if err := c.cfg.validate("correlation"); err != nil {
+		return err
+	}
+
+	if err := c.method.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  "correlation",
+			Param:      "method",
+			Value:      c.method,
+			Constraint: "must be pearson or spearman",
+			Err:        err,
+		}
+	}
+
+	if err := c.in.validate("correlation", 2); err != nil {
+		return err
+	}
+
+	c.valid = true
+
+	return nil
+}
+
+// CalcPair calculates correlation coefficient, between -1 and 1, from
+// the provided data point slices.
+func (c Correlation) CalcPair(aa, bb []decimal.Decimal) (decimal.Decimal, error) {
+	if !c.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	aa, bb, ok, err := c.in.pair(c.cfg, aa, bb)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if !ok {
+		return c.cfg.divByZero()
+	}
+
+	if c.method == CorrelationSpearman {
+		aa, bb = ranks(aa), ranks(bb)
+	}
+
+	dev := sqrt(c.cfg.svar(aa).Mul(c.cfg.svar(bb)))
+	if dev.IsZero() {
+		return c.cfg.divByZero()
+	}
+
+	return c.cfg.keep(c.cfg.round(c.cfg.div(c.cfg.cov(aa, bb), dev)))
+}
+
+// Count determines the total amount of data points of each series
+// needed for Correlation calculation.
+func (c Correlation) Count() int {
+	return c.in.count()
+}
+
+// Covariance holds all the necessary information needed to calculate
+// sample covariance of two series.
+// The zero value is not usable.
+type Covariance struct {
+	// valid specifies whether Covariance paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+}
+
+// NewCovariance validates provided configuration options and
+// creates new Covariance indicator.
+func NewCovariance(source Source, length int, opts ...Option) (Covariance, error) {
+	c := Covariance{
+		cfg: newConfig(opts),
+		in:  returns{source: source, length: length},
+	}
+
+	if err := c.validate(); err != nil {
+		return Covariance{}, err
+	}
+
+	return c, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (c *Covariance) validate() error {
+	if err := c.cfg.validate("covariance"); err != nil {
+		return err
+	}
+
+	if err := c.in.validate("covariance", 2); err != nil {
+		return err
+	}
+
+	c.valid = true
+
+	return nil
+}
+
+// CalcPair calculates sample covariance from the provided data point
+// slices.
+func (c Covariance) CalcPair(aa, bb []decimal.Decimal) (decimal.Decimal, error) {
+	if !c.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	aa, bb, ok, err := c.in.pair(c.cfg, aa, bb)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if !ok {
+		return c.cfg.divByZero()
+	}
+
+	return c.cfg.keep(c.cfg.round(c.cfg.cov(aa, bb)))
+}
+
+// Count determines the total amount of data points of each series
+// needed for Covariance calculation.
+func (c Covariance) Count() int {
+	return c.in.count()
+}
+
+// Beta holds all the necessary information needed to calculate beta of
+// an asset versus a benchmark.
+// The zero value is not usable.
+type Beta struct {
+	// valid specifies whether Beta paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+}
+
+// NewBeta validates provided configuration options and
+// creates new Beta indicator.
+func NewBeta(source Source, length int, opts ...Option) (Beta, error) {
+	b := Beta{
+		cfg: newConfig(opts),
+		in:  returns{source: source, length: length},
+	}
+
+	if err := b.validate(); err != nil {
+		return Beta{}, err
+	}
+
+	return b, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (b *Beta) validate() error {
+	if err := b.cfg.validate("beta"); err != nil {
+		return err
+	}
+
+	if err := b.in.validate("beta", 2); err != nil {
+		return err
+	}
+
+	b.valid = true
+
+	return nil
+}
+
+// CalcPair calculates beta of the asset data points versus the
+// benchmark data points: the covariance of both series divided by the
+// variance of the benchmark.
+func (b Beta) CalcPair(asset, benchmark []decimal.Decimal) (decimal.Decimal, error) {
+	if !b.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	asset, benchmark, ok, err := b.in.pair(b.cfg, asset, benchmark)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if !ok {
+		return b.cfg.divByZero()
+	}
+
+	v := b.cfg.svar(benchmark)
+	if v.IsZero() {
+		return b.cfg.divByZero()
+	}
+
+	return b.cfg.keep(b.cfg.round(b.cfg.div(b.cfg.cov(asset, benchmark), v)))
+}
+
+// Count determines the total amount of data points of each series
+// needed for Beta calculation.
+func (b Beta) Count() int {
+	return b.in.count()
+}
+
+// RelativeStrength holds all the necessary information needed to
+// calculate relative strength, i.e. the ratio line of two series.
+// The zero value is not usable.
+type RelativeStrength struct {
+	// valid specifies whether RelativeStrength paremeters were
+	// validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// length specifies how many data points should be used during the
+	// calculations.
+	length int
+}
+
+// NewRelativeStrength validates provided configuration options and
+// creates new RelativeStrength indicator. Length of 1 produces the raw
+// ratio line; a greater length rebases it to the start of the window.
+func NewRelativeStrength(length int, opts ...Option) (RelativeStrength, error) {
+	rs := RelativeStrength{
+		cfg:    newConfig(opts),
+		length: length,
+	}
+
+	if err := rs.validate(); err != nil {
+		return RelativeStrength{}, err
+	}
+
+	return rs, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (rs *RelativeStrength) validate() error {
+	if err := rs.cfg.validate("rs"); err != nil {
+		return err
+	}
+
+	if rs.length < 1 {
+		return &ValidationError{
+			Indicator:  "rs",
+			Param:      "length",
+			Value:      rs.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	rs.valid = true
+
+	return nil
+}
+
+// CalcPair calculates relative strength of the asset prices versus the
+// benchmark prices. With length of 1 the result is the ratio of the
+// newest prices; otherwise it is the ratio of the asset and benchmark
+// growth over the window, multiplied by 100, so that values above 100
+// mean outperformance.
+func (rs RelativeStrength) CalcPair(asset, benchmark []decimal.Decimal) (decimal.Decimal, error) {
+	if !rs.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	asset, benchmark, err := pairWindow(rs.cfg, asset, benchmark, rs.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	last := len(asset) - 1
+	if benchmark[last].IsZero() {
+		return rs.cfg.divByZero()
+	}
+
+	res := rs.cfg.div(asset[last], benchmark[last])
+
+	if rs.length > 1 {
+		if asset[0].IsZero() || benchmark[0].IsZero() {
+			return rs.cfg.divByZero()
+		}
+
+		base := rs.cfg.div(asset[0], benchmark[0])
+		res = rs.cfg.div(res, base).Mul(_hundred)
+	}
+
+	return rs.cfg.keep(rs.cfg.round(res))
+}
+
+// Count determines the total amount of data points of each series
+// needed for RelativeStrength calculation.
+func (rs RelativeStrength) Count() int {
+	return rs.length
+}
+
+// Spread holds all the necessary information needed to calculate the
+// z-score of the spread between two series.
+// The zero value is not usable.
+type Spread struct {
+	// valid specifies whether Spread paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// length specifies how many data points should be used during the
+	// calculations.
+	length int
+}
+
+// NewSpread validates provided configuration options and
+// creates new Spread indicator.
+func NewSpread(length int, opts ...Option) (Spread, error) {
+	s := Spread{
+		cfg:    newConfig(opts),
+		length: length,
+	}
+
+	if err := s.validate(); err != nil {
+		return Spread{}, err
+	}
+
+	return s, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (s *Spread) validate() error {
+	if err := s.cfg.validate("spread"); err != nil {
+		return err
+	}
+
+	if s.length < 3 {
+		return &ValidationError{
+			Indicator:  "spread",
+			Param:      "length",
+			Value:      s.length,
+			Constraint: "must be greater than 2",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	s.valid = true
+
+	return nil
+}
+
+// CalcPair calculates the z-score of the newest spread between the two
+// price series. The hedge ratio and the intercept are estimated with an
+// ordinary least squares regression of the first series on the second
+// one over the window, and the spread is the regression residual, as in
+// Engle-Granger cointegration test. The z-score is the newest spread
+// divided by the standard deviation of all spreads in the window.
+func (s Spread) CalcPair(aa, bb []decimal.Decimal) (decimal.Decimal, error) {
+	if !s.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	aa, bb, err := pairWindow(s.cfg, aa, bb, s.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	v := s.cfg.svar(bb)
+	if v.IsZero() {
+		return s.cfg.divByZero()
+	}
+
+	hedge := s.cfg.div(s.cfg.cov(aa, bb), v)
+	intercept := s.cfg.avg(aa).Sub(hedge.Mul(s.cfg.avg(bb)))
+
+	ss := make([]decimal.Decimal, len(aa))
+	for i := range aa {
+		ss[i] = aa[i].Sub(hedge.Mul(bb[i])).Sub(intercept)
+	}
+
+	sd := s.cfg.sdev(ss)
+	if sd.IsZero() {
+		return s.cfg.divByZero()
+	}
+
+	z := s.cfg.div(ss[len(ss)-1].Sub(s.cfg.avg(ss)), sd)
+
+	return s.cfg.keep(s.cfg.round(z))
+}
+
+// Count determines the total amount of data points of each series
+// needed for Spread calculation.
+func (s Spread) Count() int {
+	return s.length
+}
+
+// pair checks the sizes of both data point slices and converts them
+// into returns. False is returned when a price is zero.
+func (r returns) pair(c config, aa, bb []decimal.Decimal) ([]decimal.Decimal, []decimal.Decimal, bool, error) {
+	aa, bb, err := pairWindow(c, aa, bb, r.count())
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	aa, ok := r.returns(c, aa)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	bb, ok = r.returns(c, bb)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	return aa, bb, true, nil
+}
+
+// pairWindow checks the sizes of both data point slices and returns
+// their windows of count data points.
+func pairWindow(c config, aa, bb []decimal.Decimal, count int) ([]decimal.Decimal, []decimal.Decimal, error) {
+	aa, err := window(c, aa, count)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bb, err = window(c, bb, count)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return aa, bb, nil
+}
+
+// ranks replaces the values with their ranks, starting from 1. Equal
+// values receive the average of their ranks.
+func ranks(dd []decimal.Decimal) []decimal.Decimal {
+	ii := make([]int, len(dd))
+	for i := range ii {
+		ii[i] = i
+	}
+
+	sort.SliceStable(ii, func(i, j int) bool {
+		return dd[ii[i]].LessThan(dd[ii[j]])
+	})
+
+	res := make([]decimal.Decimal, len(dd))
+
+	for i := 0; i < len(ii); {
+		j := i + 1
+		for j < len(ii) && dd[ii[j]].Equal(dd[ii[i]]) {
+			j++
+		}
+
+		// positions i..j-1 share the average of ranks i+1..j.
+		rank := decimal.NewFromInt(int64(i + j + 1)).Div(decimal.NewFromInt(2))
+
+		for k := i; k < j; k++ {
+			res[ii[k]] = rank
+		}
+
+		i = j
+	}
+
+	return res
+}
@@ -0,0 +1,706 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// assets returns price series of an asset and its benchmark used by
+// pair indicator tests.
+func assets() ([]decimal.Decimal, []decimal.Decimal) {
+	return ratios("10", "11", "12", "11", "13", "14"),
+		ratios("20", "21", "23", "22", "24", "27")
+}
+
+func Test_CorrelationMethod_Validate(t *testing.T) {
+	cc := map[string]struct {
+		CorrelationMethod CorrelationMethod
+		Err               error
+	}{
+		"Invalid CorrelationMethod": {
+			Err: ErrInvalidCorrelation,
+		},
+		"Successful CorrelationPearson validation": {
+			CorrelationMethod: CorrelationPearson,
+		},
+		"Successful CorrelationSpearman validation": {
+			CorrelationMethod: CorrelationSpearman,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.CorrelationMethod.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_CorrelationMethod_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		CorrelationMethod CorrelationMethod
+		Text              string
+		Err               error
+	}{
+		"Invalid CorrelationMethod": {
+			Err: ErrInvalidCorrelation,
+		},
+		"Successful CorrelationPearson marshal": {
+			CorrelationMethod: CorrelationPearson,
+			Text:              "pearson",
+		},
+		"Successful CorrelationSpearman marshal": {
+			CorrelationMethod: CorrelationSpearman,
+			Text:              "spearman",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.CorrelationMethod.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_CorrelationMethod_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result CorrelationMethod
+		Err    error
+	}{
+		"Invalid CorrelationMethod": {
+			Err: ErrInvalidCorrelation,
+		},
+		"Successful CorrelationPearson unmarshal (long form)": {
+			Text:   "pearson",
+			Result: CorrelationPearson,
+		},
+		"Successful CorrelationPearson unmarshal (short form)": {
+			Text:   "p",
+			Result: CorrelationPearson,
+		},
+		"Successful CorrelationSpearman unmarshal (long form)": {
+			Text:   "spearman",
+			Result: CorrelationSpearman,
+		},
+		"Successful CorrelationSpearman unmarshal (short form)": {
+			Text:   "s",
+			Result: CorrelationSpearman,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var cm CorrelationMethod
+			err := cm.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, cm)
+		})
+	}
+}
+
+func Test_NewCorrelation(t *testing.T) {
+	cc := map[string]struct {
+		Method  CorrelationMethod
+		Source  Source
+		Length  int
+		Options []Option
+		Error   error
+	}{
+		"Invalid config": {
+			Method:  CorrelationPearson,
+			Source:  SourcePrice,
+			Length:  2,
+			Options: []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "correlation",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid method": {
+			Source: SourcePrice,
+			Length: 2,
+			Error: &ValidationError{
+				Indicator:  "correlation",
+				Param:      "method",
+				Value:      CorrelationMethod(0),
+				Constraint: "must be pearson or spearman",
+				Err:        ErrInvalidCorrelation,
+			},
+		},
+		"Invalid source": {
+			Method: CorrelationPearson,
+			Length: 2,
+			Error: &ValidationError{
+				Indicator:  "correlation",
+				Param:      "source",
+				Value:      Source(0),
+				Constraint: "must be price or return",
+				Err:        ErrInvalidSource,
+			},
+		},
+		"Invalid length": {
+			Method: CorrelationSpearman,
+			Source: SourceReturn,
+			Length: 1,
+			Error: &ValidationError{
+				Indicator:  "correlation",
+				Param:      "length",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully created new Correlation": {
+			Method: CorrelationSpearman,
+			Source: SourceReturn,
+			Length: 2,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewCorrelation(c.Method, c.Source, c.Length, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Method, res.method)
+			assert.Equal(t, returns{source: c.Source, length: c.Length}, res.in)
+		})
+	}
+}
+
+func Test_Correlation_CalcPair(t *testing.T) {
+	aa, bb := assets()
+
+	cc := map[string]struct {
+		Correlation Correlation
+		A           []decimal.Decimal
+		B           []decimal.Decimal
+		Result      float64
+		Error       error
+	}{
+		"Invalid indicator": {
+			A:     aa,
+			B:     bb,
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size of the first series": {
+			Correlation: Correlation{valid: true, in: returns{source: SourcePrice, length: 5}, method: CorrelationPearson},
+			A:           aa[1:],
+			B:           bb,
+			Error:       &DataSizeError{Expected: 6, Actual: 5},
+		},
+		"Invalid data size of the second series": {
+			Correlation: Correlation{valid: true, in: returns{source: SourcePrice, length: 5}, method: CorrelationPearson},
+			A:           aa,
+			B:           bb[1:],
+			Error:       &DataSizeError{Expected: 6, Actual: 5},
+		},
+		"Division by zero of zero price": {
+			Correlation: Correlation{
+				valid:  true,
+				cfg:    config{zero: ZeroPolicyError},
+				in:     returns{source: SourcePrice, length: 2},
+				method: CorrelationPearson,
+			},
+			A:     ratios("1", "2", "3"),
+			B:     ratios("0", "2", "3"),
+			Error: ErrDivisionByZero,
+		},
+		"Division by zero of constant series": {
+			Correlation: Correlation{
+				valid:  true,
+				cfg:    config{zero: ZeroPolicyError},
+				in:     returns{source: SourceReturn, length: 2},
+				method: CorrelationPearson,
+			},
+			A:     ratios("1", "2"),
+			B:     ratios("3", "3"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated Pearson correlation of returns": {
+			Correlation: Correlation{valid: true, in: returns{source: SourcePrice, length: 5}, method: CorrelationPearson},
+			A:           aa,
+			B:           bb,
+			Result:      0.795869930367983,
+		},
+		"Successfully calculated Spearman correlation of returns": {
+			Correlation: Correlation{valid: true, in: returns{source: SourcePrice, length: 5}, method: CorrelationSpearman},
+			A:           aa,
+			B:           bb,
+			Result:      0.1,
+		},
+		"Successfully calculated Pearson correlation of values": {
+			Correlation: Correlation{valid: true, in: returns{source: SourceReturn, length: 6}, method: CorrelationPearson},
+			A:           aa,
+			B:           bb,
+			Result:      0.9757560105373638,
+		},
+		"Successfully calculated Spearman correlation of values with ties": {
+			Correlation: Correlation{valid: true, in: returns{source: SourceReturn, length: 6}, method: CorrelationSpearman},
+			A:           aa,
+			B:           bb,
+			Result:      0.9856107606091623,
+		},
+		"Successfully calculated correlation with lenient window": {
+			Correlation: Correlation{
+				valid:  true,
+				cfg:    config{lenient: true},
+				in:     returns{source: SourceReturn, length: 6},
+				method: CorrelationPearson,
+			},
+			A:      append(ratios("100"), aa...),
+			B:      bb,
+			Result: 0.9757560105373638,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Correlation.CalcPair(c.A, c.B)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Correlation_Count(t *testing.T) {
+	assert.Equal(t, 6, Correlation{in: returns{source: SourcePrice, length: 5}}.Count())
+	assert.Equal(t, 5, Correlation{in: returns{source: SourceReturn, length: 5}}.Count())
+}
+
+func Test_NewCovariance(t *testing.T) {
+	_, err := NewCovariance(SourcePrice, 2, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "covariance",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewCovariance(SourcePrice, 1)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "covariance",
+		Param:      "length",
+		Value:      1,
+		Constraint: "must be greater than 1",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	res, err := NewCovariance(SourcePrice, 2)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+	assert.Equal(t, returns{source: SourcePrice, length: 2}, res.in)
+}
+
+func Test_Covariance_CalcPair(t *testing.T) {
+	aa, bb := assets()
+
+	cc := map[string]struct {
+		Covariance Covariance
+		A          []decimal.Decimal
+		B          []decimal.Decimal
+		Result     float64
+		Error      error
+	}{
+		"Invalid indicator": {
+			A:     aa,
+			B:     bb,
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Covariance: Covariance{valid: true, in: returns{source: SourcePrice, length: 5}},
+			A:          aa,
+			B:          bb[1:],
+			Error:      &DataSizeError{Expected: 6, Actual: 5},
+		},
+		"Division by zero of zero price": {
+			Covariance: Covariance{
+				valid: true,
+				cfg:   config{zero: ZeroPolicyError},
+				in:    returns{source: SourcePrice, length: 2},
+			},
+			A:     ratios("0", "2", "3"),
+			B:     ratios("1", "2", "3"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated covariance of returns": {
+			Covariance: Covariance{valid: true, in: returns{source: SourcePrice, length: 5}},
+			A:          aa,
+			B:          bb,
+			Result:     0.005038000180539703,
+		},
+		"Successfully calculated covariance of values": {
+			Covariance: Covariance{valid: true, in: returns{source: SourceReturn, length: 6}},
+			A:          aa,
+			B:          bb,
+			Result:     3.5666666666666673,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Covariance.CalcPair(c.A, c.B)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Covariance_Count(t *testing.T) {
+	assert.Equal(t, 6, Covariance{in: returns{source: SourcePrice, length: 5}}.Count())
+}
+
+func Test_NewBeta(t *testing.T) {
+	_, err := NewBeta(SourcePrice, 2, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "beta",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewBeta(Source(0), 2)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "beta",
+		Param:      "source",
+		Value:      Source(0),
+		Constraint: "must be price or return",
+		Err:        ErrInvalidSource,
+	}, err)
+
+	_, err = NewBeta(SourcePrice, 1)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "beta",
+		Param:      "length",
+		Value:      1,
+		Constraint: "must be greater than 1",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	res, err := NewBeta(SourceReturn, 2)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+	assert.Equal(t, returns{source: SourceReturn, length: 2}, res.in)
+}
+
+func Test_Beta_CalcPair(t *testing.T) {
+	aa, bb := assets()
+
+	cc := map[string]struct {
+		Beta   Beta
+		A      []decimal.Decimal
+		B      []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			A:     aa,
+			B:     bb,
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Beta:  Beta{valid: true, in: returns{source: SourcePrice, length: 5}},
+			A:     aa[1:],
+			B:     bb,
+			Error: &DataSizeError{Expected: 6, Actual: 5},
+		},
+		"Division by zero of zero price": {
+			Beta: Beta{
+				valid: true,
+				cfg:   config{zero: ZeroPolicyError},
+				in:    returns{source: SourcePrice, length: 2},
+			},
+			A:     ratios("1", "2", "3"),
+			B:     ratios("0", "2", "3"),
+			Error: ErrDivisionByZero,
+		},
+		"Division by zero of constant benchmark": {
+			Beta: Beta{
+				valid: true,
+				cfg:   config{zero: ZeroPolicyError},
+				in:    returns{source: SourceReturn, length: 2},
+			},
+			A:     ratios("0.01", "0.02"),
+			B:     ratios("0.01", "0.01"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated beta of returns": {
+			Beta:   Beta{valid: true, in: returns{source: SourcePrice, length: 5}},
+			A:      aa,
+			B:      bb,
+			Result: 1.1738522935944553,
+		},
+		"Successfully calculated beta of values": {
+			Beta:   Beta{valid: true, in: returns{source: SourceReturn, length: 6}},
+			A:      aa,
+			B:      bb,
+			Result: 0.5783783783783785,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Beta.CalcPair(c.A, c.B)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Beta_Count(t *testing.T) {
+	assert.Equal(t, 5, Beta{in: returns{source: SourceReturn, length: 5}}.Count())
+}
+
+func Test_NewRelativeStrength(t *testing.T) {
+	_, err := NewRelativeStrength(1, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "rs",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewRelativeStrength(0)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "rs",
+		Param:      "length",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	res, err := NewRelativeStrength(1)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+	assert.Equal(t, 1, res.length)
+}
+
+func Test_RelativeStrength_CalcPair(t *testing.T) {
+	aa, bb := assets()
+
+	cc := map[string]struct {
+		RelativeStrength RelativeStrength
+		A                []decimal.Decimal
+		B                []decimal.Decimal
+		Result           float64
+		Error            error
+	}{
+		"Invalid indicator": {
+			A:     aa,
+			B:     bb,
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			RelativeStrength: RelativeStrength{valid: true, length: 6},
+			A:                aa,
+			B:                bb[1:],
+			Error:            &DataSizeError{Expected: 6, Actual: 5},
+		},
+		"Division by zero of the newest benchmark price": {
+			RelativeStrength: RelativeStrength{valid: true, cfg: config{zero: ZeroPolicyError}, length: 1},
+			A:                ratios("1"),
+			B:                ratios("0"),
+			Error:            ErrDivisionByZero,
+		},
+		"Division by zero of the oldest price": {
+			RelativeStrength: RelativeStrength{valid: true, cfg: config{zero: ZeroPolicyError}, length: 2},
+			A:                ratios("0", "1"),
+			B:                ratios("1", "1"),
+			Error:            ErrDivisionByZero,
+		},
+		"Successfully calculated ratio line": {
+			RelativeStrength: RelativeStrength{valid: true, cfg: config{lenient: true}, length: 1},
+			A:                aa,
+			B:                bb,
+			Result:           0.5185185185185185,
+		},
+		"Successfully calculated rebased relative strength": {
+			RelativeStrength: RelativeStrength{valid: true, length: 6},
+			A:                aa,
+			B:                bb,
+			Result:           103.7037037037037,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.RelativeStrength.CalcPair(c.A, c.B)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_RelativeStrength_Count(t *testing.T) {
+	assert.Equal(t, 6, RelativeStrength{length: 6}.Count())
+}
+
+func Test_NewSpread(t *testing.T) {
+	_, err := NewSpread(3, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "spread",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewSpread(2)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "spread",
+		Param:      "length",
+		Value:      2,
+		Constraint: "must be greater than 2",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	res, err := NewSpread(3)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+	assert.Equal(t, 3, res.length)
+}
+
+func Test_Spread_CalcPair(t *testing.T) {
+	aa, bb := assets()
+
+	cc := map[string]struct {
+		Spread Spread
+		A      []decimal.Decimal
+		B      []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			A:     aa,
+			B:     bb,
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Spread: Spread{valid: true, length: 6},
+			A:      aa[1:],
+			B:      bb,
+			Error:  &DataSizeError{Expected: 6, Actual: 5},
+		},
+		"Division by zero of constant second series": {
+			Spread: Spread{valid: true, cfg: config{zero: ZeroPolicyError}, length: 3},
+			A:      ratios("1", "2", "3"),
+			B:      ratios("5", "5", "5"),
+			Error:  ErrDivisionByZero,
+		},
+		"Division by zero of perfectly hedged series": {
+			Spread: Spread{valid: true, cfg: config{zero: ZeroPolicyError}, length: 3},
+			A:      ratios("1", "2", "3"),
+			B:      ratios("2", "4", "6"),
+			Error:  ErrDivisionByZero,
+		},
+		"Successfully calculated spread z-score": {
+			Spread: Spread{valid: true, length: 6},
+			A:      aa,
+			B:      bb,
+			Result: -0.8271164498555335,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Spread.CalcPair(c.A, c.B)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Spread_Count(t *testing.T) {
+	assert.Equal(t, 6, Spread{length: 6}.Count())
+}
+
+func Test_ranks(t *testing.T) {
+	res := ranks(ratios("3", "1", "3", "2"))
+
+	assert.Equal(t, []string{"3.5", "1", "3.5", "2"},
+		[]string{res[0].String(), res[1].String(), res[2].String(), res[3].String()})
+}
@@ -0,0 +1,718 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/jellydator/indc"
+)
+
+// _maxSize specifies the number of candles of the longest pattern.
+const _maxSize = 5
+
+// detector recognizes a single pattern of the fixed size.
+type detector struct {
+	// pattern specifies the recognized pattern.
+	pattern Pattern
+
+	// size specifies the number of candles the pattern spans.
+	size int
+
+	// fn checks whether the bars form the pattern and returns its
+	// direction and strength.
+	fn func(ctx context, bb []bar) (indc.Trend, float64, bool)
+}
+
+// _detectors specifies detectors of all supported patterns.
+var _detectors = []detector{
+	{Doji, 1, doji},
+	{DragonflyDoji, 1, dragonflyDoji},
+	{GravestoneDoji, 1, gravestoneDoji},
+	{LongLeggedDoji, 1, longLeggedDoji},
+	{Hammer, 1, hammer},
+	{HangingMan, 1, hangingMan},
+	{InvertedHammer, 1, invertedHammer},
+	{ShootingStar, 1, shootingStar},
+	{Marubozu, 1, marubozu},
+	{SpinningTop, 1, spinningTop},
+	{BeltHold, 1, beltHold},
+	{Engulfing, 2, engulfing},
+	{Harami, 2, harami},
+	{HaramiCross, 2, haramiCross},
+	{PiercingLine, 2, piercingLine},
+	{DarkCloudCover, 2, darkCloudCover},
+	{TweezerTop, 2, tweezerTop},
+	{TweezerBottom, 2, tweezerBottom},
+	{Kicker, 2, kicker},
+	{OnNeck, 2, onNeck},
+	{InNeck, 2, inNeck},
+	{Thrusting, 2, thrusting},
+	{MatchingLow, 2, matchingLow},
+	{Counterattack, 2, counterattack},
+	{SeparatingLines, 2, separatingLines},
+	{MorningStar, 3, morningStar},
+	{EveningStar, 3, eveningStar},
+	{MorningDojiStar, 3, morningDojiStar},
+	{EveningDojiStar, 3, eveningDojiStar},
+	{AbandonedBaby, 3, abandonedBaby},
+	{ThreeWhiteSoldiers, 3, threeWhiteSoldiers},
+	{ThreeBlackCrows, 3, threeBlackCrows},
+	{ThreeInside, 3, threeInside},
+	{ThreeOutside, 3, threeOutside},
+	{TasukiGap, 3, tasukiGap},
+	{TriStar, 3, triStar},
+	{ThreeMethods, 5, threeMethods},
+}
+
+// context holds properties of candles that precede a pattern.
+type context struct {
+	// th specifies thresholds of candle proportions.
+	th Thresholds
+
+	// avgBody specifies the average body of preceding candles.
+	avgBody float64
+
+	// avgRange specifies the average range of preceding candles.
+	avgRange float64
+
+	// trend specifies the direction of preceding candles. It is zero
+	// when there are not enough of them or the price did not change.
+	trend indc.Trend
+}
+
+// long returns true if the bar has a long body.
+func (ctx context) long(b bar) bool {
+	return b.body() > 0 && b.body() >= ctx.th.LongBody*ctx.avgBody
+}
+
+// longScore scores the length of the body.
+func (ctx context) longScore(b bar) float64 {
+	return above(b.body(), ctx.th.LongBody*ctx.avgBody)
+}
+
+// doji returns true if the body of the bar is almost nonexistent.
+func (ctx context) doji(b bar) bool {
+	return b.rng() > 0 && b.body() <= ctx.th.DojiBody*b.rng()
+}
+
+// dojiScore scores the smallness of the doji body.
+func (ctx context) dojiScore(b bar) float64 {
+	return below(ratio(b.body(), b.rng()), ctx.th.DojiBody)
+}
+
+// small returns true if the bar has a small body.
+func (ctx context) small(b bar) bool {
+	return b.rng() > 0 && b.body() <= ctx.th.SmallBody*b.rng()
+}
+
+// smallScore scores the smallness of the body.
+func (ctx context) smallScore(b bar) float64 {
+	return below(ratio(b.body(), b.rng()), ctx.th.SmallBody)
+}
+
+// short returns true if the shadow of the bar is short.
+func (ctx context) short(b bar, shadow float64) bool {
+	return shadow <= ctx.th.ShortShadow*b.rng()
+}
+
+// shortScore scores the shortness of the shadow.
+func (ctx context) shortScore(b bar, shadow float64) float64 {
+	return below(ratio(shadow, b.rng()), ctx.th.ShortShadow)
+}
+
+// equal returns true if prices are almost equal.
+func (ctx context) equal(x, y float64) bool {
+	return math.Abs(x-y) <= ctx.th.Equal*ctx.avgRange
+}
+
+// equalScore scores how close the prices are.
+func (ctx context) equalScore(x, y float64) float64 {
+	return below(math.Abs(x-y), ctx.th.Equal*ctx.avgRange)
+}
+
+// clamp limits value to the range from 0 to 1.
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// doji detects a candle with almost equal open and close prices.
+func doji(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b := bb[0]
+
+	if !ctx.doji(b) {
+		return 0, 0, false
+	}
+
+	return 0, ctx.dojiScore(b), true
+}
+
+// dragonflyDoji detects a doji without an upper shadow.
+func dragonflyDoji(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b := bb[0]
+
+	if !ctx.doji(b) || !ctx.short(b, b.upper()) {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, mean(ctx.dojiScore(b), ctx.shortScore(b, b.upper())), true
+}
+
+// gravestoneDoji detects a doji without a lower shadow.
+func gravestoneDoji(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b := bb[0]
+
+	if !ctx.doji(b) || !ctx.short(b, b.lower()) {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, mean(ctx.dojiScore(b), ctx.shortScore(b, b.lower())), true
+}
+
+// longLeggedDoji detects a doji with a long range and neither of the
+// shadows short.
+func longLeggedDoji(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b := bb[0]
+
+	if !ctx.doji(b) || ctx.short(b, b.upper()) || ctx.short(b, b.lower()) ||
+		b.rng() < ctx.th.LongBody*ctx.avgRange {
+		return 0, 0, false
+	}
+
+	return 0, mean(ctx.dojiScore(b), above(b.rng(), ctx.th.LongBody*ctx.avgRange)), true
+}
+
+// lowerShadow checks whether the bar has a small body, a long lower
+// shadow and a short upper shadow.
+func lowerShadow(ctx context, b bar) (float64, bool) {
+	if !ctx.small(b) || b.lower() < ctx.th.LongShadow*b.body() || !ctx.short(b, b.upper()) {
+		return 0, false
+	}
+
+	return mean(
+		ctx.smallScore(b),
+		above(ratio(b.lower(), b.body()), ctx.th.LongShadow),
+		ctx.shortScore(b, b.upper()),
+	), true
+}
+
+// upperShadow checks whether the bar has a small body, a long upper
+// shadow and a short lower shadow.
+func upperShadow(ctx context, b bar) (float64, bool) {
+	if !ctx.small(b) || b.upper() < ctx.th.LongShadow*b.body() || !ctx.short(b, b.lower()) {
+		return 0, false
+	}
+
+	return mean(
+		ctx.smallScore(b),
+		above(ratio(b.upper(), b.body()), ctx.th.LongShadow),
+		ctx.shortScore(b, b.lower()),
+	), true
+}
+
+// hammer detects a candle with a long lower shadow after a downtrend.
+func hammer(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := lowerShadow(ctx, bb[0])
+	if !ok || ctx.trend != indc.TrendDown {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, s, true
+}
+
+// hangingMan detects a candle with a long lower shadow after an uptrend.
+func hangingMan(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := lowerShadow(ctx, bb[0])
+	if !ok || ctx.trend != indc.TrendUp {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, s, true
+}
+
+// invertedHammer detects a candle with a long upper shadow after a
+// downtrend.
+func invertedHammer(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := upperShadow(ctx, bb[0])
+	if !ok || ctx.trend != indc.TrendDown {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, s, true
+}
+
+// shootingStar detects a candle with a long upper shadow after an
+// uptrend.
+func shootingStar(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := upperShadow(ctx, bb[0])
+	if !ok || ctx.trend != indc.TrendUp {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, s, true
+}
+
+// marubozu detects a long body without shadows.
+func marubozu(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b := bb[0]
+
+	if !ctx.long(b) || !ctx.short(b, b.upper()) || !ctx.short(b, b.lower()) {
+		return 0, 0, false
+	}
+
+	return b.direction(), mean(
+		ctx.longScore(b),
+		ctx.shortScore(b, b.upper()),
+		ctx.shortScore(b, b.lower()),
+	), true
+}
+
+// spinningTop detects a small body with both shadows longer than the
+// body.
+func spinningTop(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b := bb[0]
+	shadow := math.Min(b.upper(), b.lower())
+
+	if !ctx.small(b) || ctx.doji(b) || shadow <= b.body() {
+		return 0, 0, false
+	}
+
+	return 0, mean(ctx.smallScore(b), above(ratio(shadow, b.body()), 1)), true
+}
+
+// beltHold detects a long body that opens at its extreme against the
+// preceding trend.
+func beltHold(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b := bb[0]
+
+	if !ctx.long(b) {
+		return 0, 0, false
+	}
+
+	switch {
+	case b.bull() && ctx.trend == indc.TrendDown && ctx.short(b, b.lower()):
+		return indc.TrendUp, mean(ctx.longScore(b), ctx.shortScore(b, b.lower())), true
+	case b.bear() && ctx.trend == indc.TrendUp && ctx.short(b, b.upper()):
+		return indc.TrendDown, mean(ctx.longScore(b), ctx.shortScore(b, b.upper())), true
+	default:
+		return 0, 0, false
+	}
+}
+
+// reverse returns the opposite direction.
+func reverse(t indc.Trend) indc.Trend {
+	switch t {
+	case indc.TrendUp:
+		return indc.TrendDown
+	case indc.TrendDown:
+		return indc.TrendUp
+	default:
+		return 0
+	}
+}
+
+// opposite returns true if bodies of the bars have opposite directions.
+func opposite(a, b bar) bool {
+	return a.direction() != 0 && b.direction() != 0 && a.direction() != b.direction()
+}
+
+// engulfing detects a body that engulfs the opposite body of the
+// previous candle.
+func engulfing(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !opposite(a, b) || b.top() < a.top() || b.bottom() > a.bottom() || b.body() <= a.body() {
+		return 0, 0, false
+	}
+
+	return b.direction(), above(ratio(b.body(), a.body()), 1), true
+}
+
+// contained returns true if the body of b is within the body of a.
+func contained(a, b bar) bool {
+	return b.top() <= a.top() && b.bottom() >= a.bottom() && b.body() < a.body()
+}
+
+// harami detects a small body contained within the opposite long body
+// of the previous candle.
+func harami(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !ctx.long(a) || !opposite(a, b) || ctx.doji(b) || !contained(a, b) {
+		return 0, 0, false
+	}
+
+	return b.direction(), mean(ctx.longScore(a), 1-b.body()/a.body()), true
+}
+
+// haramiCross detects a doji contained within the long body of the
+// previous candle.
+func haramiCross(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !ctx.long(a) || !ctx.doji(b) || !contained(a, b) {
+		return 0, 0, false
+	}
+
+	return reverse(a.direction()), mean(ctx.longScore(a), ctx.dojiScore(b)), true
+}
+
+// piercingLine detects a bullish body that opens below a long bearish
+// body and closes above its midpoint.
+func piercingLine(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !ctx.long(a) || !a.bear() || !b.bull() || b.o >= a.l || b.c <= a.mid() || b.c >= a.o {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, mean(ctx.longScore(a), (b.c-a.c)/a.body()), true
+}
+
+// darkCloudCover detects a bearish body that opens above a long bullish
+// body and closes below its midpoint.
+func darkCloudCover(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !ctx.long(a) || !a.bull() || !b.bear() || b.o <= a.h || b.c >= a.mid() || b.c <= a.o {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, mean(ctx.longScore(a), (a.c-b.c)/a.body()), true
+}
+
+// tweezerTop detects a bullish and a bearish candle with equal highs
+// after an uptrend.
+func tweezerTop(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if ctx.trend != indc.TrendUp || !a.bull() || !b.bear() || !ctx.equal(a.h, b.h) {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, ctx.equalScore(a.h, b.h), true
+}
+
+// tweezerBottom detects a bearish and a bullish candle with equal lows
+// after a downtrend.
+func tweezerBottom(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if ctx.trend != indc.TrendDown || !a.bear() || !b.bull() || !ctx.equal(a.l, b.l) {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, ctx.equalScore(a.l, b.l), true
+}
+
+// kicker detects two opposite long bodies, the second of which gaps
+// beyond the open of the first one.
+func kicker(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !ctx.long(a) || !ctx.long(b) || !opposite(a, b) ||
+		b.bull() && b.o <= a.o || b.bear() && b.o >= a.o {
+		return 0, 0, false
+	}
+
+	return b.direction(), mean(ctx.longScore(a), ctx.longScore(b)), true
+}
+
+// neck checks whether a bullish body opens below a long bearish body.
+func neck(ctx context, a, b bar) bool {
+	return ctx.long(a) && a.bear() && b.bull() && b.o < a.l
+}
+
+// onNeck detects a bullish body that opens below a long bearish body
+// and closes at its low.
+func onNeck(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !neck(ctx, a, b) || !ctx.equal(b.c, a.l) {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, mean(ctx.longScore(a), ctx.equalScore(b.c, a.l)), true
+}
+
+// inNeck detects a bullish body that opens below a long bearish body
+// and closes at or slightly above its close.
+func inNeck(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !neck(ctx, a, b) || b.c < a.c || !ctx.equal(b.c, a.c) {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, mean(ctx.longScore(a), ctx.equalScore(b.c, a.c)), true
+}
+
+// thrusting detects a bullish body that opens below a long bearish body
+// and closes inside of it, but below its midpoint.
+func thrusting(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !neck(ctx, a, b) || b.c <= a.c || ctx.equal(b.c, a.c) || b.c >= a.mid() {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, mean(ctx.longScore(a), 1-(b.c-a.c)/(a.mid()-a.c)), true
+}
+
+// matchingLow detects two bearish bodies with equal closes after a
+// downtrend.
+func matchingLow(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if ctx.trend != indc.TrendDown || !a.bear() || !b.bear() || !ctx.equal(a.c, b.c) {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, ctx.equalScore(a.c, b.c), true
+}
+
+// counterattack detects two opposite long bodies with equal closes.
+func counterattack(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !ctx.long(a) || !ctx.long(b) || !opposite(a, b) || !ctx.equal(a.c, b.c) {
+		return 0, 0, false
+	}
+
+	return b.direction(), mean(ctx.longScore(a), ctx.longScore(b), ctx.equalScore(a.c, b.c)), true
+}
+
+// separatingLines detects two opposite bodies with equal opens, the
+// second of which is long and continues the preceding trend.
+func separatingLines(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b := bb[0], bb[1]
+
+	if !ctx.long(b) || !opposite(a, b) || ctx.trend != b.direction() || !ctx.equal(a.o, b.o) {
+		return 0, 0, false
+	}
+
+	return b.direction(), mean(ctx.longScore(b), ctx.equalScore(a.o, b.o)), true
+}
+
+// star checks whether the star pattern of the direction is formed, with
+// the middle body checked by the provided function.
+func star(ctx context, bb []bar, dir indc.Trend, middle func(bar) bool) (float64, bool) {
+	a, b, c := bb[0], bb[1], bb[2]
+
+	if !ctx.long(a) || !middle(b) {
+		return 0, false
+	}
+
+	switch {
+	case dir == indc.TrendUp && a.bear() && b.top() < a.c && c.bull() && c.c > a.mid():
+		return mean(ctx.longScore(a), clamp((c.c-a.c)/a.body())), true
+	case dir == indc.TrendDown && a.bull() && b.bottom() > a.c && c.bear() && c.c < a.mid():
+		return mean(ctx.longScore(a), clamp((a.c-c.c)/a.body())), true
+	default:
+		return 0, false
+	}
+}
+
+// morningStar detects a long bearish body, a small body that gaps below
+// it and a bullish body that closes above the first midpoint.
+func morningStar(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := star(ctx, bb, indc.TrendUp, ctx.small)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, mean(s, ctx.smallScore(bb[1])), true
+}
+
+// eveningStar detects a long bullish body, a small body that gaps above
+// it and a bearish body that closes below the first midpoint.
+func eveningStar(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := star(ctx, bb, indc.TrendDown, ctx.small)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, mean(s, ctx.smallScore(bb[1])), true
+}
+
+// morningDojiStar detects a morning star with a doji in the middle.
+func morningDojiStar(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := star(ctx, bb, indc.TrendUp, ctx.doji)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, mean(s, ctx.dojiScore(bb[1])), true
+}
+
+// eveningDojiStar detects an evening star with a doji in the middle.
+func eveningDojiStar(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := star(ctx, bb, indc.TrendDown, ctx.doji)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, mean(s, ctx.dojiScore(bb[1])), true
+}
+
+// abandonedBaby detects a doji star whose shadows gap away from both
+// neighbouring candles.
+func abandonedBaby(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b, c := bb[0], bb[1], bb[2]
+
+	for _, dir := range []indc.Trend{indc.TrendUp, indc.TrendDown} {
+		s, ok := star(ctx, bb, dir, ctx.doji)
+		if !ok {
+			continue
+		}
+
+		if dir == indc.TrendUp && b.h < a.l && b.h < c.l ||
+			dir == indc.TrendDown && b.l > a.h && b.l > c.h {
+			return dir, mean(s, ctx.dojiScore(b)), true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// soldiers checks whether three bodies of the direction are not small,
+// move in the direction and each opens within the previous body.
+func soldiers(ctx context, bb []bar, dir indc.Trend) (float64, bool) {
+	var ss []float64
+
+	for i, b := range bb {
+		if b.direction() != dir || ctx.small(b) {
+			return 0, false
+		}
+
+		ss = append(ss, above(ratio(b.body(), b.rng()), ctx.th.SmallBody))
+
+		if i == 0 {
+			continue
+		}
+
+		p := bb[i-1]
+
+		if b.o < p.bottom() || b.o > p.top() ||
+			dir == indc.TrendUp && b.c <= p.c || dir == indc.TrendDown && b.c >= p.c {
+			return 0, false
+		}
+	}
+
+	return mean(ss...), true
+}
+
+// threeWhiteSoldiers detects three rising bullish bodies, each opening
+// within the previous one.
+func threeWhiteSoldiers(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := soldiers(ctx, bb, indc.TrendUp)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return indc.TrendUp, s, true
+}
+
+// threeBlackCrows detects three falling bearish bodies, each opening
+// within the previous one.
+func threeBlackCrows(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	s, ok := soldiers(ctx, bb, indc.TrendDown)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return indc.TrendDown, s, true
+}
+
+// confirmed returns true if the third bar closes beyond the open of the
+// first one in the direction.
+func confirmed(bb []bar, dir indc.Trend) bool {
+	a, c := bb[0], bb[2]
+
+	return dir == indc.TrendUp && c.c > a.o || dir == indc.TrendDown && c.c < a.o
+}
+
+// threeInside detects a harami confirmed by the third candle.
+func threeInside(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	dir, s, ok := harami(ctx, bb[:2])
+	if !ok || !confirmed(bb, dir) {
+		return 0, 0, false
+	}
+
+	return dir, s, true
+}
+
+// threeOutside detects an engulfing confirmed by the third candle.
+func threeOutside(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	b, c := bb[1], bb[2]
+
+	dir, s, ok := engulfing(ctx, bb[:2])
+	if !ok || dir == indc.TrendUp && c.c <= b.c || dir == indc.TrendDown && c.c >= b.c {
+		return 0, 0, false
+	}
+
+	return dir, s, true
+}
+
+// tasukiGap detects two bodies that gap in the same direction followed
+// by an opposite body that opens within the second body and does not
+// close the gap.
+func tasukiGap(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b, c := bb[0], bb[1], bb[2]
+
+	if a.direction() != b.direction() || !opposite(b, c) || c.o < b.bottom() || c.o > b.top() {
+		return 0, 0, false
+	}
+
+	switch {
+	case a.bull() && b.l > a.h && c.c > a.h && c.c < b.l:
+		return indc.TrendUp, 1 - (b.l-c.c)/(b.l-a.h), true
+	case a.bear() && b.h < a.l && c.c < a.l && c.c > b.h:
+		return indc.TrendDown, 1 - (c.c-b.h)/(a.l-b.h), true
+	default:
+		return 0, 0, false
+	}
+}
+
+// triStar detects three doji, the middle one gapping away from the
+// others.
+func triStar(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, b, c := bb[0], bb[1], bb[2]
+
+	if !ctx.doji(a) || !ctx.doji(b) || !ctx.doji(c) {
+		return 0, 0, false
+	}
+
+	s := mean(ctx.dojiScore(a), ctx.dojiScore(b), ctx.dojiScore(c))
+
+	switch {
+	case b.top() < a.bottom() && b.top() < c.bottom():
+		return indc.TrendUp, s, true
+	case b.bottom() > a.top() && b.bottom() > c.top():
+		return indc.TrendDown, s, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// threeMethods detects a long body, three small bodies within its range
+// and a long body of the same direction that closes beyond the first
+// one.
+func threeMethods(ctx context, bb []bar) (indc.Trend, float64, bool) {
+	a, e := bb[0], bb[4]
+
+	if !ctx.long(a) || !ctx.long(e) || a.direction() != e.direction() ||
+		a.bull() && e.c <= a.c || a.bear() && e.c >= a.c {
+		return 0, 0, false
+	}
+
+	ss := []float64{ctx.longScore(a), ctx.longScore(e)}
+
+	for _, b := range bb[1:4] {
+		if b.h > a.h || b.l < a.l || b.body() >= a.body() {
+			return 0, 0, false
+		}
+
+		ss = append(ss, 1-b.body()/a.body())
+	}
+
+	return a.direction(), mean(ss...), true
+}
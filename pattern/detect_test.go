@@ -0,0 +1,426 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/jellydator/indc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_detectors(t *testing.T) {
+	cc := map[string]struct {
+		Detector func(ctx context, bb []bar) (indc.Trend, float64, bool)
+		Context  indc.Trend
+		Bars     []bar
+		Trend    indc.Trend
+		Strength float64
+		Match    bool
+	}{
+		"Doji not matched": {
+			Detector: doji,
+			Bars:     []bar{ohlc(10, 11, 9, 10.5)},
+		},
+		"Doji not matched without range": {
+			Detector: doji,
+			Bars:     []bar{ohlc(10, 10, 10, 10)},
+		},
+		"Successfully matched doji": {
+			Detector: doji,
+			Bars:     []bar{ohlc(10, 11, 9, 10)},
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched dragonfly doji": {
+			Detector: dragonflyDoji,
+			Bars:     []bar{ohlc(10, 10, 8, 10)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched gravestone doji": {
+			Detector: gravestoneDoji,
+			Bars:     []bar{ohlc(10, 12, 10, 10)},
+			Trend:    indc.TrendDown,
+			Strength: 1,
+			Match:    true,
+		},
+		"Long legged doji not matched with short range": {
+			Detector: longLeggedDoji,
+			Bars:     []bar{ohlc(10, 10.5, 9.5, 10)},
+		},
+		"Successfully matched long legged doji": {
+			Detector: longLeggedDoji,
+			Bars:     []bar{ohlc(10, 12, 8, 10)},
+			Strength: 1,
+			Match:    true,
+		},
+		"Hammer not matched without downtrend": {
+			Detector: hammer,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(10, 10.5, 8, 10.5)},
+		},
+		"Successfully matched hammer": {
+			Detector: hammer,
+			Context:  indc.TrendDown,
+			Bars:     []bar{ohlc(10, 10.5, 8, 10.5)},
+			Trend:    indc.TrendUp,
+			Strength: 8.0 / 9,
+			Match:    true,
+		},
+		"Successfully matched hanging man": {
+			Detector: hangingMan,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(10, 10.5, 8, 10.5)},
+			Trend:    indc.TrendDown,
+			Strength: 8.0 / 9,
+			Match:    true,
+		},
+		"Successfully matched inverted hammer": {
+			Detector: invertedHammer,
+			Context:  indc.TrendDown,
+			Bars:     []bar{ohlc(10, 12.5, 10, 10.5)},
+			Trend:    indc.TrendUp,
+			Strength: 8.0 / 9,
+			Match:    true,
+		},
+		"Shooting star not matched with long lower shadow": {
+			Detector: shootingStar,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(10, 12.5, 9, 10.5)},
+		},
+		"Successfully matched shooting star": {
+			Detector: shootingStar,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(10, 12.5, 10, 10.5)},
+			Trend:    indc.TrendDown,
+			Strength: 8.0 / 9,
+			Match:    true,
+		},
+		"Marubozu not matched with short body": {
+			Detector: marubozu,
+			Bars:     []bar{ohlc(10, 10.5, 10, 10.5)},
+		},
+		"Successfully matched bullish marubozu": {
+			Detector: marubozu,
+			Bars:     []bar{ohlc(10, 12, 10, 12)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched bearish marubozu": {
+			Detector: marubozu,
+			Bars:     []bar{ohlc(12, 12, 10, 10)},
+			Trend:    indc.TrendDown,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched spinning top": {
+			Detector: spinningTop,
+			Bars:     []bar{ohlc(10, 11, 9, 10.4)},
+			Strength: 17.0 / 24,
+			Match:    true,
+		},
+		"Belt hold not matched in the direction of the trend": {
+			Detector: beltHold,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(10, 12.5, 10, 12)},
+		},
+		"Successfully matched belt hold": {
+			Detector: beltHold,
+			Context:  indc.TrendDown,
+			Bars:     []bar{ohlc(10, 12.5, 10, 12)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Engulfing not matched with equal directions": {
+			Detector: engulfing,
+			Bars:     []bar{ohlc(10, 11.2, 9.8, 11), ohlc(9.5, 12, 9.4, 11.5)},
+		},
+		"Successfully matched engulfing": {
+			Detector: engulfing,
+			Bars:     []bar{ohlc(11, 11.2, 9.8, 10), ohlc(9.5, 12, 9.4, 11.5)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched harami": {
+			Detector: harami,
+			Bars:     []bar{ohlc(10, 12.2, 9.8, 12), ohlc(11.5, 11.6, 10.9, 11)},
+			Trend:    indc.TrendDown,
+			Strength: 0.875,
+			Match:    true,
+		},
+		"Successfully matched harami cross": {
+			Detector: haramiCross,
+			Bars:     []bar{ohlc(10, 12.2, 9.8, 12), ohlc(11, 11.5, 10.5, 11)},
+			Trend:    indc.TrendDown,
+			Strength: 1,
+			Match:    true,
+		},
+		"Piercing line not matched below midpoint": {
+			Detector: piercingLine,
+			Bars:     []bar{ohlc(12, 12.1, 9.9, 10), ohlc(9.5, 10.9, 9.4, 10.8)},
+		},
+		"Successfully matched piercing line": {
+			Detector: piercingLine,
+			Bars:     []bar{ohlc(12, 12.1, 9.9, 10), ohlc(9.5, 11.6, 9.4, 11.5)},
+			Trend:    indc.TrendUp,
+			Strength: 0.875,
+			Match:    true,
+		},
+		"Successfully matched dark cloud cover": {
+			Detector: darkCloudCover,
+			Bars:     []bar{ohlc(10, 12.1, 9.9, 12), ohlc(12.5, 12.6, 10.4, 10.5)},
+			Trend:    indc.TrendDown,
+			Strength: 0.875,
+			Match:    true,
+		},
+		"Tweezer top not matched with different highs": {
+			Detector: tweezerTop,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(10, 12, 9.9, 11.5), ohlc(11.5, 12.5, 10, 10.5)},
+		},
+		"Successfully matched tweezer top": {
+			Detector: tweezerTop,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(10, 12, 9.9, 11.5), ohlc(11.5, 12.05, 10, 10.5)},
+			Trend:    indc.TrendDown,
+			Strength: 0.75,
+			Match:    true,
+		},
+		"Successfully matched tweezer bottom": {
+			Detector: tweezerBottom,
+			Context:  indc.TrendDown,
+			Bars:     []bar{ohlc(11.5, 11.6, 10, 10.5), ohlc(10.5, 11.5, 10, 11)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched kicker": {
+			Detector: kicker,
+			Bars:     []bar{ohlc(12, 12, 10, 10), ohlc(12.5, 14.5, 12.5, 14.5)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched on neck": {
+			Detector: onNeck,
+			Bars:     []bar{ohlc(12, 12.1, 9.5, 10), ohlc(9, 9.6, 8.9, 9.5)},
+			Trend:    indc.TrendDown,
+			Strength: 1,
+			Match:    true,
+		},
+		"In neck not matched below close": {
+			Detector: inNeck,
+			Bars:     []bar{ohlc(12, 12.1, 9.5, 10), ohlc(9, 9.6, 8.9, 9.5)},
+		},
+		"Successfully matched in neck": {
+			Detector: inNeck,
+			Bars:     []bar{ohlc(12, 12.1, 9.5, 10), ohlc(9, 10.1, 8.9, 10.05)},
+			Trend:    indc.TrendDown,
+			Strength: 0.875,
+			Match:    true,
+		},
+		"Successfully matched thrusting": {
+			Detector: thrusting,
+			Bars:     []bar{ohlc(12, 12.1, 9.5, 10), ohlc(9, 10.9, 8.9, 10.8)},
+			Trend:    indc.TrendDown,
+			Strength: 0.6,
+			Match:    true,
+		},
+		"Successfully matched matching low": {
+			Detector: matchingLow,
+			Context:  indc.TrendDown,
+			Bars:     []bar{ohlc(12, 12.1, 9.9, 10), ohlc(11, 11.1, 9.9, 10)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched counterattack": {
+			Detector: counterattack,
+			Bars:     []bar{ohlc(12, 12, 10, 10), ohlc(8, 10, 8, 10)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Separating lines not matched against the trend": {
+			Detector: separatingLines,
+			Context:  indc.TrendDown,
+			Bars:     []bar{ohlc(11, 11.1, 9.9, 10), ohlc(11, 13, 11, 13)},
+		},
+		"Successfully matched separating lines": {
+			Detector: separatingLines,
+			Context:  indc.TrendUp,
+			Bars:     []bar{ohlc(11, 11.1, 9.9, 10), ohlc(11, 13, 11, 13)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Morning star not matched without gap": {
+			Detector: morningStar,
+			Bars:     []bar{ohlc(12, 12, 10, 10), ohlc(10.1, 10.4, 9.6, 10.2), ohlc(10, 11.6, 10, 11.5)},
+		},
+		"Successfully matched morning star": {
+			Detector: morningStar,
+			Bars:     []bar{ohlc(12, 12, 10, 10), ohlc(9.5, 9.8, 9, 9.4), ohlc(10, 11.6, 10, 11.5)},
+			Trend:    indc.TrendUp,
+			Strength: 5.0 / 6,
+			Match:    true,
+		},
+		"Successfully matched evening star": {
+			Detector: eveningStar,
+			Bars:     []bar{ohlc(10, 12, 10, 12), ohlc(12.5, 13, 12.2, 12.6), ohlc(12, 12, 10.4, 10.5)},
+			Trend:    indc.TrendDown,
+			Strength: 5.0 / 6,
+			Match:    true,
+		},
+		"Successfully matched morning doji star": {
+			Detector: morningDojiStar,
+			Bars:     []bar{ohlc(12, 12, 10, 10), ohlc(9.5, 9.8, 9, 9.5), ohlc(10, 11.6, 10, 11.5)},
+			Trend:    indc.TrendUp,
+			Strength: 0.9375,
+			Match:    true,
+		},
+		"Successfully matched evening doji star": {
+			Detector: eveningDojiStar,
+			Bars:     []bar{ohlc(10, 12, 10, 12), ohlc(12.5, 13, 12.2, 12.5), ohlc(12, 12, 10.4, 10.5)},
+			Trend:    indc.TrendDown,
+			Strength: 0.9375,
+			Match:    true,
+		},
+		"Abandoned baby not matched with overlapping shadows": {
+			Detector: abandonedBaby,
+			Bars:     []bar{ohlc(12, 12, 10, 10), ohlc(9.5, 10.2, 9, 9.5), ohlc(10, 11.6, 10, 11.5)},
+		},
+		"Successfully matched abandoned baby": {
+			Detector: abandonedBaby,
+			Bars:     []bar{ohlc(12, 12, 10, 10), ohlc(9.5, 9.8, 9, 9.5), ohlc(10, 11.6, 10, 11.5)},
+			Trend:    indc.TrendUp,
+			Strength: 0.9375,
+			Match:    true,
+		},
+		"Three white soldiers not matched with falling close": {
+			Detector: threeWhiteSoldiers,
+			Bars:     []bar{ohlc(10, 11.1, 9.9, 11), ohlc(10.5, 12.1, 10.4, 12), ohlc(11.5, 11.9, 11.4, 11.8)},
+		},
+		"Successfully matched three white soldiers": {
+			Detector: threeWhiteSoldiers,
+			Bars:     []bar{ohlc(10, 11.1, 9.9, 11), ohlc(10.5, 12.1, 10.4, 12), ohlc(11.5, 13.1, 11.4, 13)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Successfully matched three black crows": {
+			Detector: threeBlackCrows,
+			Bars:     []bar{ohlc(13, 13.1, 11.9, 12), ohlc(12.5, 12.6, 10.9, 11), ohlc(11.5, 11.6, 9.9, 10)},
+			Trend:    indc.TrendDown,
+			Strength: 1,
+			Match:    true,
+		},
+		"Three inside not matched without confirmation": {
+			Detector: threeInside,
+			Bars:     []bar{ohlc(10, 12.2, 9.8, 12), ohlc(11.5, 11.6, 10.9, 11), ohlc(11, 11.5, 10.5, 11.4)},
+		},
+		"Successfully matched three inside": {
+			Detector: threeInside,
+			Bars:     []bar{ohlc(10, 12.2, 9.8, 12), ohlc(11.5, 11.6, 10.9, 11), ohlc(11, 11, 9.5, 9.6)},
+			Trend:    indc.TrendDown,
+			Strength: 0.875,
+			Match:    true,
+		},
+		"Successfully matched three outside": {
+			Detector: threeOutside,
+			Bars:     []bar{ohlc(11, 11.2, 9.8, 10), ohlc(9.5, 12, 9.4, 11.5), ohlc(11.5, 12.6, 11.4, 12.5)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Tasuki gap not matched with closed gap": {
+			Detector: tasukiGap,
+			Bars:     []bar{ohlc(10, 11, 9.9, 11), ohlc(11.5, 12.6, 11.2, 12.5), ohlc(12, 12.1, 10.5, 10.8)},
+		},
+		"Successfully matched tasuki gap": {
+			Detector: tasukiGap,
+			Bars:     []bar{ohlc(10, 11, 9.9, 11), ohlc(11.5, 12.6, 11.2, 12.5), ohlc(12, 12.1, 11.05, 11.1)},
+			Trend:    indc.TrendUp,
+			Strength: 0.5,
+			Match:    true,
+		},
+		"Successfully matched tri star": {
+			Detector: triStar,
+			Bars:     []bar{ohlc(10, 10.5, 9.5, 10), ohlc(9, 9.5, 8.5, 9), ohlc(10, 10.5, 9.5, 10)},
+			Trend:    indc.TrendUp,
+			Strength: 1,
+			Match:    true,
+		},
+		"Three methods not matched outside of the range": {
+			Detector: threeMethods,
+			Bars: []bar{
+				ohlc(10, 12, 10, 12),
+				ohlc(11.8, 12.5, 11.4, 11.5),
+				ohlc(11.5, 11.6, 11.1, 11.2),
+				ohlc(11.2, 11.3, 10.9, 11),
+				ohlc(11, 13, 11, 13),
+			},
+		},
+		"Successfully matched three methods": {
+			Detector: threeMethods,
+			Bars: []bar{
+				ohlc(10, 12, 10, 12),
+				ohlc(11.8, 11.9, 11.4, 11.5),
+				ohlc(11.5, 11.6, 11.1, 11.2),
+				ohlc(11.2, 11.3, 10.9, 11),
+				ohlc(11, 13, 11, 13),
+			},
+			Trend:    indc.TrendUp,
+			Strength: 0.92,
+			Match:    true,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context{
+				th:       DefaultThresholds(),
+				avgBody:  1,
+				avgRange: 2,
+				trend:    c.Context,
+			}
+
+			trend, strength, ok := c.Detector(ctx, c.Bars)
+			assert.Equal(t, c.Match, ok)
+			if !ok {
+				return
+			}
+
+			assert.Equal(t, c.Trend, trend)
+			assert.InDelta(t, c.Strength, strength, 1e-9)
+		})
+	}
+}
+
+func Test_detectors_Sizes(t *testing.T) {
+	for _, d := range _detectors {
+		assert.NoError(t, d.pattern.Validate())
+		assert.True(t, d.size > 0 && d.size <= _maxSize, d.pattern.String())
+	}
+
+	assert.Len(t, _detectors, int(ThreeMethods))
+}
+
+func Test_reverse(t *testing.T) {
+	assert.Equal(t, indc.TrendDown, reverse(indc.TrendUp))
+	assert.Equal(t, indc.TrendUp, reverse(indc.TrendDown))
+	assert.Equal(t, indc.Trend(0), reverse(0))
+}
+
+func Test_clamp(t *testing.T) {
+	assert.Equal(t, 0.0, clamp(-1))
+	assert.Equal(t, 0.5, clamp(0.5))
+	assert.Equal(t, 1.0, clamp(2))
+}
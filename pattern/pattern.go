@@ -0,0 +1,613 @@
+// Package pattern recognizes classic candlestick patterns.
+//
+// Patterns are reported at the candle that completes them, together
+// with their direction and a strength score. Bullish patterns have the
+// indc.TrendUp direction, bearish patterns have the indc.TrendDown
+// direction and neutral patterns, such as doji, have zero direction.
+//
+// Candle proportions are evaluated relative to thresholds, which can be
+// adjusted. The trend that precedes a pattern is only used to tell apart
+// patterns of the same shape, e.g. hammer and hanging man.
+package pattern
+
+import (
+	"errors"
+	"math"
+
+	"github.com/jellydator/indc"
+)
+
+var (
+	// ErrInvalidPattern is returned when pattern is invalid.
+	ErrInvalidPattern = errors.New("invalid pattern")
+
+	// ErrInvalidThreshold is returned when threshold is out of its
+	// bounds.
+	ErrInvalidThreshold = errors.New("invalid threshold")
+)
+
+// Pattern specifies a candlestick pattern.
+type Pattern int
+
+// Available patterns.
+const (
+	// Doji is a single candle with almost equal open and close prices.
+	Doji Pattern = iota + 1
+
+	// DragonflyDoji is a doji without an upper shadow.
+	DragonflyDoji
+
+	// GravestoneDoji is a doji without a lower shadow.
+	GravestoneDoji
+
+	// LongLeggedDoji is a doji with long upper and lower shadows.
+	LongLeggedDoji
+
+	// Hammer is a small body with a long lower shadow after a
+	// downtrend.
+	Hammer
+
+	// HangingMan is a small body with a long lower shadow after an
+	// uptrend.
+	HangingMan
+
+	// InvertedHammer is a small body with a long upper shadow after a
+	// downtrend.
+	InvertedHammer
+
+	// ShootingStar is a small body with a long upper shadow after an
+	// uptrend.
+	ShootingStar
+
+	// Marubozu is a long body without shadows.
+	Marubozu
+
+	// SpinningTop is a small body with shadows longer than the body.
+	SpinningTop
+
+	// BeltHold is a long body that opens at its extreme against the
+	// preceding trend.
+	BeltHold
+
+	// Engulfing is a body that engulfs the opposite body of the previous
+	// candle.
+	Engulfing
+
+	// Harami is a small body contained within the opposite long body of
+	// the previous candle.
+	Harami
+
+	// HaramiCross is a doji contained within the long body of the
+	// previous candle.
+	HaramiCross
+
+	// PiercingLine is a bullish body that opens below a long bearish
+	// body and closes above its midpoint.
+	PiercingLine
+
+	// DarkCloudCover is a bearish body that opens above a long bullish
+	// body and closes below its midpoint.
+	DarkCloudCover
+
+	// TweezerTop is a bullish and a bearish candle with equal highs.
+	TweezerTop
+
+	// TweezerBottom is a bearish and a bullish candle with equal lows.
+	TweezerBottom
+
+	// Kicker is a long body that gaps beyond the open of the opposite
+	// previous body.
+	Kicker
+
+	// OnNeck is a bullish body that opens below a long bearish body and
+	// closes at its low.
+	OnNeck
+
+	// InNeck is a bullish body that opens below a long bearish body and
+	// closes at its close.
+	InNeck
+
+	// Thrusting is a bullish body that opens below a long bearish body
+	// and closes inside of it, but below its midpoint.
+	Thrusting
+
+	// MatchingLow is two bearish bodies with equal closes.
+	MatchingLow
+
+	// Counterattack is two long opposite bodies with equal closes.
+	Counterattack
+
+	// SeparatingLines is two opposite bodies with equal opens, the
+	// second of which is long.
+	SeparatingLines
+
+	// MorningStar is a long bearish body, a small body that gaps below
+	// it and a bullish body that closes above the first midpoint.
+	MorningStar
+
+	// EveningStar is a long bullish body, a small body that gaps above
+	// it and a bearish body that closes below the first midpoint.
+	EveningStar
+
+	// MorningDojiStar is a morning star with a doji in the middle.
+	MorningDojiStar
+
+	// EveningDojiStar is an evening star with a doji in the middle.
+	EveningDojiStar
+
+	// AbandonedBaby is a doji star whose shadows gap away from both
+	// neighbouring candles.
+	AbandonedBaby
+
+	// ThreeWhiteSoldiers is three rising bullish bodies, each opening
+	// within the previous one.
+	ThreeWhiteSoldiers
+
+	// ThreeBlackCrows is three falling bearish bodies, each opening
+	// within the previous one.
+	ThreeBlackCrows
+
+	// ThreeInside is a harami confirmed by the third candle.
+	ThreeInside
+
+	// ThreeOutside is an engulfing confirmed by the third candle.
+	ThreeOutside
+
+	// TasukiGap is two bodies that gap in the same direction followed
+	// by an opposite body that does not close the gap.
+	TasukiGap
+
+	// TriStar is three doji, the middle one gapping away from the
+	// others.
+	TriStar
+
+	// ThreeMethods is a long body, three small bodies within its range
+	// and a long body of the same direction that closes beyond the
+	// first one.
+	ThreeMethods
+)
+
+// _names specifies textual representations of patterns.
+var _names = [...]string{
+	Doji:               "doji",
+	DragonflyDoji:      "dragonfly_doji",
+	GravestoneDoji:     "gravestone_doji",
+	LongLeggedDoji:     "long_legged_doji",
+	Hammer:             "hammer",
+	HangingMan:         "hanging_man",
+	InvertedHammer:     "inverted_hammer",
+	ShootingStar:       "shooting_star",
+	Marubozu:           "marubozu",
+	SpinningTop:        "spinning_top",
+	BeltHold:           "belt_hold",
+	Engulfing:          "engulfing",
+	Harami:             "harami",
+	HaramiCross:        "harami_cross",
+	PiercingLine:       "piercing_line",
+	DarkCloudCover:     "dark_cloud_cover",
+	TweezerTop:         "tweezer_top",
+	TweezerBottom:      "tweezer_bottom",
+	Kicker:             "kicker",
+	OnNeck:             "on_neck",
+	InNeck:             "in_neck",
+	Thrusting:          "thrusting",
+	MatchingLow:        "matching_low",
+	Counterattack:      "counterattack",
+	SeparatingLines:    "separating_lines",
+	MorningStar:        "morning_star",
+	EveningStar:        "evening_star",
+	MorningDojiStar:    "morning_doji_star",
+	EveningDojiStar:    "evening_doji_star",
+	AbandonedBaby:      "abandoned_baby",
+	ThreeWhiteSoldiers: "three_white_soldiers",
+	ThreeBlackCrows:    "three_black_crows",
+	ThreeInside:        "three_inside",
+	ThreeOutside:       "three_outside",
+	TasukiGap:          "tasuki_gap",
+	TriStar:            "tri_star",
+	ThreeMethods:       "three_methods",
+}
+
+// Validate checks whether the pattern is one of
+// supported patterns or not.
+func (p Pattern) Validate() error {
+	if p < Doji || p > ThreeMethods {
+		return ErrInvalidPattern
+	}
+
+	return nil
+}
+
+// MarshalText turns pattern into appropriate string
+// representation.
+func (p Pattern) MarshalText() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return []byte(_names[p]), nil
+}
+
+// UnmarshalText turns string to appropriate pattern value.
+func (p *Pattern) UnmarshalText(d []byte) error {
+	for i := Doji; i <= ThreeMethods; i++ {
+		if _names[i] == string(d) {
+			*p = i
+			return nil
+		}
+	}
+
+	return ErrInvalidPattern
+}
+
+// String returns textual representation of the pattern.
+func (p Pattern) String() string {
+	if p.Validate() != nil {
+		return "unknown"
+	}
+
+	return _names[p]
+}
+
+// Thresholds specifies candle proportions used to recognize patterns.
+type Thresholds struct {
+	// DojiBody specifies the maximum body to range ratio of doji.
+	DojiBody float64
+
+	// SmallBody specifies the maximum body to range ratio of small
+	// bodies, e.g. of spinning tops and stars.
+	SmallBody float64
+
+	// LongBody specifies the minimum ratio of a long body to the average
+	// body of the preceding candles.
+	LongBody float64
+
+	// LongShadow specifies the minimum shadow to body ratio of long
+	// shadows, e.g. of hammers.
+	LongShadow float64
+
+	// ShortShadow specifies the maximum shadow to range ratio of short
+	// shadows, e.g. of marubozu.
+	ShortShadow float64
+
+	// Equal specifies the maximum difference of equal prices, relative
+	// to the average range of the preceding candles, e.g. of tweezers.
+	Equal float64
+
+	// Period specifies how many preceding candles are used to calculate
+	// average bodies and ranges and to detect the preceding trend.
+	Period int
+}
+
+// DefaultThresholds returns commonly used thresholds.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		DojiBody:    0.1,
+		SmallBody:   0.3,
+		LongBody:    1,
+		LongShadow:  2,
+		ShortShadow: 0.1,
+		Equal:       0.05,
+		Period:      10,
+	}
+}
+
+// validate checks whether all thresholds are within their bounds.
+func (th Thresholds) validate() error {
+	for _, r := range []struct {
+		name string
+		v    float64
+		max  float64
+	}{
+		{"doji body", th.DojiBody, 1},
+		{"small body", th.SmallBody, 1},
+		{"long body", th.LongBody, math.Inf(1)},
+		{"long shadow", th.LongShadow, math.Inf(1)},
+		{"short shadow", th.ShortShadow, 1},
+		{"equal", th.Equal, math.Inf(1)},
+	} {
+		if !(r.v > 0 && r.v <= r.max) {
+			constraint := "must be greater than 0"
+			if !math.IsInf(r.max, 1) {
+				constraint += " and not greater than 1"
+			}
+
+			return &indc.ValidationError{
+				Indicator:  "pattern",
+				Param:      r.name,
+				Value:      r.v,
+				Constraint: constraint,
+				Err:        ErrInvalidThreshold,
+			}
+		}
+	}
+
+	if th.DojiBody > th.SmallBody {
+		return &indc.ValidationError{
+			Indicator:  "pattern",
+			Param:      "doji body",
+			Value:      th.DojiBody,
+			Constraint: "must not be greater than small body",
+			Err:        ErrInvalidThreshold,
+		}
+	}
+
+	if th.Period < 2 {
+		return &indc.ValidationError{
+			Indicator:  "pattern",
+			Param:      "period",
+			Value:      th.Period,
+			Constraint: "must be greater than 1",
+			Err:        indc.ErrInvalidLength,
+		}
+	}
+
+	return nil
+}
+
+// Match holds a recognized pattern.
+type Match struct {
+	// Pattern specifies the recognized pattern.
+	Pattern Pattern `json:"pattern"`
+
+	// Trend specifies the direction of the pattern. It is zero for
+	// neutral patterns.
+	Trend indc.Trend `json:"trend,omitempty"`
+
+	// Strength specifies how pronounced the pattern is, from 0 to 1.
+	// Patterns that barely satisfy the thresholds have strength of
+	// about 0.5.
+	Strength float64 `json:"strength"`
+
+	// Candles specifies how many candles the pattern spans, including
+	// the candle it is reported at.
+	Candles int `json:"candles"`
+}
+
+// Recognizer recognizes candlestick patterns.
+// The zero value is not usable.
+type Recognizer struct {
+	// valid specifies whether Recognizer paremeters were validated.
+	valid bool
+
+	// th specifies thresholds of candle proportions.
+	th Thresholds
+}
+
+// NewRecognizer validates provided thresholds and creates new
+// Recognizer.
+func NewRecognizer(th Thresholds) (Recognizer, error) {
+	r := Recognizer{th: th}
+
+	if err := r.validate(); err != nil {
+		return Recognizer{}, err
+	}
+
+	return r, nil
+}
+
+// validate checks whether the recognizer has valid configuration
+// properties.
+func (r *Recognizer) validate() error {
+	if err := r.th.validate(); err != nil {
+		return err
+	}
+
+	r.valid = true
+
+	return nil
+}
+
+// Recognize recognizes patterns at every candle. Result is aligned with
+// the candles, which are ordered from the oldest to the newest.
+func (r Recognizer) Recognize(cc []indc.Candle) ([][]Match, error) {
+	if !r.valid {
+		return nil, indc.ErrInvalidIndicator
+	}
+
+	bb := bars(cc)
+	res := make([][]Match, len(cc))
+
+	for i := range bb {
+		res[i] = r.at(bb, i)
+	}
+
+	return res, nil
+}
+
+// Last recognizes patterns completed by the newest candle.
+func (r Recognizer) Last(cc []indc.Candle) ([]Match, error) {
+	if !r.valid {
+		return nil, indc.ErrInvalidIndicator
+	}
+
+	if len(cc) == 0 {
+		return nil, nil
+	}
+
+	from := len(cc) - r.th.Period - _maxSize
+	if from < 0 {
+		from = 0
+	}
+
+	bb := bars(cc[from:])
+
+	return r.at(bb, len(bb)-1), nil
+}
+
+// at recognizes patterns completed by the ith candle.
+func (r Recognizer) at(bb []bar, i int) []Match {
+	var res []Match
+
+	for _, d := range _detectors {
+		start := i - d.size + 1
+		if start < 0 {
+			continue
+		}
+
+		ctx := r.context(bb, start, d.size)
+
+		trend, strength, ok := d.fn(ctx, bb[start:i+1])
+		if !ok {
+			continue
+		}
+
+		res = append(res, Match{
+			Pattern:  d.pattern,
+			Trend:    trend,
+			Strength: strength,
+			Candles:  d.size,
+		})
+	}
+
+	return res
+}
+
+// context calculates properties of candles that precede the pattern
+// starting at the provided index.
+func (r Recognizer) context(bb []bar, start, size int) context {
+	ctx := context{th: r.th}
+
+	from := start - r.th.Period
+	if from < 0 {
+		from = 0
+	}
+
+	prev := bb[from:start]
+	if len(prev) == 0 {
+		prev = bb[start : start+size]
+	}
+
+	for _, b := range prev {
+		ctx.avgBody += b.body()
+		ctx.avgRange += b.rng()
+	}
+
+	ctx.avgBody /= float64(len(prev))
+	ctx.avgRange /= float64(len(prev))
+
+	if start-from >= 2 {
+		first, last := bb[from].c, bb[start-1].c
+
+		switch {
+		case last > first:
+			ctx.trend = indc.TrendUp
+		case last < first:
+			ctx.trend = indc.TrendDown
+		}
+	}
+
+	return ctx
+}
+
+// bar holds candle prices as floats.
+type bar struct {
+	o, h, l, c float64
+}
+
+// bars converts candles into bars.
+func bars(cc []indc.Candle) []bar {
+	bb := make([]bar, len(cc))
+
+	for i, c := range cc {
+		bb[i].o, _ = c.Open.Float64()
+		bb[i].h, _ = c.High.Float64()
+		bb[i].l, _ = c.Low.Float64()
+		bb[i].c, _ = c.Close.Float64()
+	}
+
+	return bb
+}
+
+// body returns the size of the body.
+func (b bar) body() float64 {
+	return math.Abs(b.c - b.o)
+}
+
+// rng returns the range between the high and the low.
+func (b bar) rng() float64 {
+	return b.h - b.l
+}
+
+// top returns the top of the body.
+func (b bar) top() float64 {
+	return math.Max(b.o, b.c)
+}
+
+// bottom returns the bottom of the body.
+func (b bar) bottom() float64 {
+	return math.Min(b.o, b.c)
+}
+
+// mid returns the midpoint of the body.
+func (b bar) mid() float64 {
+	return (b.o + b.c) / 2
+}
+
+// upper returns the size of the upper shadow.
+func (b bar) upper() float64 {
+	return b.h - b.top()
+}
+
+// lower returns the size of the lower shadow.
+func (b bar) lower() float64 {
+	return b.bottom() - b.l
+}
+
+// bull returns true if the bar closes above its open.
+func (b bar) bull() bool {
+	return b.c > b.o
+}
+
+// bear returns true if the bar closes below its open.
+func (b bar) bear() bool {
+	return b.c < b.o
+}
+
+// direction returns the direction of the body.
+func (b bar) direction() indc.Trend {
+	switch {
+	case b.bull():
+		return indc.TrendUp
+	case b.bear():
+		return indc.TrendDown
+	default:
+		return 0
+	}
+}
+
+// ratio returns a / b, or positive infinity when b is zero and a is
+// not.
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		if a == 0 {
+			return 0
+		}
+
+		return math.Inf(1)
+	}
+
+	return a / b
+}
+
+// above scores value that must be at least min: reaching the minimum
+// scores 0.5 and reaching its double scores 1.
+func above(v, min float64) float64 {
+	return math.Min(1, ratio(v, 2*min))
+}
+
+// below scores value that must not exceed max: reaching the maximum
+// scores 0.5 and zero scores 1.
+func below(v, max float64) float64 {
+	return math.Max(0, 1-ratio(v, 2*max))
+}
+
+// mean returns the mean of the scores.
+func mean(ss ...float64) float64 {
+	var sum float64
+	for _, s := range ss {
+		sum += s
+	}
+
+	return sum / float64(len(ss))
+}
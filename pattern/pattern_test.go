@@ -0,0 +1,385 @@
+package pattern
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jellydator/indc"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+// ohlc is a shorthand for creating bars.
+func ohlc(o, h, l, c float64) bar {
+	return bar{o: o, h: h, l: l, c: c}
+}
+
+// candles creates candles of consecutive days from bars.
+func candles(bb ...bar) []indc.Candle {
+	cc := make([]indc.Candle, len(bb))
+	for i, b := range bb {
+		cc[i] = indc.Candle{
+			Time:  time.Date(2021, 1, i+1, 0, 0, 0, 0, time.UTC),
+			Open:  decimal.NewFromFloat(b.o),
+			High:  decimal.NewFromFloat(b.h),
+			Low:   decimal.NewFromFloat(b.l),
+			Close: decimal.NewFromFloat(b.c),
+		}
+	}
+
+	return cc
+}
+
+func Test_Pattern_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Pattern Pattern
+		Err     error
+	}{
+		"Invalid Pattern": {
+			Err: ErrInvalidPattern,
+		},
+		"Invalid Pattern after the last one": {
+			Pattern: ThreeMethods + 1,
+			Err:     ErrInvalidPattern,
+		},
+		"Successful Doji validation": {
+			Pattern: Doji,
+		},
+		"Successful ThreeMethods validation": {
+			Pattern: ThreeMethods,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Pattern.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Pattern_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Pattern Pattern
+		Text    string
+		Err     error
+	}{
+		"Invalid Pattern": {
+			Err: ErrInvalidPattern,
+		},
+		"Successful Hammer marshal": {
+			Pattern: Hammer,
+			Text:    "hammer",
+		},
+		"Successful ThreeWhiteSoldiers marshal": {
+			Pattern: ThreeWhiteSoldiers,
+			Text:    "three_white_soldiers",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Pattern.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Pattern_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Pattern
+		Err    error
+	}{
+		"Invalid Pattern": {
+			Text: "hammers",
+			Err:  ErrInvalidPattern,
+		},
+		"Successful Doji unmarshal": {
+			Text:   "doji",
+			Result: Doji,
+		},
+		"Successful ThreeMethods unmarshal": {
+			Text:   "three_methods",
+			Result: ThreeMethods,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var p Pattern
+			err := p.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, p)
+		})
+	}
+}
+
+func Test_Pattern_String(t *testing.T) {
+	assert.Equal(t, "morning_star", MorningStar.String())
+	assert.Equal(t, "unknown", Pattern(0).String())
+
+	for p := Doji; p <= ThreeMethods; p++ {
+		assert.NotEmpty(t, _names[p])
+	}
+}
+
+func Test_NewRecognizer(t *testing.T) {
+	th := func(fn func(*Thresholds)) Thresholds {
+		res := DefaultThresholds()
+		fn(&res)
+
+		return res
+	}
+
+	cc := map[string]struct {
+		Thresholds Thresholds
+		Error      error
+	}{
+		"Invalid doji body": {
+			Thresholds: th(func(th *Thresholds) { th.DojiBody = 0 }),
+			Error: &indc.ValidationError{
+				Indicator:  "pattern",
+				Param:      "doji body",
+				Value:      0.0,
+				Constraint: "must be greater than 0 and not greater than 1",
+				Err:        ErrInvalidThreshold,
+			},
+		},
+		"Invalid small body": {
+			Thresholds: th(func(th *Thresholds) { th.SmallBody = 1.5 }),
+			Error: &indc.ValidationError{
+				Indicator:  "pattern",
+				Param:      "small body",
+				Value:      1.5,
+				Constraint: "must be greater than 0 and not greater than 1",
+				Err:        ErrInvalidThreshold,
+			},
+		},
+		"Invalid long shadow": {
+			Thresholds: th(func(th *Thresholds) { th.LongShadow = -1 }),
+			Error: &indc.ValidationError{
+				Indicator:  "pattern",
+				Param:      "long shadow",
+				Value:      -1.0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidThreshold,
+			},
+		},
+		"Invalid equal": {
+			Thresholds: th(func(th *Thresholds) { th.Equal = math.Inf(-1) }),
+			Error: &indc.ValidationError{
+				Indicator:  "pattern",
+				Param:      "equal",
+				Value:      math.Inf(-1),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidThreshold,
+			},
+		},
+		"Doji body greater than small body": {
+			Thresholds: th(func(th *Thresholds) { th.DojiBody = 0.5 }),
+			Error: &indc.ValidationError{
+				Indicator:  "pattern",
+				Param:      "doji body",
+				Value:      0.5,
+				Constraint: "must not be greater than small body",
+				Err:        ErrInvalidThreshold,
+			},
+		},
+		"Invalid period": {
+			Thresholds: th(func(th *Thresholds) { th.Period = 1 }),
+			Error: &indc.ValidationError{
+				Indicator:  "pattern",
+				Param:      "period",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        indc.ErrInvalidLength,
+			},
+		},
+		"Successfully created new Recognizer": {
+			Thresholds: DefaultThresholds(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewRecognizer(c.Thresholds)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Thresholds, res.th)
+		})
+	}
+}
+
+func Test_NewRecognizer_NaN(t *testing.T) {
+	th := DefaultThresholds()
+	th.Equal = math.NaN()
+
+	_, err := NewRecognizer(th)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+}
+
+func Test_Recognizer_Recognize(t *testing.T) {
+	r, err := NewRecognizer(DefaultThresholds())
+	require.NoError(t, err)
+
+	cc := candles(
+		ohlc(14, 14.5, 13, 13.5),
+		ohlc(13.5, 14, 12.5, 13),
+		ohlc(13, 13.5, 12, 12.5),
+		ohlc(12.5, 13, 11.5, 12),
+		ohlc(12, 12.2, 9.8, 10),
+		ohlc(9.5, 12.5, 9.4, 12.4),
+	)
+
+	res, err := r.Recognize(cc)
+	require.NoError(t, err)
+	require.Len(t, res, len(cc))
+
+	assert.Empty(t, res[0])
+
+	m, ok := find(res[3], ThreeBlackCrows)
+	require.True(t, ok)
+	assert.Equal(t, indc.TrendDown, m.Trend)
+	assert.Equal(t, 3, m.Candles)
+
+	m, ok = find(res[4], Marubozu)
+	require.True(t, ok)
+	assert.Equal(t, indc.TrendDown, m.Trend)
+	assert.Equal(t, 1, m.Candles)
+	assert.InDelta(t, 13.0/18, m.Strength, 1e-9)
+
+	m, ok = find(res[5], Engulfing)
+	require.True(t, ok)
+	assert.Equal(t, indc.TrendUp, m.Trend)
+	assert.Equal(t, 2, m.Candles)
+	assert.InDelta(t, 0.725, m.Strength, 1e-9)
+
+	last, err := r.Last(cc)
+	require.NoError(t, err)
+	assert.Equal(t, res[5], last)
+
+	last, err = r.Last(nil)
+	require.NoError(t, err)
+	assert.Empty(t, last)
+}
+
+// find returns the match of the pattern, if any.
+func find(mm []Match, p Pattern) (Match, bool) {
+	for _, m := range mm {
+		if m.Pattern == p {
+			return m, true
+		}
+	}
+
+	return Match{}, false
+}
+
+func Test_Recognizer_Invalid(t *testing.T) {
+	_, err := Recognizer{}.Recognize(nil)
+	assertEqualError(t, indc.ErrInvalidIndicator, err)
+
+	_, err = Recognizer{}.Last(nil)
+	assertEqualError(t, indc.ErrInvalidIndicator, err)
+}
+
+func Test_Recognizer_context(t *testing.T) {
+	r := Recognizer{valid: true, th: DefaultThresholds()}
+	r.th.Period = 2
+
+	bb := []bar{
+		ohlc(10, 11, 9, 10),
+		ohlc(10, 12, 10, 12),
+		ohlc(12, 14, 11, 13),
+		ohlc(13, 13, 12, 12),
+	}
+
+	ctx := r.context(bb, 3, 1)
+	assert.InDelta(t, 1.5, ctx.avgBody, 1e-9)
+	assert.InDelta(t, 2.5, ctx.avgRange, 1e-9)
+	assert.Equal(t, indc.TrendUp, ctx.trend)
+
+	ctx = r.context(bb, 1, 1)
+	assert.InDelta(t, 0, ctx.avgBody, 1e-9)
+	assert.InDelta(t, 2, ctx.avgRange, 1e-9)
+	assert.Equal(t, indc.Trend(0), ctx.trend)
+
+	ctx = r.context(bb, 0, 2)
+	assert.InDelta(t, 1, ctx.avgBody, 1e-9)
+	assert.InDelta(t, 2, ctx.avgRange, 1e-9)
+}
+
+func Test_bar(t *testing.T) {
+	b := ohlc(12, 14, 9, 10)
+
+	assert.Equal(t, 2.0, b.body())
+	assert.Equal(t, 5.0, b.rng())
+	assert.Equal(t, 12.0, b.top())
+	assert.Equal(t, 10.0, b.bottom())
+	assert.Equal(t, 11.0, b.mid())
+	assert.Equal(t, 2.0, b.upper())
+	assert.Equal(t, 1.0, b.lower())
+	assert.False(t, b.bull())
+	assert.True(t, b.bear())
+	assert.Equal(t, indc.TrendDown, b.direction())
+	assert.Equal(t, indc.Trend(0), ohlc(1, 2, 0, 1).direction())
+}
+
+func Test_scores(t *testing.T) {
+	assert.Equal(t, 0.0, ratio(0, 0))
+	assert.True(t, math.IsInf(ratio(1, 0), 1))
+	assert.Equal(t, 0.5, ratio(1, 2))
+
+	assert.Equal(t, 0.5, above(2, 2))
+	assert.Equal(t, 1.0, above(5, 2))
+	assert.Equal(t, 1.0, above(1, 0))
+
+	assert.Equal(t, 1.0, below(0, 2))
+	assert.Equal(t, 0.5, below(2, 2))
+	assert.Equal(t, 0.0, below(5, 2))
+
+	assert.Equal(t, 0.5, mean(0, 1))
+}
@@ -0,0 +1,328 @@
+package indc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidInterval is returned when resampling interval is not
+	// positive.
+	ErrInvalidInterval = errors.New("invalid interval")
+
+	// ErrInvalidSession is returned when session start is not within a
+	// single day.
+	ErrInvalidSession = errors.New("invalid session")
+
+	// ErrInvalidGap is returned when gap policy is invalid.
+	ErrInvalidGap = errors.New("invalid gap policy")
+
+	// ErrUnordered is returned when data is not ordered from the oldest
+	// to the newest.
+	ErrUnordered = errors.New("data is not ordered from the oldest to the newest")
+)
+
+// _day specifies the duration of a day without daylight saving time
+// transitions.
+const _day = 24 * time.Hour
+
+// _epoch specifies the Monday from which intervals spanning whole days
+// are counted.
+var _epoch = time.Date(1970, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+// Gap specifies how intervals without any data are resampled.
+type Gap int
+
+// Available gap policies.
+const (
+	// GapSkip specifies that empty intervals are omitted.
+	GapSkip Gap = iota + 1
+
+	// GapFill specifies that empty intervals are filled with flat
+	// candles at the previous close price and with zero volume.
+	GapFill
+)
+
+// Validate checks whether the gap policy is one of
+// supported gap policies or not.
+func (g Gap) Validate() error {
+	switch g {
+	case GapSkip, GapFill:
+		return nil
+	default:
+		return ErrInvalidGap
+	}
+}
+
+// MarshalText turns gap policy into appropriate string
+// representation.
+func (g Gap) MarshalText() ([]byte, error) {
+	var v string
+
+	switch g {
+	case GapSkip:
+		v = "skip"
+	case GapFill:
+		v = "fill"
+	default:
+		return nil, ErrInvalidGap
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate gap policy value.
+func (g *Gap) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "skip", "s":
+		*g = GapSkip
+	case "fill", "f":
+		*g = GapFill
+	default:
+		return ErrInvalidGap
+	}
+
+	return nil
+}
+
+// Tick holds a single trade or price update.
+type Tick struct {
+	// Time specifies when the tick occurred.
+	Time time.Time `json:"time"`
+
+	// Price specifies the traded price.
+	Price decimal.Decimal `json:"price"`
+
+	// Volume specifies the traded volume.
+	Volume decimal.Decimal `json:"volume"`
+}
+
+// Session specifies how resampled intervals are aligned to the wall
+// clock.
+type Session struct {
+	// Location specifies the time zone in which intervals are aligned.
+	// UTC is used when it is nil.
+	Location *time.Location
+
+	// Start specifies the wall clock offset of the session start from
+	// the local midnight, e.g. 9h30m. Intervals of every day are
+	// counted from it.
+	Start time.Duration
+}
+
+// Resampler aggregates ticks or candles into candles of a higher
+// interval.
+//
+// Intervals shorter than a day are counted from the session start of
+// every day, so the last interval of a day may be shorter when the
+// interval does not divide a day evenly. Intervals that are multiples
+// of a day span whole local calendar days, regardless of daylight saving
+// time transitions, and are counted from Monday, 5 January 1970, so
+// that weekly intervals start on Mondays.
+// The zero value is not usable.
+type Resampler struct {
+	// valid specifies whether Resampler paremeters were validated.
+	valid bool
+
+	// interval specifies the duration of resampled candles.
+	interval time.Duration
+
+	// session specifies interval alignment.
+	session Session
+
+	// gap specifies how empty intervals are handled.
+	gap Gap
+}
+
+// NewResampler validates provided configuration options and creates
+// new Resampler.
+func NewResampler(interval time.Duration, session Session, gap Gap) (Resampler, error) {
+	if session.Location == nil {
+		session.Location = time.UTC
+	}
+
+	r := Resampler{
+		interval: interval,
+		session:  session,
+		gap:      gap,
+	}
+
+	if err := r.validate(); err != nil {
+		return Resampler{}, err
+	}
+
+	return r, nil
+}
+
+// validate checks whether the resampler has valid configuration
+// properties.
+func (r *Resampler) validate() error {
+	if r.interval <= 0 {
+		return &ValidationError{
+			Indicator:  "resampler",
+			Param:      "interval",
+			Value:      r.interval,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidInterval,
+		}
+	}
+
+	if r.session.Start < 0 || r.session.Start >= _day {
+		return &ValidationError{
+			Indicator:  "resampler",
+			Param:      "session start",
+			Value:      r.session.Start,
+			Constraint: "must be within a day",
+			Err:        ErrInvalidSession,
+		}
+	}
+
+	if err := r.gap.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  "resampler",
+			Param:      "gap",
+			Value:      r.gap,
+			Constraint: "must be a valid gap policy",
+			Err:        err,
+		}
+	}
+
+	r.valid = true
+
+	return nil
+}
+
+// Start returns the start of the interval that contains t.
+func (r Resampler) Start(t time.Time) time.Time {
+	lt := t.In(r.session.Location)
+
+	y, m, d := lt.Date()
+	if lt.Before(r.sessionStart(y, m, d)) {
+		d--
+	}
+
+	if r.interval%_day == 0 {
+		days := int(r.interval / _day)
+
+		n := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(_epoch) / _day)
+		n -= ((n % days) + days) % days
+
+		return r.sessionStart(1970, time.January, 5+n)
+	}
+
+	start := r.sessionStart(y, m, d)
+
+	return start.Add(lt.Sub(start) / r.interval * r.interval)
+}
+
+// End returns the end of the interval that starts at start, which is
+// also the start of the following interval.
+func (r Resampler) End(start time.Time) time.Time {
+	lt := start.In(r.session.Location)
+	y, m, d := lt.Date()
+
+	if r.interval%_day == 0 {
+		return r.sessionStart(y, m, d+int(r.interval/_day))
+	}
+
+	if lt.Before(r.sessionStart(y, m, d)) {
+		d--
+	}
+
+	end := lt.Add(r.interval)
+	if next := r.sessionStart(y, m, d+1); next.Before(end) {
+		return next
+	}
+
+	return end
+}
+
+// sessionStart returns the session start of the provided local date.
+func (r Resampler) sessionStart(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, int(r.session.Start), r.session.Location)
+}
+
+// Ticks aggregates ticks into candles. Ticks must be ordered from the
+// oldest to the newest.
+func (r Resampler) Ticks(tt []Tick) ([]Candle, error) {
+	cc := make([]Candle, len(tt))
+
+	for i, t := range tt {
+		cc[i] = Candle{
+			Time:   t.Time,
+			Open:   t.Price,
+			High:   t.Price,
+			Low:    t.Price,
+			Close:  t.Price,
+			Volume: t.Volume,
+		}
+	}
+
+	return r.Candles(cc)
+}
+
+// Candles aggregates candles into candles of the resampler interval.
+// Candles must be ordered from the oldest to the newest and their time
+// must specify the start of their period. Resampled candles are
+// timestamped with the start of their interval.
+func (r Resampler) Candles(cc []Candle) ([]Candle, error) {
+	if !r.valid {
+		return nil, ErrInvalidIndicator
+	}
+
+	var res []Candle
+
+	for i, c := range cc {
+		if i > 0 && c.Time.Before(cc[i-1].Time) {
+			return nil, ErrUnordered
+		}
+
+		start := r.Start(c.Time)
+
+		if len(res) > 0 {
+			last := &res[len(res)-1]
+
+			if last.Time.Equal(start) {
+				*last = Merge(*last, c)
+				continue
+			}
+
+			if r.gap == GapFill {
+				for t := r.End(last.Time); t.Before(start); t = r.End(t) {
+					res = append(res, flat(t, res[len(res)-1].Close))
+				}
+			}
+		}
+
+		c.Time = start
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+// Merge extends the aggregated candle a with the newer candle b of the
+// same period. High and low prices are widened, the close price is taken
+// from b and volumes are summed, while the time and the open price of a
+// are kept.
+func Merge(a, b Candle) Candle {
+	a.High = decimal.Max(a.High, b.High)
+	a.Low = decimal.Min(a.Low, b.Low)
+	a.Close = b.Close
+	a.Volume = a.Volume.Add(b.Volume)
+
+	return a
+}
+
+// flat creates a candle without any price movement and volume.
+func flat(t time.Time, price decimal.Decimal) Candle {
+	return Candle{
+		Time:  t,
+		Open:  price,
+		High:  price,
+		Low:   price,
+		Close: price,
+	}
+}
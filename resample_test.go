@@ -0,0 +1,469 @@
+package indc
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // test locations must not depend on the system database.
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Gap_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Gap Gap
+		Err error
+	}{
+		"Invalid Gap": {
+			Err: ErrInvalidGap,
+		},
+		"Successful GapSkip validation": {
+			Gap: GapSkip,
+		},
+		"Successful GapFill validation": {
+			Gap: GapFill,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Gap.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Gap_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Gap  Gap
+		Text string
+		Err  error
+	}{
+		"Invalid Gap": {
+			Err: ErrInvalidGap,
+		},
+		"Successful GapSkip marshal": {
+			Gap:  GapSkip,
+			Text: "skip",
+		},
+		"Successful GapFill marshal": {
+			Gap:  GapFill,
+			Text: "fill",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Gap.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Gap_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Gap
+		Err    error
+	}{
+		"Invalid Gap": {
+			Err: ErrInvalidGap,
+		},
+		"Successful GapSkip unmarshal (long form)": {
+			Text:   "skip",
+			Result: GapSkip,
+		},
+		"Successful GapSkip unmarshal (short form)": {
+			Text:   "s",
+			Result: GapSkip,
+		},
+		"Successful GapFill unmarshal (long form)": {
+			Text:   "fill",
+			Result: GapFill,
+		},
+		"Successful GapFill unmarshal (short form)": {
+			Text:   "f",
+			Result: GapFill,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var g Gap
+
+			err := g.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, g)
+		})
+	}
+}
+
+func Test_NewResampler(t *testing.T) {
+	cc := map[string]struct {
+		Interval time.Duration
+		Session  Session
+		Gap      Gap
+		Result   Resampler
+		Error    error
+	}{
+		"Invalid interval": {
+			Session: Session{Location: time.UTC},
+			Gap:     GapSkip,
+			Error: &ValidationError{
+				Indicator:  "resampler",
+				Param:      "interval",
+				Value:      time.Duration(0),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidInterval,
+			},
+		},
+		"Invalid negative session start": {
+			Interval: time.Hour,
+			Session:  Session{Start: -time.Minute},
+			Gap:      GapSkip,
+			Error: &ValidationError{
+				Indicator:  "resampler",
+				Param:      "session start",
+				Value:      -time.Minute,
+				Constraint: "must be within a day",
+				Err:        ErrInvalidSession,
+			},
+		},
+		"Invalid session start": {
+			Interval: time.Hour,
+			Session:  Session{Start: _day},
+			Gap:      GapSkip,
+			Error: &ValidationError{
+				Indicator:  "resampler",
+				Param:      "session start",
+				Value:      24 * time.Hour,
+				Constraint: "must be within a day",
+				Err:        ErrInvalidSession,
+			},
+		},
+		"Invalid gap": {
+			Interval: time.Hour,
+			Error: &ValidationError{
+				Indicator:  "resampler",
+				Param:      "gap",
+				Value:      Gap(0),
+				Constraint: "must be a valid gap policy",
+				Err:        ErrInvalidGap,
+			},
+		},
+		"Successfully created new Resampler": {
+			Interval: time.Hour,
+			Session:  Session{Start: time.Hour},
+			Gap:      GapFill,
+			Result: Resampler{
+				valid:    true,
+				interval: time.Hour,
+				session:  Session{Location: time.UTC, Start: time.Hour},
+				gap:      GapFill,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewResampler(c.Interval, c.Session, c.Gap)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Resampler_Start(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	cc := map[string]struct {
+		Interval time.Duration
+		Session  Session
+		Time     time.Time
+		Result   time.Time
+	}{
+		"Successfully aligned minutes": {
+			Interval: 5 * time.Minute,
+			Time:     time.Date(2021, 3, 1, 10, 7, 30, 0, time.UTC),
+			Result:   time.Date(2021, 3, 1, 10, 5, 0, 0, time.UTC),
+		},
+		"Successfully aligned hours to the session start": {
+			Interval: time.Hour,
+			Session:  Session{Location: ny, Start: 9*time.Hour + 30*time.Minute},
+			Time:     time.Date(2021, 3, 1, 10, 15, 0, 0, ny),
+			Result:   time.Date(2021, 3, 1, 9, 30, 0, 0, ny),
+		},
+		"Successfully aligned time before the session start": {
+			Interval: time.Hour,
+			Session:  Session{Location: ny, Start: 9*time.Hour + 30*time.Minute},
+			Time:     time.Date(2021, 3, 2, 9, 0, 0, 0, ny),
+			Result:   time.Date(2021, 3, 2, 8, 30, 0, 0, ny),
+		},
+		"Successfully aligned interval that does not divide a day": {
+			Interval: 7 * time.Hour,
+			Time:     time.Date(2021, 3, 1, 22, 0, 0, 0, time.UTC),
+			Result:   time.Date(2021, 3, 1, 21, 0, 0, 0, time.UTC),
+		},
+		"Successfully aligned day with daylight saving time transition": {
+			Interval: _day,
+			Session:  Session{Location: ny},
+			Time:     time.Date(2021, 3, 14, 23, 0, 0, 0, ny),
+			Result:   time.Date(2021, 3, 14, 0, 0, 0, 0, ny),
+		},
+		"Successfully aligned day with session start": {
+			Interval: _day,
+			Session:  Session{Location: ny, Start: 18 * time.Hour},
+			Time:     time.Date(2021, 3, 15, 10, 0, 0, 0, ny),
+			Result:   time.Date(2021, 3, 14, 18, 0, 0, 0, ny),
+		},
+		"Successfully aligned week": {
+			Interval: 7 * _day,
+			Time:     time.Date(2021, 1, 7, 12, 0, 0, 0, time.UTC),
+			Result:   time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"Successfully aligned week before 1970": {
+			Interval: 7 * _day,
+			Time:     time.Date(1969, 12, 31, 12, 0, 0, 0, time.UTC),
+			Result:   time.Date(1969, 12, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := NewResampler(c.Interval, c.Session, GapSkip)
+			require.NoError(t, err)
+
+			res := r.Start(c.Time)
+			assert.True(t, c.Result.Equal(res), res.String())
+		})
+	}
+}
+
+func Test_Resampler_End(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	cc := map[string]struct {
+		Interval time.Duration
+		Session  Session
+		Start    time.Time
+		Result   time.Time
+	}{
+		"Successfully returned end of hour": {
+			Interval: time.Hour,
+			Start:    time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+			Result:   time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC),
+		},
+		"Successfully returned end of the last interval of a day": {
+			Interval: 7 * time.Hour,
+			Start:    time.Date(2021, 3, 1, 21, 0, 0, 0, time.UTC),
+			Result:   time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"Successfully returned end of the last interval before the session start": {
+			Interval: 7 * time.Hour,
+			Session:  Session{Start: 2 * time.Hour},
+			Start:    time.Date(2021, 3, 2, 1, 0, 0, 0, time.UTC),
+			Result:   time.Date(2021, 3, 2, 2, 0, 0, 0, time.UTC),
+		},
+		"Successfully returned end of day with daylight saving time transition": {
+			Interval: _day,
+			Session:  Session{Location: ny},
+			Start:    time.Date(2021, 3, 14, 0, 0, 0, 0, ny),
+			Result:   time.Date(2021, 3, 15, 0, 0, 0, 0, ny),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := NewResampler(c.Interval, c.Session, GapSkip)
+			require.NoError(t, err)
+
+			res := r.End(c.Start)
+			assert.True(t, c.Result.Equal(res), res.String())
+		})
+	}
+}
+
+func Test_Resampler_Candles(t *testing.T) {
+	minute := func(m int, o, h, l, c, v string) Candle {
+		return Candle{
+			Time:   time.Date(2021, 3, 1, 10, m, 0, 0, time.UTC),
+			Open:   decimal.RequireFromString(o),
+			High:   decimal.RequireFromString(h),
+			Low:    decimal.RequireFromString(l),
+			Close:  decimal.RequireFromString(c),
+			Volume: decimal.RequireFromString(v),
+		}
+	}
+
+	candles := []Candle{
+		minute(0, "1", "2", "1", "2", "10"),
+		minute(1, "2", "4", "1.5", "3", "20"),
+		minute(5, "3", "3", "2", "2.5", "5"),
+		minute(15, "4", "5", "4", "5", "1"),
+	}
+
+	cc := map[string]struct {
+		Resampler Resampler
+		Candles   []Candle
+		Result    []Candle
+		Error     error
+	}{
+		"Invalid resampler": {
+			Error: ErrInvalidIndicator,
+		},
+		"Unordered candles": {
+			Resampler: Resampler{valid: true, interval: 5 * time.Minute, session: Session{Location: time.UTC}},
+			Candles:   []Candle{candles[1], candles[0]},
+			Error:     ErrUnordered,
+		},
+		"Successfully resampled empty candles": {
+			Resampler: Resampler{valid: true, interval: 5 * time.Minute, session: Session{Location: time.UTC}},
+		},
+		"Successfully resampled candles with skipped gaps": {
+			Resampler: Resampler{
+				valid:    true,
+				interval: 5 * time.Minute,
+				session:  Session{Location: time.UTC},
+				gap:      GapSkip,
+			},
+			Candles: candles,
+			Result: []Candle{
+				minute(0, "1", "4", "1", "3", "30"),
+				minute(5, "3", "3", "2", "2.5", "5"),
+				minute(15, "4", "5", "4", "5", "1"),
+			},
+		},
+		"Successfully resampled candles with filled gaps": {
+			Resampler: Resampler{
+				valid:    true,
+				interval: 5 * time.Minute,
+				session:  Session{Location: time.UTC},
+				gap:      GapFill,
+			},
+			Candles: candles,
+			Result: []Candle{
+				minute(0, "1", "4", "1", "3", "30"),
+				minute(5, "3", "3", "2", "2.5", "5"),
+				minute(10, "2.5", "2.5", "2.5", "2.5", "0"),
+				minute(15, "4", "5", "4", "5", "1"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Resampler.Candles(c.Candles)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			require.Len(t, res, len(c.Result))
+
+			for i := range res {
+				assert.True(t, c.Result[i].Time.Equal(res[i].Time), "time %d", i)
+				assert.Equal(t, c.Result[i].Open.String(), res[i].Open.String(), "open %d", i)
+				assert.Equal(t, c.Result[i].High.String(), res[i].High.String(), "high %d", i)
+				assert.Equal(t, c.Result[i].Low.String(), res[i].Low.String(), "low %d", i)
+				assert.Equal(t, c.Result[i].Close.String(), res[i].Close.String(), "close %d", i)
+				assert.Equal(t, c.Result[i].Volume.String(), res[i].Volume.String(), "volume %d", i)
+			}
+		})
+	}
+}
+
+func Test_Resampler_Ticks(t *testing.T) {
+	r, err := NewResampler(time.Minute, Session{}, GapSkip)
+	require.NoError(t, err)
+
+	res, err := r.Ticks([]Tick{
+		{Time: time.Date(2021, 3, 1, 10, 0, 5, 0, time.UTC), Price: decimal.NewFromInt(3), Volume: decimal.NewFromInt(1)},
+		{Time: time.Date(2021, 3, 1, 10, 0, 30, 0, time.UTC), Price: decimal.NewFromInt(5), Volume: decimal.NewFromInt(2)},
+		{Time: time.Date(2021, 3, 1, 10, 0, 55, 0, time.UTC), Price: decimal.NewFromInt(4), Volume: decimal.NewFromInt(3)},
+		{Time: time.Date(2021, 3, 1, 10, 1, 0, 0, time.UTC), Price: decimal.NewFromInt(2), Volume: decimal.NewFromInt(4)},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC), res[0].Time)
+	assert.Equal(t, "3", res[0].Open.String())
+	assert.Equal(t, "5", res[0].High.String())
+	assert.Equal(t, "3", res[0].Low.String())
+	assert.Equal(t, "4", res[0].Close.String())
+	assert.Equal(t, "6", res[0].Volume.String())
+
+	assert.Equal(t, time.Date(2021, 3, 1, 10, 1, 0, 0, time.UTC), res[1].Time)
+	assert.Equal(t, "2", res[1].Close.String())
+	assert.Equal(t, "4", res[1].Volume.String())
+}
+
+func Test_Merge(t *testing.T) {
+	tm := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	res := Merge(Candle{
+		Time:   tm,
+		Open:   decimal.NewFromInt(3),
+		High:   decimal.NewFromInt(4),
+		Low:    decimal.NewFromInt(2),
+		Close:  decimal.NewFromInt(3),
+		Volume: decimal.NewFromInt(1),
+	}, Candle{
+		Time:   tm.Add(time.Minute),
+		Open:   decimal.NewFromInt(1),
+		High:   decimal.NewFromInt(5),
+		Low:    decimal.NewFromInt(1),
+		Close:  decimal.NewFromInt(2),
+		Volume: decimal.NewFromInt(2),
+	})
+
+	assert.Equal(t, tm, res.Time)
+	assert.Equal(t, "3", res.Open.String())
+	assert.Equal(t, "5", res.High.String())
+	assert.Equal(t, "1", res.Low.String())
+	assert.Equal(t, "2", res.Close.String())
+	assert.Equal(t, "3", res.Volume.String())
+}
@@ -0,0 +1,1021 @@
+package indc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidSource is returned when source doesn't match any of the
+	// available data point sources.
+	ErrInvalidSource = errors.New("invalid source")
+
+	// ErrInvalidRiskMethod is returned when risk method doesn't match
+	// any of the available risk methods.
+	ErrInvalidRiskMethod = errors.New("invalid risk method")
+
+	// ErrInvalidConfidence is returned when confidence level is not
+	// between 0 and 1.
+	ErrInvalidConfidence = errors.New("invalid confidence")
+)
+
+// Source specifies what data points risk indicators receive.
+//
+// Risk indicators are calculated over a rolling window of length
+// returns. To calculate them over a whole series, e.g. a complete equity
+// curve, length should be set to the number of returns the series holds,
+// i.e. its size minus one for SourcePrice and its size for SourceReturn.
+type Source int
+
+// Available data point sources.
+const (
+	// SourcePrice specifies that data points are prices or values of an
+	// equity curve.
+	SourcePrice Source = iota + 1
+
+	// SourceReturn specifies that data points are simple per period
+	// returns, e.g. 0.01 for 1%.
+	SourceReturn
+)
+
+// Validate checks whether the source is one of
+// supported sources or not.
+func (s Source) Validate() error {
+	switch s {
+	case SourcePrice, SourceReturn:
+		return nil
+	default:
+		return ErrInvalidSource
+	}
+}
+
+// MarshalText turns source into appropriate string
+// representation.
+func (s Source) MarshalText() ([]byte, error) {
+	var v string
+
+	switch s {
+	case SourcePrice:
+		v = "price"
+	case SourceReturn:
+		v = "return"
+	default:
+		return nil, ErrInvalidSource
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate source value.
+func (s *Source) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "price", "p":
+		*s = SourcePrice
+	case "return", "r":
+		*s = SourceReturn
+	default:
+		return ErrInvalidSource
+	}
+
+	return nil
+}
+
+// RiskMethod specifies how value-at-risk and expected shortfall are
+// estimated.
+type RiskMethod int
+
+// Available risk methods.
+const (
+	// RiskHistorical specifies estimation from the empirical
+	// distribution of returns.
+	RiskHistorical RiskMethod = iota + 1
+
+	// RiskParametric specifies estimation from the normal distribution
+	// with the mean and the standard deviation of returns.
+	RiskParametric
+)
+
+// Validate checks whether the risk method is one of
+// supported risk methods or not.
+func (rm RiskMethod) Validate() error {
+	switch rm {
+	case RiskHistorical, RiskParametric:
+		return nil
+	default:
+		return ErrInvalidRiskMethod
+	}
+}
+
+// MarshalText turns risk method into appropriate string
+// representation.
+func (rm RiskMethod) MarshalText() ([]byte, error) {
+	var v string
+
+	switch rm {
+	case RiskHistorical:
+		v = "historical"
+	case RiskParametric:
+		v = "parametric"
+	default:
+		return nil, ErrInvalidRiskMethod
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate risk method value.
+func (rm *RiskMethod) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "historical", "h":
+		*rm = RiskHistorical
+	case "parametric", "p":
+		*rm = RiskParametric
+	default:
+		return ErrInvalidRiskMethod
+	}
+
+	return nil
+}
+
+// returns holds configuration properties that are shared by all risk
+// indicators.
+type returns struct {
+	// source specifies what data points are received.
+	source Source
+
+	// length specifies how many returns should be used during the
+	// calculations.
+	length int
+}
+
+// validate checks whether the source is valid and the length is not
+// lower than min.
+func (r returns) validate(name string, min int) error {
+	if err := r.source.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  name,
+			Param:      "source",
+			Value:      r.source,
+			Constraint: "must be price or return",
+			Err:        err,
+		}
+	}
+
+	if r.length < min {
+		return &ValidationError{
+			Indicator:  name,
+			Param:      "length",
+			Value:      r.length,
+			Constraint: fmt.Sprintf("must be greater than %d", min-1),
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	return nil
+}
+
+// count determines the total amount of data points needed for length
+// returns.
+func (r returns) count() int {
+	if r.source == SourcePrice {
+		return r.length + 1
+	}
+
+	return r.length
+}
+
+// returns converts the data points into returns. False is returned
+// when a price is zero.
+func (r returns) returns(c config, dd []decimal.Decimal) ([]decimal.Decimal, bool) {
+	if r.source == SourceReturn {
+		return dd, true
+	}
+
+	rr := make([]decimal.Decimal, len(dd)-1)
+
+	for i := 1; i < len(dd); i++ {
+		if dd[i-1].IsZero() {
+			return nil, false
+		}
+
+		rr[i-1] = c.div(dd[i], dd[i-1]).Sub(_one)
+	}
+
+	return rr, true
+}
+
+// curve converts the data points into an equity curve. Returns are
+// compounded starting from 1.
+func (r returns) curve(dd []decimal.Decimal) []decimal.Decimal {
+	if r.source == SourcePrice {
+		return dd
+	}
+
+	res := make([]decimal.Decimal, len(dd)+1)
+	res[0] = _one
+
+	for i, d := range dd {
+		res[i+1] = res[i].Mul(_one.Add(d))
+	}
+
+	return res
+}
+
+// drawdowns calculates the drawdown of every value of the curve from the
+// highest preceding value, as a non-positive fraction.
+func drawdowns(c config, curve []decimal.Decimal) []decimal.Decimal {
+	res := make([]decimal.Decimal, len(curve))
+	peak := curve[0]
+
+	for i, v := range curve {
+		if v.GreaterThan(peak) {
+			peak = v
+		}
+
+		if peak.IsPositive() {
+			res[i] = c.div(v.Sub(peak), peak)
+		}
+	}
+
+	return res
+}
+
+// Sharpe holds all the necessary information needed to calculate
+// annualized Sharpe ratio.
+// The zero value is not usable.
+type Sharpe struct {
+	// valid specifies whether Sharpe paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+
+	// periods specifies how many periods there are in a year.
+	periods int
+
+	// riskFree specifies annual risk-free rate.
+	riskFree decimal.Decimal
+}
+
+// NewSharpe validates provided configuration options and
+// creates new Sharpe indicator. Periods specifies how many periods
+// there are in a year, e.g. PeriodsTradingDays, and risk-free specifies
+// the annual risk-free rate, e.g. 0.02 for 2%.
+func NewSharpe(source Source, length, periods int, riskFree decimal.Decimal, opts ...Option) (Sharpe, error) {
+	s := Sharpe{
+		cfg:      newConfig(opts),
+		in:       returns{source: source, length: length},
+		periods:  periods,
+		riskFree: riskFree,
+	}
+
+	if err := s.validate(); err != nil {
+		return Sharpe{}, err
+	}
+
+	return s, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (s *Sharpe) validate() error {
+	if err := s.cfg.validate("sharpe"); err != nil {
+		return err
+	}
+
+	if err := s.in.validate("sharpe", 2); err != nil {
+		return err
+	}
+
+	if err := validatePeriods("sharpe", s.periods); err != nil {
+		return err
+	}
+
+	s.valid = true
+
+	return nil
+}
+
+// Calc calculates Sharpe ratio from the provided data points slice:
+// the mean excess return divided by the sample standard deviation of
+// returns, annualized with the square root of periods.
+func (s Sharpe) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !s.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(s.cfg, dd, s.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rr, ok := s.in.returns(s.cfg, dd)
+	if !ok {
+		return s.cfg.divByZero()
+	}
+
+	sd := sqrt(s.cfg.svar(rr))
+	if sd.IsZero() {
+		return s.cfg.divByZero()
+	}
+
+	excess := s.cfg.avg(rr).Sub(perPeriod(s.cfg, s.riskFree, s.periods))
+
+	return s.cfg.keep(s.cfg.round(s.cfg.div(excess, sd).Mul(annualize(s.periods))))
+}
+
+// Count determines the total amount of data points needed for Sharpe
+// calculation.
+func (s Sharpe) Count() int {
+	return s.in.count()
+}
+
+// Sortino holds all the necessary information needed to calculate
+// annualized Sortino ratio.
+// The zero value is not usable.
+type Sortino struct {
+	// valid specifies whether Sortino paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+
+	// periods specifies how many periods there are in a year.
+	periods int
+
+	// riskFree specifies annual risk-free rate, which is also used as
+	// the minimum acceptable return.
+	riskFree decimal.Decimal
+}
+
+// NewSortino validates provided configuration options and
+// creates new Sortino indicator. See NewSharpe for details.
+func NewSortino(source Source, length, periods int, riskFree decimal.Decimal, opts ...Option) (Sortino, error) {
+	s := Sortino{
+		cfg:      newConfig(opts),
+		in:       returns{source: source, length: length},
+		periods:  periods,
+		riskFree: riskFree,
+	}
+
+	if err := s.validate(); err != nil {
+		return Sortino{}, err
+	}
+
+	return s, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (s *Sortino) validate() error {
+	if err := s.cfg.validate("sortino"); err != nil {
+		return err
+	}
+
+	if err := s.in.validate("sortino", 2); err != nil {
+		return err
+	}
+
+	if err := validatePeriods("sortino", s.periods); err != nil {
+		return err
+	}
+
+	s.valid = true
+
+	return nil
+}
+
+// Calc calculates Sortino ratio from the provided data points slice:
+// the mean excess return divided by the downside deviation of returns
+// below the risk-free rate, annualized with the square root of periods.
+func (s Sortino) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !s.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(s.cfg, dd, s.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rr, ok := s.in.returns(s.cfg, dd)
+	if !ok {
+		return s.cfg.divByZero()
+	}
+
+	rf := perPeriod(s.cfg, s.riskFree, s.periods)
+	sum := decimal.Zero
+
+	for _, r := range rr {
+		if d := r.Sub(rf); d.IsNegative() {
+			sum = sum.Add(d.Mul(d))
+		}
+	}
+
+	dev := sqrt(s.cfg.div(sum, decimal.NewFromInt(int64(len(rr)))))
+	if dev.IsZero() {
+		return s.cfg.divByZero()
+	}
+
+	excess := s.cfg.avg(rr).Sub(rf)
+
+	return s.cfg.keep(s.cfg.round(s.cfg.div(excess, dev).Mul(annualize(s.periods))))
+}
+
+// Count determines the total amount of data points needed for Sortino
+// calculation.
+func (s Sortino) Count() int {
+	return s.in.count()
+}
+
+// Calmar holds all the necessary information needed to calculate Calmar
+// ratio.
+// The zero value is not usable.
+type Calmar struct {
+	// valid specifies whether Calmar paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+
+	// periods specifies how many periods there are in a year.
+	periods int
+}
+
+// NewCalmar validates provided configuration options and
+// creates new Calmar indicator. Periods specifies how many periods
+// there are in a year, e.g. PeriodsTradingDays.
+func NewCalmar(source Source, length, periods int, opts ...Option) (Calmar, error) {
+	c := Calmar{
+		cfg:     newConfig(opts),
+		in:      returns{source: source, length: length},
+		periods: periods,
+	}
+
+	if err := c.validate(); err != nil {
+		return Calmar{}, err
+	}
+
+	return c, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (c *Calmar) validate() error {
+	if err := c.cfg.validate("calmar"); err != nil {
+		return err
+	}
+
+	if err := c.in.validate("calmar", 1); err != nil {
+		return err
+	}
+
+	if err := validatePeriods("calmar", c.periods); err != nil {
+		return err
+	}
+
+	c.valid = true
+
+	return nil
+}
+
+// Calc calculates Calmar ratio from the provided data points slice: the
+// compound annual growth rate divided by the maximum drawdown.
+func (c Calmar) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !c.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(c.cfg, dd, c.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	curve := c.in.curve(dd)
+
+	first, last := curve[0], curve[len(curve)-1]
+	if first.IsZero() {
+		return c.cfg.divByZero()
+	}
+
+	mdd := decimal.Min(decimal.Zero, drawdowns(c.cfg, curve)...).Neg()
+	if mdd.IsZero() {
+		return c.cfg.divByZero()
+	}
+
+	growth, _ := c.cfg.div(last, first).Float64()
+
+	cagr := -1.0
+	if growth > 0 {
+		cagr = math.Pow(growth, float64(c.periods)/float64(c.in.length)) - 1
+	}
+
+	return c.cfg.keep(c.cfg.round(c.cfg.div(decimal.NewFromFloat(cagr), mdd)))
+}
+
+// Count determines the total amount of data points needed for Calmar
+// calculation.
+func (c Calmar) Count() int {
+	return c.in.count()
+}
+
+// MaxDrawdown holds all the necessary information needed to calculate
+// maximum drawdown.
+// The zero value is not usable.
+type MaxDrawdown struct {
+	// valid specifies whether MaxDrawdown paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+}
+
+// NewMaxDrawdown validates provided configuration options and
+// creates new MaxDrawdown indicator.
+func NewMaxDrawdown(source Source, length int, opts ...Option) (MaxDrawdown, error) {
+	md := MaxDrawdown{
+		cfg: newConfig(opts),
+		in:  returns{source: source, length: length},
+	}
+
+	if err := md.validate(); err != nil {
+		return MaxDrawdown{}, err
+	}
+
+	return md, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (md *MaxDrawdown) validate() error {
+	if err := md.cfg.validate("mdd"); err != nil {
+		return err
+	}
+
+	if err := md.in.validate("mdd", 1); err != nil {
+		return err
+	}
+
+	md.valid = true
+
+	return nil
+}
+
+// Calc calculates the largest peak to trough decline in percent from
+// the provided data points slice.
+func (md MaxDrawdown) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !md.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(md.cfg, dd, md.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	res := decimal.Min(decimal.Zero, drawdowns(md.cfg, md.in.curve(dd))...)
+
+	return md.cfg.round(res.Neg().Mul(_hundred)), nil
+}
+
+// Count determines the total amount of data points needed for
+// MaxDrawdown calculation.
+func (md MaxDrawdown) Count() int {
+	return md.in.count()
+}
+
+// DrawdownDuration holds all the necessary information needed to
+// calculate the longest drawdown duration.
+// The zero value is not usable.
+type DrawdownDuration struct {
+	// valid specifies whether DrawdownDuration paremeters were
+	// validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+}
+
+// NewDrawdownDuration validates provided configuration options and
+// creates new DrawdownDuration indicator.
+func NewDrawdownDuration(source Source, length int, opts ...Option) (DrawdownDuration, error) {
+	ddur := DrawdownDuration{
+		cfg: newConfig(opts),
+		in:  returns{source: source, length: length},
+	}
+
+	if err := ddur.validate(); err != nil {
+		return DrawdownDuration{}, err
+	}
+
+	return ddur, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (ddur *DrawdownDuration) validate() error {
+	if err := ddur.cfg.validate("ddur"); err != nil {
+		return err
+	}
+
+	if err := ddur.in.validate("ddur", 1); err != nil {
+		return err
+	}
+
+	ddur.valid = true
+
+	return nil
+}
+
+// Calc calculates the longest number of consecutive periods the values
+// stay below their preceding peak from the provided data points slice.
+// A drawdown that has not recovered yet is counted as well.
+func (ddur DrawdownDuration) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !ddur.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(ddur.cfg, dd, ddur.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	var cur, res int64
+
+	for _, d := range drawdowns(ddur.cfg, ddur.in.curve(dd)) {
+		if !d.IsNegative() {
+			cur = 0
+			continue
+		}
+
+		cur++
+
+		if cur > res {
+			res = cur
+		}
+	}
+
+	return decimal.NewFromInt(res), nil
+}
+
+// Count determines the total amount of data points needed for
+// DrawdownDuration calculation.
+func (ddur DrawdownDuration) Count() int {
+	return ddur.in.count()
+}
+
+// Ulcer holds all the necessary information needed to calculate Ulcer
+// index.
+// The zero value is not usable.
+type Ulcer struct {
+	// valid specifies whether Ulcer paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+}
+
+// NewUlcer validates provided configuration options and
+// creates new Ulcer indicator.
+func NewUlcer(source Source, length int, opts ...Option) (Ulcer, error) {
+	u := Ulcer{
+		cfg: newConfig(opts),
+		in:  returns{source: source, length: length},
+	}
+
+	if err := u.validate(); err != nil {
+		return Ulcer{}, err
+	}
+
+	return u, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (u *Ulcer) validate() error {
+	if err := u.cfg.validate("ulcer"); err != nil {
+		return err
+	}
+
+	if err := u.in.validate("ulcer", 1); err != nil {
+		return err
+	}
+
+	u.valid = true
+
+	return nil
+}
+
+// Calc calculates Ulcer index from the provided data points slice: the
+// root mean square of percentage drawdowns from the preceding peak.
+// Calculation is based on formula provided by investopedia.
+// https://www.investopedia.com/terms/u/ulcerindex.asp.
+func (u Ulcer) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !u.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(u.cfg, dd, u.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	ss := drawdowns(u.cfg, u.in.curve(dd))
+
+	for i, s := range ss {
+		s = s.Mul(_hundred)
+		ss[i] = s.Mul(s)
+	}
+
+	return u.cfg.round(sqrt(u.cfg.avg(ss))), nil
+}
+
+// Count determines the total amount of data points needed for Ulcer
+// calculation.
+func (u Ulcer) Count() int {
+	return u.in.count()
+}
+
+// VaR holds all the necessary information needed to calculate
+// value-at-risk.
+// The zero value is not usable.
+type VaR struct {
+	// valid specifies whether VaR paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+
+	// method specifies how the value is estimated.
+	method RiskMethod
+
+	// confidence specifies the confidence level.
+	confidence decimal.Decimal
+}
+
+// NewVaR validates provided configuration options and
+// creates new VaR indicator. Confidence specifies the confidence level,
+// e.g. 0.95.
+func NewVaR(source Source, method RiskMethod, confidence decimal.Decimal, length int, opts ...Option) (VaR, error) {
+	v := VaR{
+		cfg:        newConfig(opts),
+		in:         returns{source: source, length: length},
+		method:     method,
+		confidence: confidence,
+	}
+
+	if err := v.validate(); err != nil {
+		return VaR{}, err
+	}
+
+	return v, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (v *VaR) validate() error {
+	if err := validateTail("var", v.cfg, v.in, v.method, v.confidence); err != nil {
+		return err
+	}
+
+	v.valid = true
+
+	return nil
+}
+
+// Calc calculates value-at-risk in percent from the provided data
+// points slice: the loss of a single period that is not exceeded with
+// the confidence level. Historical method uses the return that has the
+// tail fraction of returns at or below it; parametric method uses the
+// quantile of the normal distribution.
+func (v VaR) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !v.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(v.cfg, dd, v.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rr, ok := v.in.returns(v.cfg, dd)
+	if !ok {
+		return v.cfg.divByZero()
+	}
+
+	alpha := tail(v.confidence)
+
+	var res decimal.Decimal
+
+	switch v.method {
+	case RiskHistorical:
+		rr = worst(rr, v.confidence)
+		res = rr[len(rr)-1]
+	case RiskParametric:
+		res = v.cfg.avg(rr).Add(sqrt(v.cfg.svar(rr)).Mul(decimal.NewFromFloat(quantile(alpha))))
+	}
+
+	return v.cfg.keep(v.cfg.round(res.Neg().Mul(_hundred)))
+}
+
+// Count determines the total amount of data points needed for VaR
+// calculation.
+func (v VaR) Count() int {
+	return v.in.count()
+}
+
+// ExpectedShortfall holds all the necessary information needed to
+// calculate expected shortfall, also known as conditional value-at-risk.
+// The zero value is not usable.
+type ExpectedShortfall struct {
+	// valid specifies whether ExpectedShortfall paremeters were
+	// validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// in specifies data point source and length.
+	in returns
+
+	// method specifies how the value is estimated.
+	method RiskMethod
+
+	// confidence specifies the confidence level.
+	confidence decimal.Decimal
+}
+
+// NewExpectedShortfall validates provided configuration options and
+// creates new ExpectedShortfall indicator. See NewVaR for details.
+func NewExpectedShortfall(source Source, method RiskMethod, confidence decimal.Decimal, length int, opts ...Option) (ExpectedShortfall, error) {
+	es := ExpectedShortfall{
+		cfg:        newConfig(opts),
+		in:         returns{source: source, length: length},
+		method:     method,
+		confidence: confidence,
+	}
+
+	if err := es.validate(); err != nil {
+		return ExpectedShortfall{}, err
+	}
+
+	return es, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (es *ExpectedShortfall) validate() error {
+	if err := validateTail("es", es.cfg, es.in, es.method, es.confidence); err != nil {
+		return err
+	}
+
+	es.valid = true
+
+	return nil
+}
+
+// Calc calculates expected shortfall in percent from the provided data
+// points slice: the average loss of a single period in the tail beyond
+// the value-at-risk.
+func (es ExpectedShortfall) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !es.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	dd, err := window(es.cfg, dd, es.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rr, ok := es.in.returns(es.cfg, dd)
+	if !ok {
+		return es.cfg.divByZero()
+	}
+
+	alpha := tail(es.confidence)
+
+	var res decimal.Decimal
+
+	switch es.method {
+	case RiskHistorical:
+		res = es.cfg.avg(worst(rr, es.confidence))
+	case RiskParametric:
+		z := quantile(alpha)
+		pdf := math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+		res = es.cfg.avg(rr).Sub(sqrt(es.cfg.svar(rr)).Mul(decimal.NewFromFloat(pdf / alpha)))
+	}
+
+	return es.cfg.keep(es.cfg.round(res.Neg().Mul(_hundred)))
+}
+
+// Count determines the total amount of data points needed for
+// ExpectedShortfall calculation.
+func (es ExpectedShortfall) Count() int {
+	return es.in.count()
+}
+
+// validatePeriods checks whether the number of periods per year is
+// positive.
+func validatePeriods(name string, periods int) error {
+	if periods < 1 {
+		return &ValidationError{
+			Indicator:  name,
+			Param:      "periods",
+			Value:      periods,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidFactor,
+		}
+	}
+
+	return nil
+}
+
+// validateTail checks configuration properties of tail risk indicators.
+func validateTail(name string, c config, in returns, method RiskMethod, confidence decimal.Decimal) error {
+	if err := c.validate(name); err != nil {
+		return err
+	}
+
+	if err := in.validate(name, 2); err != nil {
+		return err
+	}
+
+	if err := method.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  name,
+			Param:      "method",
+			Value:      method,
+			Constraint: "must be historical or parametric",
+			Err:        err,
+		}
+	}
+
+	if !confidence.IsPositive() || !confidence.LessThan(_one) {
+		return &ValidationError{
+			Indicator:  name,
+			Param:      "confidence",
+			Value:      confidence,
+			Constraint: "must be greater than 0 and less than 1",
+			Err:        ErrInvalidConfidence,
+		}
+	}
+
+	return nil
+}
+
+// perPeriod converts the annual rate into the rate of a single period.
+func perPeriod(c config, rate decimal.Decimal, periods int) decimal.Decimal {
+	return c.div(rate, decimal.NewFromInt(int64(periods)))
+}
+
+// annualize returns the square root of periods.
+func annualize(periods int) decimal.Decimal {
+	return sqrt(decimal.NewFromInt(int64(periods)))
+}
+
+// tail returns the probability of the tail beyond the confidence level.
+func tail(confidence decimal.Decimal) float64 {
+	f, _ := _one.Sub(confidence).Float64()
+	return f
+}
+
+// worst returns the lowest returns that make up the tail fraction
+// beyond the confidence level of all returns, but at least one, sorted
+// from the lowest. The size of the tail is calculated with decimals, so
+// that it is not inflated by floating point errors.
+func worst(rr []decimal.Decimal, confidence decimal.Decimal) []decimal.Decimal {
+	res := append([]decimal.Decimal(nil), rr...)
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].LessThan(res[j])
+	})
+
+	n := int(_one.Sub(confidence).Mul(decimal.NewFromInt(int64(len(res)))).Ceil().IntPart())
+	if n < 1 {
+		n = 1
+	}
+
+	return res[:n]
+}
+
+// quantile returns the quantile of the standard normal distribution.
+func quantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
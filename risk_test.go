@@ -0,0 +1,1192 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prices returns an equity curve used by risk tests. Its returns are
+// 10%, -10%, 6.06% and 14.29%.
+func prices() []decimal.Decimal {
+	return ratios("100", "110", "99", "105", "120")
+}
+
+func Test_Source_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Source Source
+		Err    error
+	}{
+		"Invalid Source": {
+			Err: ErrInvalidSource,
+		},
+		"Successful SourcePrice validation": {
+			Source: SourcePrice,
+		},
+		"Successful SourceReturn validation": {
+			Source: SourceReturn,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Source.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Source_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Source Source
+		Text   string
+		Err    error
+	}{
+		"Invalid Source": {
+			Err: ErrInvalidSource,
+		},
+		"Successful SourcePrice marshal": {
+			Source: SourcePrice,
+			Text:   "price",
+		},
+		"Successful SourceReturn marshal": {
+			Source: SourceReturn,
+			Text:   "return",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Source.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Source_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Source
+		Err    error
+	}{
+		"Invalid Source": {
+			Err: ErrInvalidSource,
+		},
+		"Successful SourcePrice unmarshal (long form)": {
+			Text:   "price",
+			Result: SourcePrice,
+		},
+		"Successful SourcePrice unmarshal (short form)": {
+			Text:   "p",
+			Result: SourcePrice,
+		},
+		"Successful SourceReturn unmarshal (long form)": {
+			Text:   "return",
+			Result: SourceReturn,
+		},
+		"Successful SourceReturn unmarshal (short form)": {
+			Text:   "r",
+			Result: SourceReturn,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var s Source
+			err := s.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, s)
+		})
+	}
+}
+
+func Test_RiskMethod_Validate(t *testing.T) {
+	cc := map[string]struct {
+		RiskMethod RiskMethod
+		Err        error
+	}{
+		"Invalid RiskMethod": {
+			Err: ErrInvalidRiskMethod,
+		},
+		"Successful RiskHistorical validation": {
+			RiskMethod: RiskHistorical,
+		},
+		"Successful RiskParametric validation": {
+			RiskMethod: RiskParametric,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.RiskMethod.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_RiskMethod_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		RiskMethod RiskMethod
+		Text       string
+		Err        error
+	}{
+		"Invalid RiskMethod": {
+			Err: ErrInvalidRiskMethod,
+		},
+		"Successful RiskHistorical marshal": {
+			RiskMethod: RiskHistorical,
+			Text:       "historical",
+		},
+		"Successful RiskParametric marshal": {
+			RiskMethod: RiskParametric,
+			Text:       "parametric",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.RiskMethod.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_RiskMethod_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result RiskMethod
+		Err    error
+	}{
+		"Invalid RiskMethod": {
+			Err: ErrInvalidRiskMethod,
+		},
+		"Successful RiskHistorical unmarshal (long form)": {
+			Text:   "historical",
+			Result: RiskHistorical,
+		},
+		"Successful RiskHistorical unmarshal (short form)": {
+			Text:   "h",
+			Result: RiskHistorical,
+		},
+		"Successful RiskParametric unmarshal (long form)": {
+			Text:   "parametric",
+			Result: RiskParametric,
+		},
+		"Successful RiskParametric unmarshal (short form)": {
+			Text:   "p",
+			Result: RiskParametric,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var rm RiskMethod
+			err := rm.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, rm)
+		})
+	}
+}
+
+func Test_returns(t *testing.T) {
+	in := returns{source: SourcePrice, length: 2}
+	assert.Equal(t, 3, in.count())
+
+	rr, ok := in.returns(config{}, ratios("100", "110", "99"))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"0.1", "-0.1"}, []string{rr[0].String(), rr[1].String()})
+
+	_, ok = in.returns(config{}, ratios("0", "110", "99"))
+	assert.False(t, ok)
+
+	in = returns{source: SourceReturn, length: 2}
+	assert.Equal(t, 2, in.count())
+
+	curve := in.curve(ratios("0.1", "-0.1"))
+	assert.Equal(t, []string{"1", "1.1", "0.99"}, []string{curve[0].String(), curve[1].String(), curve[2].String()})
+
+	res := drawdowns(config{}, curve)
+	assert.Equal(t, []string{"0", "0", "-0.1"}, []string{res[0].String(), res[1].String(), res[2].String()})
+}
+
+func Test_NewSharpe(t *testing.T) {
+	cc := map[string]struct {
+		Source  Source
+		Length  int
+		Periods int
+		Options []Option
+		Error   error
+	}{
+		"Invalid config": {
+			Source:  SourcePrice,
+			Length:  2,
+			Periods: PeriodsTradingDays,
+			Options: []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "sharpe",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid source": {
+			Length:  2,
+			Periods: PeriodsTradingDays,
+			Error: &ValidationError{
+				Indicator:  "sharpe",
+				Param:      "source",
+				Value:      Source(0),
+				Constraint: "must be price or return",
+				Err:        ErrInvalidSource,
+			},
+		},
+		"Invalid length": {
+			Source:  SourceReturn,
+			Length:  1,
+			Periods: PeriodsTradingDays,
+			Error: &ValidationError{
+				Indicator:  "sharpe",
+				Param:      "length",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Invalid periods": {
+			Source: SourceReturn,
+			Length: 2,
+			Error: &ValidationError{
+				Indicator:  "sharpe",
+				Param:      "periods",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidFactor,
+			},
+		},
+		"Successfully created new Sharpe": {
+			Source:  SourcePrice,
+			Length:  2,
+			Periods: PeriodsTradingDays,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewSharpe(c.Source, c.Length, c.Periods, decimal.NewFromFloat(0.02), c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, returns{source: c.Source, length: c.Length}, res.in)
+			assert.Equal(t, c.Periods, res.periods)
+			assert.Equal(t, "0.02", res.riskFree.String())
+		})
+	}
+}
+
+func Test_Sharpe_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Sharpe Sharpe
+		Data   []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Sharpe: Sharpe{valid: true, in: returns{source: SourcePrice, length: 4}, periods: 252},
+			Data:   prices()[1:],
+			Error:  &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Division by zero of zero price": {
+			Sharpe: Sharpe{
+				valid:   true,
+				cfg:     config{zero: ZeroPolicyError},
+				in:      returns{source: SourcePrice, length: 2},
+				periods: 252,
+			},
+			Data:  ratios("0", "1", "2"),
+			Error: ErrDivisionByZero,
+		},
+		"Division by zero of constant returns": {
+			Sharpe: Sharpe{
+				valid:   true,
+				cfg:     config{zero: ZeroPolicyError},
+				in:      returns{source: SourceReturn, length: 2},
+				periods: 252,
+			},
+			Data:  ratios("0.01", "0.01"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated Sharpe ratio of prices": {
+			Sharpe: Sharpe{
+				valid:    true,
+				in:       returns{source: SourcePrice, length: 4},
+				periods:  252,
+				riskFree: decimal.NewFromFloat(0.02),
+			},
+			Data:   prices(),
+			Result: 7.6030523217155,
+		},
+		"Successfully calculated Sharpe ratio of returns with lenient window": {
+			Sharpe: Sharpe{
+				valid:   true,
+				cfg:     config{lenient: true},
+				in:      returns{source: SourceReturn, length: 3},
+				periods: 12,
+			},
+			Data:   ratios("0.3", "0.1", "-0.1", "0.05"),
+			Result: 0.554700196225229,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Sharpe.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Sharpe_Count(t *testing.T) {
+	assert.Equal(t, 5, Sharpe{in: returns{source: SourcePrice, length: 4}}.Count())
+	assert.Equal(t, 4, Sharpe{in: returns{source: SourceReturn, length: 4}}.Count())
+}
+
+func Test_NewSortino(t *testing.T) {
+	_, err := NewSortino(SourcePrice, 2, PeriodsTradingDays, decimal.Zero, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "sortino",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewSortino(SourcePrice, 1, PeriodsTradingDays, decimal.Zero)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "sortino",
+		Param:      "length",
+		Value:      1,
+		Constraint: "must be greater than 1",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	_, err = NewSortino(SourcePrice, 2, 0, decimal.Zero)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "sortino",
+		Param:      "periods",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidFactor,
+	}, err)
+
+	res, err := NewSortino(SourcePrice, 2, PeriodsTradingDays, decimal.Zero)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+}
+
+func Test_Sortino_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Sortino Sortino
+		Data    []decimal.Decimal
+		Result  float64
+		Error   error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Sortino: Sortino{valid: true, in: returns{source: SourcePrice, length: 4}, periods: 252},
+			Data:    prices()[1:],
+			Error:   &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Division by zero of returns without losses": {
+			Sortino: Sortino{
+				valid:   true,
+				cfg:     config{zero: ZeroPolicyError},
+				in:      returns{source: SourceReturn, length: 2},
+				periods: 252,
+			},
+			Data:  ratios("0.01", "0.02"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated Sortino ratio": {
+			Sortino: Sortino{
+				valid:    true,
+				in:       returns{source: SourcePrice, length: 4},
+				periods:  252,
+				riskFree: decimal.NewFromFloat(0.02),
+			},
+			Data:   prices(),
+			Result: 16.111406657270845,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Sortino.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Sortino_Count(t *testing.T) {
+	assert.Equal(t, 5, Sortino{in: returns{source: SourcePrice, length: 4}}.Count())
+}
+
+func Test_NewCalmar(t *testing.T) {
+	_, err := NewCalmar(SourcePrice, 1, PeriodsTradingDays, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "calmar",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewCalmar(SourcePrice, 0, PeriodsTradingDays)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "calmar",
+		Param:      "length",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	_, err = NewCalmar(SourcePrice, 1, 0)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "calmar",
+		Param:      "periods",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidFactor,
+	}, err)
+
+	res, err := NewCalmar(SourcePrice, 1, PeriodsTradingDays)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+}
+
+func Test_Calmar_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Calmar Calmar
+		Data   []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Calmar: Calmar{valid: true, in: returns{source: SourcePrice, length: 4}, periods: 4},
+			Data:   prices()[1:],
+			Error:  &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Division by zero of zero price": {
+			Calmar: Calmar{
+				valid:   true,
+				cfg:     config{zero: ZeroPolicyError},
+				in:      returns{source: SourcePrice, length: 1},
+				periods: 4,
+			},
+			Data:  ratios("0", "1"),
+			Error: ErrDivisionByZero,
+		},
+		"Division by zero without drawdown": {
+			Calmar: Calmar{
+				valid:   true,
+				cfg:     config{zero: ZeroPolicyError},
+				in:      returns{source: SourcePrice, length: 1},
+				periods: 4,
+			},
+			Data:  ratios("1", "2"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated Calmar ratio of prices": {
+			Calmar: Calmar{valid: true, in: returns{source: SourcePrice, length: 4}, periods: 4},
+			Data:   prices(),
+			Result: 2,
+		},
+		"Successfully calculated Calmar ratio of returns": {
+			Calmar: Calmar{valid: true, in: returns{source: SourceReturn, length: 3}, periods: 12},
+			Data:   ratios("0.1", "-0.1", "0.05"),
+			Result: 1.6761045388006295,
+		},
+		"Successfully calculated Calmar ratio of total loss": {
+			Calmar: Calmar{valid: true, in: returns{source: SourceReturn, length: 1}, periods: 12},
+			Data:   ratios("-1"),
+			Result: -1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Calmar.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Calmar_Count(t *testing.T) {
+	assert.Equal(t, 4, Calmar{in: returns{source: SourceReturn, length: 4}}.Count())
+}
+
+func Test_NewMaxDrawdown(t *testing.T) {
+	_, err := NewMaxDrawdown(SourcePrice, 1, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "mdd",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewMaxDrawdown(Source(0), 1)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "mdd",
+		Param:      "source",
+		Value:      Source(0),
+		Constraint: "must be price or return",
+		Err:        ErrInvalidSource,
+	}, err)
+
+	_, err = NewMaxDrawdown(SourcePrice, 0)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "mdd",
+		Param:      "length",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	res, err := NewMaxDrawdown(SourcePrice, 1)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+}
+
+func Test_MaxDrawdown_Calc(t *testing.T) {
+	cc := map[string]struct {
+		MaxDrawdown MaxDrawdown
+		Data        []decimal.Decimal
+		Result      string
+		Error       error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			MaxDrawdown: MaxDrawdown{valid: true, in: returns{source: SourcePrice, length: 4}},
+			Data:        prices()[1:],
+			Error:       &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Successfully calculated maximum drawdown of prices": {
+			MaxDrawdown: MaxDrawdown{valid: true, in: returns{source: SourcePrice, length: 4}},
+			Data:        prices(),
+			Result:      "10",
+		},
+		"Successfully calculated maximum drawdown of returns": {
+			MaxDrawdown: MaxDrawdown{valid: true, in: returns{source: SourceReturn, length: 3}},
+			Data:        ratios("0.1", "-0.1", "0.05"),
+			Result:      "10",
+		},
+		"Successfully calculated maximum drawdown of rising prices": {
+			MaxDrawdown: MaxDrawdown{valid: true, in: returns{source: SourcePrice, length: 2}},
+			Data:        ratios("1", "2", "3"),
+			Result:      "0",
+		},
+		"Successfully calculated maximum drawdown with lenient window": {
+			MaxDrawdown: MaxDrawdown{
+				valid: true,
+				cfg:   config{lenient: true},
+				in:    returns{source: SourcePrice, length: 2},
+			},
+			Data:   prices(),
+			Result: "0",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.MaxDrawdown.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res.String())
+		})
+	}
+}
+
+func Test_MaxDrawdown_Count(t *testing.T) {
+	assert.Equal(t, 5, MaxDrawdown{in: returns{source: SourcePrice, length: 4}}.Count())
+}
+
+func Test_NewDrawdownDuration(t *testing.T) {
+	_, err := NewDrawdownDuration(SourcePrice, 1, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "ddur",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewDrawdownDuration(SourcePrice, 0)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "ddur",
+		Param:      "length",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	res, err := NewDrawdownDuration(SourceReturn, 1)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+}
+
+func Test_DrawdownDuration_Calc(t *testing.T) {
+	cc := map[string]struct {
+		DrawdownDuration DrawdownDuration
+		Data             []decimal.Decimal
+		Result           string
+		Error            error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			DrawdownDuration: DrawdownDuration{valid: true, in: returns{source: SourcePrice, length: 4}},
+			Data:             prices()[1:],
+			Error:            &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Successfully calculated recovered drawdown duration": {
+			DrawdownDuration: DrawdownDuration{valid: true, in: returns{source: SourcePrice, length: 4}},
+			Data:             prices(),
+			Result:           "2",
+		},
+		"Successfully calculated ongoing drawdown duration": {
+			DrawdownDuration: DrawdownDuration{valid: true, in: returns{source: SourceReturn, length: 5}},
+			Data:             ratios("-0.1", "0.2", "-0.1", "-0.1", "0.05"),
+			Result:           "3",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.DrawdownDuration.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res.String())
+		})
+	}
+}
+
+func Test_DrawdownDuration_Count(t *testing.T) {
+	assert.Equal(t, 4, DrawdownDuration{in: returns{source: SourceReturn, length: 4}}.Count())
+}
+
+func Test_NewUlcer(t *testing.T) {
+	_, err := NewUlcer(SourcePrice, 1, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "ulcer",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewUlcer(SourcePrice, 0)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "ulcer",
+		Param:      "length",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidLength,
+	}, err)
+
+	res, err := NewUlcer(SourcePrice, 1)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+}
+
+func Test_Ulcer_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Ulcer  Ulcer
+		Data   []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Ulcer: Ulcer{valid: true, in: returns{source: SourcePrice, length: 4}},
+			Data:  prices()[1:],
+			Error: &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Successfully calculated Ulcer index of prices": {
+			Ulcer:  Ulcer{valid: true, in: returns{source: SourcePrice, length: 4}},
+			Data:   prices(),
+			Result: 4.912456758584108,
+		},
+		"Successfully calculated Ulcer index of returns": {
+			Ulcer:  Ulcer{valid: true, in: returns{source: SourceReturn, length: 3}},
+			Data:   ratios("0.1", "-0.1", "0.05"),
+			Result: 5.706356105256663,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Ulcer.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Ulcer_Count(t *testing.T) {
+	assert.Equal(t, 5, Ulcer{in: returns{source: SourcePrice, length: 4}}.Count())
+}
+
+func Test_NewVaR(t *testing.T) {
+	cc := map[string]struct {
+		Source     Source
+		Method     RiskMethod
+		Confidence decimal.Decimal
+		Length     int
+		Options    []Option
+		Error      error
+	}{
+		"Invalid config": {
+			Source:     SourcePrice,
+			Method:     RiskHistorical,
+			Confidence: decimal.NewFromFloat(0.95),
+			Length:     2,
+			Options:    []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "var",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid length": {
+			Source:     SourcePrice,
+			Method:     RiskHistorical,
+			Confidence: decimal.NewFromFloat(0.95),
+			Length:     1,
+			Error: &ValidationError{
+				Indicator:  "var",
+				Param:      "length",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Invalid method": {
+			Source:     SourcePrice,
+			Confidence: decimal.NewFromFloat(0.95),
+			Length:     2,
+			Error: &ValidationError{
+				Indicator:  "var",
+				Param:      "method",
+				Value:      RiskMethod(0),
+				Constraint: "must be historical or parametric",
+				Err:        ErrInvalidRiskMethod,
+			},
+		},
+		"Invalid confidence": {
+			Source:     SourcePrice,
+			Method:     RiskHistorical,
+			Confidence: decimal.NewFromInt(0),
+			Length:     2,
+			Error: &ValidationError{
+				Indicator:  "var",
+				Param:      "confidence",
+				Value:      decimal.NewFromInt(0),
+				Constraint: "must be greater than 0 and less than 1",
+				Err:        ErrInvalidConfidence,
+			},
+		},
+		"Invalid confidence of 1": {
+			Source:     SourcePrice,
+			Method:     RiskHistorical,
+			Confidence: decimal.NewFromInt(1),
+			Length:     2,
+			Error: &ValidationError{
+				Indicator:  "var",
+				Param:      "confidence",
+				Value:      decimal.NewFromInt(1),
+				Constraint: "must be greater than 0 and less than 1",
+				Err:        ErrInvalidConfidence,
+			},
+		},
+		"Successfully created new VaR": {
+			Source:     SourceReturn,
+			Method:     RiskParametric,
+			Confidence: decimal.NewFromFloat(0.99),
+			Length:     2,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewVaR(c.Source, c.Method, c.Confidence, c.Length, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, returns{source: c.Source, length: c.Length}, res.in)
+			assert.Equal(t, c.Method, res.method)
+			assert.Equal(t, c.Confidence.String(), res.confidence.String())
+		})
+	}
+}
+
+func Test_VaR_Calc(t *testing.T) {
+	cc := map[string]struct {
+		VaR    VaR
+		Data   []decimal.Decimal
+		Result float64
+		Error  error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			VaR: VaR{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:  prices()[1:],
+			Error: &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Division by zero of zero price": {
+			VaR: VaR{
+				valid:      true,
+				cfg:        config{zero: ZeroPolicyError},
+				in:         returns{source: SourcePrice, length: 2},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:  ratios("0", "1", "2"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated historical VaR": {
+			VaR: VaR{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:   prices(),
+			Result: 10,
+		},
+		"Successfully calculated historical VaR of a wider tail": {
+			VaR: VaR{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.5),
+			},
+			Data:   prices(),
+			Result: -6.060606060606055,
+		},
+		"Successfully calculated parametric VaR": {
+			VaR: VaR{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskParametric,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:   prices(),
+			Result: 2.065549596086365,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.VaR.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_VaR_Count(t *testing.T) {
+	assert.Equal(t, 5, VaR{in: returns{source: SourcePrice, length: 4}}.Count())
+}
+
+func Test_NewExpectedShortfall(t *testing.T) {
+	_, err := NewExpectedShortfall(SourcePrice, RiskHistorical, decimal.NewFromFloat(0.95), 2, WithPrecision(2, 70))
+	assertEqualError(t, &ValidationError{
+		Indicator:  "es",
+		Param:      "rounding",
+		Value:      RoundingMode(70),
+		Constraint: "must be a valid rounding mode",
+		Err:        ErrInvalidRounding,
+	}, err)
+
+	_, err = NewExpectedShortfall(SourcePrice, RiskMethod(0), decimal.NewFromFloat(0.95), 2)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "es",
+		Param:      "method",
+		Value:      RiskMethod(0),
+		Constraint: "must be historical or parametric",
+		Err:        ErrInvalidRiskMethod,
+	}, err)
+
+	_, err = NewExpectedShortfall(SourcePrice, RiskHistorical, decimal.NewFromFloat(1.5), 2)
+	assertEqualError(t, &ValidationError{
+		Indicator:  "es",
+		Param:      "confidence",
+		Value:      decimal.NewFromFloat(1.5),
+		Constraint: "must be greater than 0 and less than 1",
+		Err:        ErrInvalidConfidence,
+	}, err)
+
+	res, err := NewExpectedShortfall(SourcePrice, RiskHistorical, decimal.NewFromFloat(0.95), 2)
+	assert.NoError(t, err)
+	assert.True(t, res.valid)
+}
+
+func Test_ExpectedShortfall_Calc(t *testing.T) {
+	cc := map[string]struct {
+		ExpectedShortfall ExpectedShortfall
+		Data              []decimal.Decimal
+		Result            float64
+		Error             error
+	}{
+		"Invalid indicator": {
+			Data:  prices(),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			ExpectedShortfall: ExpectedShortfall{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:  prices()[1:],
+			Error: &DataSizeError{Expected: 5, Actual: 4},
+		},
+		"Division by zero of zero price": {
+			ExpectedShortfall: ExpectedShortfall{
+				valid:      true,
+				cfg:        config{zero: ZeroPolicyError},
+				in:         returns{source: SourcePrice, length: 2},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:  ratios("0", "1", "2"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated historical expected shortfall": {
+			ExpectedShortfall: ExpectedShortfall{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:   prices(),
+			Result: 10,
+		},
+		"Successfully calculated historical expected shortfall of a wider tail": {
+			ExpectedShortfall: ExpectedShortfall{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskHistorical,
+				confidence: decimal.NewFromFloat(0.5),
+			},
+			Data:   prices(),
+			Result: 1.9696969696969726,
+		},
+		"Successfully calculated parametric expected shortfall": {
+			ExpectedShortfall: ExpectedShortfall{
+				valid:      true,
+				in:         returns{source: SourcePrice, length: 4},
+				method:     RiskParametric,
+				confidence: decimal.NewFromFloat(0.75),
+			},
+			Data:   prices(),
+			Result: 8.391930193460743,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.ExpectedShortfall.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_ExpectedShortfall_Count(t *testing.T) {
+	assert.Equal(t, 5, ExpectedShortfall{in: returns{source: SourcePrice, length: 4}}.Count())
+}
+
+func Test_worst(t *testing.T) {
+	rr := make([]decimal.Decimal, 30)
+	for i := range rr {
+		rr[i] = decimal.New(int64(-i-1), -2)
+	}
+
+	assert.Equal(t, []decimal.Decimal{rr[29], rr[28], rr[27]}, worst(rr, decimal.RequireFromString("0.9")))
+	assert.Equal(t, []decimal.Decimal{rr[29]}, worst(rr, decimal.RequireFromString("0.99")))
+
+	v, err := NewVaR(SourceReturn, RiskHistorical, decimal.RequireFromString("0.9"), 30)
+	require.NoError(t, err)
+
+	res, err := v.Calc(rr)
+	require.NoError(t, err)
+	assert.Equal(t, "28", res.String())
+
+	es, err := NewExpectedShortfall(SourceReturn, RiskHistorical, decimal.RequireFromString("0.9"), 30)
+	require.NoError(t, err)
+
+	res, err = es.Calc(rr)
+	require.NoError(t, err)
+	assert.Equal(t, "29", res.String())
+}
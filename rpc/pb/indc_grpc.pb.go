@@ -0,0 +1,226 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: indc.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	IndicatorService_Validate_FullMethodName = "/indc.v1.IndicatorService/Validate"
+	IndicatorService_Calc_FullMethodName     = "/indc.v1.IndicatorService/Calc"
+	IndicatorService_Stream_FullMethodName   = "/indc.v1.IndicatorService/Stream"
+)
+
+// IndicatorServiceClient is the client API for IndicatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IndicatorServiceClient interface {
+	// Validate validates an indicator config.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Calc calculates indicator values at the newest candle.
+	Calc(ctx context.Context, in *CalcRequest, opts ...grpc.CallOption) (*CalcResponse, error)
+	// Stream calculates indicator values every time a tick is pushed.
+	// The first request must hold the configs, every following request
+	// must hold a tick.
+	Stream(ctx context.Context, opts ...grpc.CallOption) (IndicatorService_StreamClient, error)
+}
+
+type indicatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIndicatorServiceClient(cc grpc.ClientConnInterface) IndicatorServiceClient {
+	return &indicatorServiceClient{cc}
+}
+
+func (c *indicatorServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, IndicatorService_Validate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indicatorServiceClient) Calc(ctx context.Context, in *CalcRequest, opts ...grpc.CallOption) (*CalcResponse, error) {
+	out := new(CalcResponse)
+	err := c.cc.Invoke(ctx, IndicatorService_Calc_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indicatorServiceClient) Stream(ctx context.Context, opts ...grpc.CallOption) (IndicatorService_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &IndicatorService_ServiceDesc.Streams[0], IndicatorService_Stream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &indicatorServiceStreamClient{stream}
+	return x, nil
+}
+
+type IndicatorService_StreamClient interface {
+	Send(*StreamRequest) error
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type indicatorServiceStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *indicatorServiceStreamClient) Send(m *StreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *indicatorServiceStreamClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IndicatorServiceServer is the server API for IndicatorService service.
+// All implementations must embed UnimplementedIndicatorServiceServer
+// for forward compatibility
+type IndicatorServiceServer interface {
+	// Validate validates an indicator config.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Calc calculates indicator values at the newest candle.
+	Calc(context.Context, *CalcRequest) (*CalcResponse, error)
+	// Stream calculates indicator values every time a tick is pushed.
+	// The first request must hold the configs, every following request
+	// must hold a tick.
+	Stream(IndicatorService_StreamServer) error
+	mustEmbedUnimplementedIndicatorServiceServer()
+}
+
+// UnimplementedIndicatorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedIndicatorServiceServer struct {
+}
+
+func (UnimplementedIndicatorServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedIndicatorServiceServer) Calc(context.Context, *CalcRequest) (*CalcResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calc not implemented")
+}
+func (UnimplementedIndicatorServiceServer) Stream(IndicatorService_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedIndicatorServiceServer) mustEmbedUnimplementedIndicatorServiceServer() {}
+
+// UnsafeIndicatorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IndicatorServiceServer will
+// result in compilation errors.
+type UnsafeIndicatorServiceServer interface {
+	mustEmbedUnimplementedIndicatorServiceServer()
+}
+
+func RegisterIndicatorServiceServer(s grpc.ServiceRegistrar, srv IndicatorServiceServer) {
+	s.RegisterService(&IndicatorService_ServiceDesc, srv)
+}
+
+func _IndicatorService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndicatorServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndicatorService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndicatorServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndicatorService_Calc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndicatorServiceServer).Calc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndicatorService_Calc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndicatorServiceServer).Calc(ctx, req.(*CalcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndicatorService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(IndicatorServiceServer).Stream(&indicatorServiceStreamServer{stream})
+}
+
+type IndicatorService_StreamServer interface {
+	Send(*StreamResponse) error
+	Recv() (*StreamRequest, error)
+	grpc.ServerStream
+}
+
+type indicatorServiceStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *indicatorServiceStreamServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *indicatorServiceStreamServer) Recv() (*StreamRequest, error) {
+	m := new(StreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IndicatorService_ServiceDesc is the grpc.ServiceDesc for IndicatorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IndicatorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "indc.v1.IndicatorService",
+	HandlerType: (*IndicatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Validate",
+			Handler:    _IndicatorService_Validate_Handler,
+		},
+		{
+			MethodName: "Calc",
+			Handler:    _IndicatorService_Calc_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _IndicatorService_Stream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "indc.proto",
+}
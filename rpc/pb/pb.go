@@ -0,0 +1,5 @@
+// Package pb holds protobuf messages and gRPC service definitions of the
+// indicator API.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative indc.proto
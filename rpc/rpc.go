@@ -0,0 +1,285 @@
+// Package rpc provides a gRPC service that exposes indicator
+// calculations. Messages and service definitions are generated into the
+// pb package from indc.proto.
+//
+// Besides unary calculations, the service supports bidirectional
+// streams: clients push ticks and receive indicator values recalculated
+// at the newest candle after every tick. Each stream keeps its own
+// Window, which holds only as many candles as its indicators need and
+// recalculates values from them.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jellydator/indc"
+	"github.com/jellydator/indc/expr"
+	"github.com/jellydator/indc/rpc/pb"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// _requestErrs holds errors caused by invalid requests rather than by
+// the service itself.
+var _requestErrs = []error{
+	indc.ErrInvalidLength,
+	indc.ErrInvalidDataSize,
+	indc.ErrInvalidTrend,
+	indc.ErrInvalidBand,
+	indc.ErrInvalidMA,
+	indc.ErrInvalidRounding,
+	indc.ErrInvalidFactor,
+	indc.ErrInvalidZeroPolicy,
+	indc.ErrUnknownIndicator,
+	indc.ErrInvalidArgument,
+	indc.ErrInvalidCandle,
+	indc.ErrDivisionByZero,
+	expr.ErrInvalidSyntax,
+	expr.ErrTypeMismatch,
+	ErrMissingExprs,
+	ErrOutOfOrderTick,
+	ErrMissingTickTime,
+}
+
+// Service implements pb.IndicatorServiceServer.
+// The zero value is ready to use.
+type Service struct {
+	pb.UnimplementedIndicatorServiceServer
+}
+
+// Validate validates the config and returns its details.
+func (s *Service) Validate(_ context.Context, req *pb.ValidateRequest) (*pb.ValidateResponse, error) {
+	e, err := parseConfig(req.GetConfig())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	t, err := e.Type().MarshalText()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ValidateResponse{
+		Spec:  e.String(),
+		Type:  string(t),
+		Count: int64(e.Count()),
+	}, nil
+}
+
+// Calc calculates values of all configs at the newest candle.
+func (s *Service) Calc(_ context.Context, req *pb.CalcRequest) (*pb.CalcResponse, error) {
+	ee, err := parseConfigs(req.GetConfigs())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	cc := make([]indc.Candle, len(req.GetCandles()))
+	for i, c := range req.GetCandles() {
+		cc[i], err = candle(c)
+		if err != nil {
+			return nil, toStatus(fmt.Errorf("candle %d: %w", i, err))
+		}
+	}
+
+	res := &pb.CalcResponse{Values: make([]*pb.Value, len(ee))}
+
+	for i, e := range ee {
+		v, err := eval(e, cc)
+		if err != nil {
+			return nil, toStatus(fmt.Errorf("config %d: %w", i, err))
+		}
+
+		res.Values[i] = value(v)
+	}
+
+	return res, nil
+}
+
+// Stream reads configs from the first request and responds with
+// recalculated values to every following tick. Values that do not have
+// enough candles yet are left unset and calculation errors are reported
+// per value, so that the stream is not interrupted by them.
+func (s *Service) Stream(stream pb.IndicatorService_StreamServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return eof(err)
+	}
+
+	if req.GetConfig() == nil {
+		return status.Error(codes.InvalidArgument, "first request must hold configs")
+	}
+
+	ee, err := parseConfigs(req.GetConfig().GetConfigs())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	w, err := NewWindow(ee...)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return eof(err)
+		}
+
+		if req.GetTick() == nil {
+			return status.Error(codes.InvalidArgument, "requests after the first one must hold ticks")
+		}
+
+		c, err := candle(req.GetTick().GetCandle())
+		if err != nil {
+			return toStatus(err)
+		}
+
+		if err := w.Push(c); err != nil {
+			return toStatus(err)
+		}
+
+		if err := stream.Send(streamResponse(c, w.Results())); err != nil {
+			return err
+		}
+	}
+}
+
+// streamResponse creates stream response of the results calculated at
+// the candle.
+func streamResponse(c indc.Candle, rr []Result) *pb.StreamResponse {
+	res := &pb.StreamResponse{
+		Values: make([]*pb.Value, len(rr)),
+	}
+
+	if !c.Time.IsZero() {
+		res.Time = timestamppb.New(c.Time)
+	}
+
+	for i, r := range rr {
+		var derr *indc.DataSizeError
+
+		switch {
+		case errors.As(r.Err, &derr):
+			res.Values[i] = &pb.Value{}
+			res.Warmup = true
+		case r.Err != nil:
+			res.Values[i] = &pb.Value{Kind: &pb.Value_Error{Error: r.Err.Error()}}
+		default:
+			res.Values[i] = value(r.Value)
+		}
+	}
+
+	return res
+}
+
+// parseConfigs parses all configs.
+func parseConfigs(cc []*pb.IndicatorConfig) ([]expr.Expr, error) {
+	if len(cc) == 0 {
+		return nil, ErrMissingExprs
+	}
+
+	ee := make([]expr.Expr, len(cc))
+
+	for i, c := range cc {
+		e, err := parseConfig(c)
+		if err != nil {
+			return nil, fmt.Errorf("config %d: %w", i, err)
+		}
+
+		ee[i] = e
+	}
+
+	return ee, nil
+}
+
+// parseConfig parses the spec of the config.
+func parseConfig(c *pb.IndicatorConfig) (expr.Expr, error) {
+	var opts []indc.Option
+	if c.GetLenient() {
+		opts = append(opts, indc.WithLenient())
+	}
+
+	return expr.Parse(c.GetSpec(), opts...)
+}
+
+// candle converts protobuf candle. Missing open, high and low prices
+// default to the close price and missing volume defaults to zero.
+func candle(c *pb.Candle) (indc.Candle, error) {
+	if strings.TrimSpace(c.GetClose()) == "" {
+		return indc.Candle{}, fmt.Errorf("%w: missing close", indc.ErrInvalidCandle)
+	}
+
+	var (
+		res indc.Candle
+		err error
+	)
+
+	if c.GetTime() != nil {
+		res.Time = c.GetTime().AsTime()
+	}
+
+	for _, f := range []struct {
+		name string
+		src  string
+		dst  *decimal.Decimal
+	}{
+		{"close", c.GetClose(), &res.Close},
+		{"open", c.GetOpen(), &res.Open},
+		{"high", c.GetHigh(), &res.High},
+		{"low", c.GetLow(), &res.Low},
+		{"volume", c.GetVolume(), &res.Volume},
+	} {
+		src := strings.TrimSpace(f.src)
+
+		switch {
+		case src != "":
+			*f.dst, err = decimal.NewFromString(src)
+			if err != nil {
+				return indc.Candle{}, fmt.Errorf("%w: %s: %v", indc.ErrInvalidCandle, f.name, err)
+			}
+		case f.name != "volume":
+			*f.dst = res.Close
+		}
+	}
+
+	return res, nil
+}
+
+// value converts calculated decimal or boolean value.
+func value(v interface{}) *pb.Value {
+	switch v := v.(type) {
+	case bool:
+		return &pb.Value{Kind: &pb.Value_Bool{Bool: v}}
+	case decimal.Decimal:
+		return &pb.Value{Kind: &pb.Value_Number{Number: v.String()}}
+	default:
+		return &pb.Value{}
+	}
+}
+
+// eof turns the end of the client stream into a successful completion.
+func eof(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+// toStatus converts the error into status error. Errors caused by
+// invalid requests are reported as invalid arguments.
+func toStatus(err error) error {
+	for _, rerr := range _requestErrs {
+		if errors.Is(err, rerr) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
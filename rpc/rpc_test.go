@@ -0,0 +1,335 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jellydator/indc"
+	"github.com/jellydator/indc/rpc/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newClient starts the service on an in-memory listener and returns a
+// client connected to it.
+func newClient(t *testing.T) pb.IndicatorServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	srv := grpc.NewServer()
+	pb.RegisterIndicatorServiceServer(srv, &Service{})
+
+	go func() {
+		// Serve returns after Stop is called.
+		_ = srv.Serve(lis)
+	}()
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return pb.NewIndicatorServiceClient(conn)
+}
+
+func config(spec string) *pb.IndicatorConfig {
+	return &pb.IndicatorConfig{Spec: spec}
+}
+
+func number(v string) *pb.Value {
+	return &pb.Value{Kind: &pb.Value_Number{Number: v}}
+}
+
+func boolean(v bool) *pb.Value {
+	return &pb.Value{Kind: &pb.Value_Bool{Bool: v}}
+}
+
+func pbTick(day int, price string) *pb.StreamRequest {
+	return &pb.StreamRequest{Kind: &pb.StreamRequest_Tick{Tick: &pb.Tick{
+		Candle: &pb.Candle{
+			Time:  timestamppb.New(time.Date(2021, 1, day, 0, 0, 0, 0, time.UTC)),
+			Close: price,
+		},
+	}}}
+}
+
+func Test_Service_Validate(t *testing.T) {
+	client := newClient(t)
+
+	cc := map[string]struct {
+		Config *pb.IndicatorConfig
+		Result *pb.ValidateResponse
+		Code   codes.Code
+	}{
+		"Invalid syntax": {
+			Config: config("sma(2"),
+			Code:   codes.InvalidArgument,
+		},
+		"Invalid length": {
+			Config: config("sma(0)"),
+			Code:   codes.InvalidArgument,
+		},
+		"Successfully validated config": {
+			Config: config("rsi(14) < 30"),
+			Result: &pb.ValidateResponse{
+				Spec:  "(rsi(14) < 30)",
+				Type:  "bool",
+				Count: 14,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := client.Validate(context.Background(), &pb.ValidateRequest{Config: c.Config})
+			assert.Equal(t, c.Code, status.Code(err))
+			if err != nil {
+				return
+			}
+
+			assert.True(t, proto.Equal(c.Result, res), res.String())
+		})
+	}
+}
+
+func Test_Service_Calc(t *testing.T) {
+	client := newClient(t)
+
+	candles := []*pb.Candle{
+		{Close: "1"},
+		{Close: "3", High: "4", Low: "2"},
+	}
+
+	cc := map[string]struct {
+		Configs []*pb.IndicatorConfig
+		Candles []*pb.Candle
+		Result  []*pb.Value
+		Code    codes.Code
+	}{
+		"Missing configs": {
+			Candles: candles,
+			Code:    codes.InvalidArgument,
+		},
+		"Unknown indicator": {
+			Configs: []*pb.IndicatorConfig{config("foo(1)")},
+			Candles: candles,
+			Code:    codes.InvalidArgument,
+		},
+		"Missing close": {
+			Configs: []*pb.IndicatorConfig{config("close")},
+			Candles: []*pb.Candle{{Open: "1"}},
+			Code:    codes.InvalidArgument,
+		},
+		"Invalid price": {
+			Configs: []*pb.IndicatorConfig{config("close")},
+			Candles: []*pb.Candle{{Close: "1", High: "x"}},
+			Code:    codes.InvalidArgument,
+		},
+		"Invalid data size": {
+			Configs: []*pb.IndicatorConfig{config("sma(3)")},
+			Candles: candles,
+			Code:    codes.InvalidArgument,
+		},
+		"Successfully calculated values": {
+			Configs: []*pb.IndicatorConfig{
+				config("sma(2)"),
+				config("high - low"),
+				config("cross_up(close, 2)"),
+				{Spec: "sma(1)", Lenient: true},
+			},
+			Candles: candles,
+			Result: []*pb.Value{
+				number("2"),
+				number("2"),
+				boolean(true),
+				number("3"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := client.Calc(context.Background(), &pb.CalcRequest{
+				Configs: c.Configs,
+				Candles: c.Candles,
+			})
+			assert.Equal(t, c.Code, status.Code(err))
+			if err != nil {
+				return
+			}
+
+			assert.True(t, proto.Equal(&pb.CalcResponse{Values: c.Result}, res), res.String())
+		})
+	}
+}
+
+func Test_Service_Stream(t *testing.T) {
+	client := newClient(t)
+
+	configs := &pb.StreamRequest{Kind: &pb.StreamRequest_Config{Config: &pb.StreamConfig{
+		Configs: []*pb.IndicatorConfig{
+			config("sma(2)"),
+			config("1 / (close - 3)"),
+		},
+	}}}
+
+	type step struct {
+		Request  *pb.StreamRequest
+		Response *pb.StreamResponse
+	}
+
+	cc := map[string]struct {
+		Steps []step
+		Code  codes.Code
+	}{
+		"Missing configs": {
+			Steps: []step{{Request: pbTick(1, "1")}},
+			Code:  codes.InvalidArgument,
+		},
+		"Invalid configs": {
+			Steps: []step{{Request: &pb.StreamRequest{Kind: &pb.StreamRequest_Config{
+				Config: &pb.StreamConfig{},
+			}}}},
+			Code: codes.InvalidArgument,
+		},
+		"Repeated configs": {
+			Steps: []step{{Request: configs}, {Request: configs}},
+			Code:  codes.InvalidArgument,
+		},
+		"Invalid tick": {
+			Steps: []step{{Request: configs}, {Request: pbTick(1, "")}},
+			Code:  codes.InvalidArgument,
+		},
+		"Missing tick time": {
+			Steps: []step{
+				{Request: configs},
+				{Request: &pb.StreamRequest{Kind: &pb.StreamRequest_Tick{Tick: &pb.Tick{
+					Candle: &pb.Candle{Close: "1"},
+				}}}},
+			},
+			Code: codes.InvalidArgument,
+		},
+		"Out of order tick": {
+			Steps: []step{
+				{Request: configs},
+				{
+					Request: pbTick(2, "1"),
+					Response: &pb.StreamResponse{
+						Time:   timestamppb.New(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+						Values: []*pb.Value{{}, number("-0.5")},
+						Warmup: true,
+					},
+				},
+				{Request: pbTick(1, "1")},
+			},
+			Code: codes.InvalidArgument,
+		},
+		"Successfully streamed values": {
+			Steps: []step{
+				{Request: configs},
+				{
+					Request: pbTick(1, "1"),
+					Response: &pb.StreamResponse{
+						Time:   timestamppb.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+						Values: []*pb.Value{{}, number("-0.5")},
+						Warmup: true,
+					},
+				},
+				{
+					Request: pbTick(2, "2"),
+					Response: &pb.StreamResponse{
+						Time:   timestamppb.New(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+						Values: []*pb.Value{number("1.5"), number("-1")},
+					},
+				},
+				{
+					Request: pbTick(2, "3"),
+					Response: &pb.StreamResponse{
+						Time: timestamppb.New(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+						Values: []*pb.Value{
+							number("2"),
+							{Kind: &pb.Value_Error{Error: "(1 / (close - 3)): division by zero"}},
+						},
+					},
+				},
+				{
+					Request: pbTick(3, "5"),
+					Response: &pb.StreamResponse{
+						Time:   timestamppb.New(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+						Values: []*pb.Value{number("4"), number("0.5")},
+					},
+				},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			stream, err := client.Stream(context.Background())
+			require.NoError(t, err)
+
+			for _, s := range c.Steps {
+				require.NoError(t, stream.Send(s.Request))
+
+				if s.Response == nil {
+					continue
+				}
+
+				res, err := stream.Recv()
+				require.NoError(t, err)
+				assert.True(t, proto.Equal(s.Response, res), res.String())
+			}
+
+			require.NoError(t, stream.CloseSend())
+
+			_, err = stream.Recv()
+			if c.Code == codes.OK {
+				assert.Equal(t, io.EOF, err)
+				return
+			}
+
+			assert.Equal(t, c.Code, status.Code(err))
+		})
+	}
+}
+
+func Test_toStatus(t *testing.T) {
+	err := toStatus(&indc.DataSizeError{Expected: 2, Actual: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = toStatus(assert.AnError)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, assert.AnError.Error(), status.Convert(err).Message())
+}
@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jellydator/indc"
+	"github.com/jellydator/indc/expr"
+)
+
+var (
+	// ErrMissingExprs is returned when no expressions are provided.
+	ErrMissingExprs = errors.New("at least one expression must be provided")
+
+	// ErrOutOfOrderTick is returned when a tick is older than the
+	// previous one.
+	ErrOutOfOrderTick = errors.New("tick is older than the previous one")
+
+	// ErrMissingTickTime is returned when a tick has no time.
+	ErrMissingTickTime = errors.New("tick time must be set")
+)
+
+// Result holds a single value calculated by a Window.
+type Result struct {
+	// Value specifies the calculated decimal.Decimal or bool value.
+	Value interface{}
+
+	// Err specifies the calculation error. It is *indc.DataSizeError
+	// while the window does not hold enough candles yet.
+	Err error
+}
+
+// Window holds the newest candles required by a set of expressions and
+// recalculates expression values every time a tick is pushed. Only as
+// many candles as the most demanding expression needs are kept, so
+// memory usage does not grow with the length of the stream.
+//
+// Values are recalculated from the kept candles rather than updated
+// incrementally: expressions combine arbitrary indicators and most of
+// them have no incremental form, while a bounded window keeps the cost
+// of every tick constant.
+// The zero value is not usable.
+type Window struct {
+	// valid specifies whether Window paremeters were validated.
+	valid bool
+
+	// exprs specifies expressions that are calculated.
+	exprs []expr.Expr
+
+	// size specifies the maximum amount of kept candles.
+	size int
+
+	// candles specifies kept candles, ordered from the oldest to the
+	// newest.
+	candles []indc.Candle
+}
+
+// NewWindow validates provided expressions and creates new Window.
+func NewWindow(ee ...expr.Expr) (*Window, error) {
+	w := &Window{exprs: ee}
+
+	if err := w.validate(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range ee {
+		if e.Count() > w.size {
+			w.size = e.Count()
+		}
+	}
+
+	w.candles = make([]indc.Candle, 0, w.size)
+
+	return w, nil
+}
+
+// validate checks whether the window has valid expressions.
+func (w *Window) validate() error {
+	if len(w.exprs) == 0 {
+		return ErrMissingExprs
+	}
+
+	for i, e := range w.exprs {
+		if e.Count() < 1 {
+			return fmt.Errorf("expression %d: %w", i, expr.ErrInvalidExpression)
+		}
+	}
+
+	w.valid = true
+
+	return nil
+}
+
+// Push adds the tick to the window. Its time must be set. A tick with
+// the same time as the newest candle is merged into it, a newer tick is
+// appended as a new candle and the oldest candle is dropped when the
+// window is full.
+func (w *Window) Push(c indc.Candle) error {
+	if !w.valid {
+		return indc.ErrInvalidIndicator
+	}
+
+	if c.Time.IsZero() {
+		return ErrMissingTickTime
+	}
+
+	if len(w.candles) > 0 {
+		last := w.candles[len(w.candles)-1]
+
+		switch {
+		case c.Time.Equal(last.Time):
+			w.candles[len(w.candles)-1] = indc.Merge(last, c)
+			return nil
+		case c.Time.Before(last.Time):
+			return ErrOutOfOrderTick
+		}
+	}
+
+	if len(w.candles) == w.size {
+		copy(w.candles, w.candles[1:])
+		w.candles = w.candles[:len(w.candles)-1]
+	}
+
+	w.candles = append(w.candles, c)
+
+	return nil
+}
+
+// Last returns the newest candle of the window. False is returned when
+// the window is empty.
+func (w *Window) Last() (indc.Candle, bool) {
+	if len(w.candles) == 0 {
+		return indc.Candle{}, false
+	}
+
+	return w.candles[len(w.candles)-1], true
+}
+
+// Results calculates values of all expressions at the newest candle.
+// Results are in the same order as the expressions.
+func (w *Window) Results() []Result {
+	rr := make([]Result, len(w.exprs))
+
+	for i, e := range w.exprs {
+		rr[i].Value, rr[i].Err = eval(e, w.candles)
+	}
+
+	return rr
+}
+
+// eval evaluates the expression at the newest candle.
+func eval(e expr.Expr, cc []indc.Candle) (interface{}, error) {
+	if e.Type() == expr.TypeBool {
+		return e.Bool(cc)
+	}
+
+	return e.Number(cc)
+}
@@ -0,0 +1,226 @@
+package rpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jellydator/indc"
+	"github.com/jellydator/indc/expr"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		if exp == assert.AnError { //nolint:goerr113 // direct check is needed
+			assert.Error(t, err)
+			return
+		}
+
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+func mustParse(t *testing.T, src string) expr.Expr {
+	t.Helper()
+
+	e, err := expr.Parse(src)
+	require.NoError(t, err)
+
+	return e
+}
+
+func tick(day int, price string) indc.Candle {
+	d := decimal.RequireFromString(price)
+
+	return indc.Candle{
+		Time:  time.Date(2021, 1, day, 0, 0, 0, 0, time.UTC),
+		Open:  d,
+		High:  d,
+		Low:   d,
+		Close: d,
+	}
+}
+
+func Test_NewWindow(t *testing.T) {
+	cc := map[string]struct {
+		Exprs []expr.Expr
+		Size  int
+		Error error
+	}{
+		"Missing expressions": {
+			Error: ErrMissingExprs,
+		},
+		"Invalid expression": {
+			Exprs: []expr.Expr{{}},
+			Error: fmt.Errorf("expression 0: %w", expr.ErrInvalidExpression),
+		},
+		"Successfully created new Window": {
+			Exprs: []expr.Expr{
+				mustParse(t, "sma(3)"),
+				mustParse(t, "cross_up(close, sma(2))"),
+			},
+			Size: 3,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewWindow(c.Exprs...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Size, res.size)
+		})
+	}
+}
+
+func Test_Window_Push(t *testing.T) {
+	cc := map[string]struct {
+		Window  *Window
+		Candles []indc.Candle
+		Result  []string
+		Error   error
+	}{
+		"Invalid window": {
+			Window:  &Window{},
+			Candles: []indc.Candle{tick(1, "1")},
+			Error:   indc.ErrInvalidIndicator,
+		},
+		"Missing tick time": {
+			Window:  &Window{valid: true, size: 2},
+			Candles: []indc.Candle{{Close: decimal.NewFromInt(1)}},
+			Error:   ErrMissingTickTime,
+		},
+		"Out of order tick": {
+			Window:  &Window{valid: true, size: 2},
+			Candles: []indc.Candle{tick(2, "1"), tick(1, "2")},
+			Error:   ErrOutOfOrderTick,
+		},
+		"Successfully appended ticks": {
+			Window:  &Window{valid: true, size: 2},
+			Candles: []indc.Candle{tick(1, "1"), tick(2, "2")},
+			Result:  []string{"1", "2"},
+		},
+		"Successfully merged tick": {
+			Window:  &Window{valid: true, size: 2},
+			Candles: []indc.Candle{tick(1, "1"), tick(2, "2"), tick(2, "3")},
+			Result:  []string{"1", "3"},
+		},
+		"Successfully dropped oldest candle": {
+			Window:  &Window{valid: true, size: 2},
+			Candles: []indc.Candle{tick(1, "1"), tick(2, "2"), tick(3, "3")},
+			Result:  []string{"2", "3"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var err error
+
+			for _, cd := range c.Candles {
+				if err = c.Window.Push(cd); err != nil {
+					break
+				}
+			}
+
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			res := make([]string, len(c.Window.candles))
+			for i, cd := range c.Window.candles {
+				res[i] = cd.Close.String()
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Window_Push_Merge(t *testing.T) {
+	w, err := NewWindow(mustParse(t, "close"))
+	require.NoError(t, err)
+
+	c := tick(1, "5")
+	c.Volume = decimal.NewFromInt(2)
+	require.NoError(t, w.Push(c))
+
+	c = tick(1, "3")
+	c.Volume = decimal.NewFromInt(1)
+	require.NoError(t, w.Push(c))
+
+	c = tick(1, "4")
+	require.NoError(t, w.Push(c))
+
+	res, ok := w.Last()
+	require.True(t, ok)
+	assert.Equal(t, indc.Candle{
+		Time:   c.Time,
+		Open:   decimal.RequireFromString("5"),
+		High:   decimal.RequireFromString("5"),
+		Low:    decimal.RequireFromString("3"),
+		Close:  decimal.RequireFromString("4"),
+		Volume: decimal.NewFromInt(3),
+	}, res)
+}
+
+func Test_Window_Last(t *testing.T) {
+	w, err := NewWindow(mustParse(t, "close"))
+	require.NoError(t, err)
+
+	_, ok := w.Last()
+	assert.False(t, ok)
+
+	require.NoError(t, w.Push(tick(1, "5")))
+
+	res, ok := w.Last()
+	assert.True(t, ok)
+	assert.Equal(t, tick(1, "5"), res)
+}
+
+func Test_Window_Results(t *testing.T) {
+	w, err := NewWindow(
+		mustParse(t, "sma(2)"),
+		mustParse(t, "close > sma(2)"),
+		mustParse(t, "1 / (close - 3)"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Push(tick(1, "1")))
+
+	rr := w.Results()
+	require.Len(t, rr, 3)
+	assertEqualError(t, &indc.DataSizeError{Expected: 2, Actual: 1}, rr[0].Err)
+	assertEqualError(t, &indc.DataSizeError{Expected: 2, Actual: 1}, rr[1].Err)
+	assert.Equal(t, "-0.5", fmt.Sprint(rr[2].Value))
+
+	require.NoError(t, w.Push(tick(2, "3")))
+
+	rr = w.Results()
+	assert.Equal(t, "2", fmt.Sprint(rr[0].Value))
+	assert.Equal(t, true, rr[1].Value)
+	assertEqualError(t, fmt.Errorf("(1 / (close - 3)): %w", indc.ErrDivisionByZero), rr[2].Err)
+
+	assert.Empty(t, (&Window{}).Results())
+}
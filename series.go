@@ -0,0 +1,29 @@
+package indc
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Series calculates indicator values for every data point that has
+// enough preceding data points for the calculation. The first value of
+// the result corresponds to dd[ind.Count()-1], the last one to
+// dd[len(dd)-1].
+func Series(ind Indicator, dd []decimal.Decimal) ([]decimal.Decimal, error) {
+	count := ind.Count()
+	if count < 1 || len(dd) < count {
+		return nil, &DataSizeError{Expected: count, Actual: len(dd)}
+	}
+
+	res := make([]decimal.Decimal, len(dd)-count+1)
+
+	for i := range res {
+		v, err := ind.Calc(dd[i : i+count])
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = v
+	}
+
+	return res, nil
+}
@@ -0,0 +1,63 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Series(t *testing.T) {
+	cc := map[string]struct {
+		Indicator Indicator
+		Data      []decimal.Decimal
+		Result    []string
+		Error     error
+	}{
+		"Invalid data size": {
+			Indicator: SMA{valid: true, length: 3},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(1),
+			},
+			Error: &DataSizeError{Expected: 3, Actual: 1},
+		},
+		"Calc returns an error": {
+			Indicator: SMA{length: 1},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(1),
+			},
+			Error: ErrInvalidIndicator,
+		},
+		"Successful calculation": {
+			Indicator: SMA{valid: true, length: 2},
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(1),
+				decimal.NewFromInt(3),
+				decimal.NewFromInt(5),
+				decimal.NewFromInt(9),
+			},
+			Result: []string{"2", "4", "7"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Series(c.Indicator, c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			ss := make([]string, len(res))
+			for i := range res {
+				ss[i] = res[i].String()
+			}
+
+			assert.Equal(t, c.Result, ss)
+		})
+	}
+}
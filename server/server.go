@@ -0,0 +1,404 @@
+// Package server provides an HTTP handler that exposes indicator
+// calculations through a JSON API.
+//
+// Endpoints:
+//
+//	GET  /indicators  lists descriptions of all available indicators.
+//	POST /validate    validates an indicator spec.
+//	POST /calc        calculates a single value at the newest data point.
+//	POST /series      calculates values at every data point that has
+//	                  enough preceding data points.
+//
+// Indicator specs are expressions supported by the expr package, e.g.
+// "bb(upper,2,20)" or "rsi(14) < 30". Data is posted either as candles
+// or as plain data points, ordered from the oldest to the newest.
+// Errors are reported with an appropriate status code and a body of
+// the form {"error":{"code":"...","message":"..."}}.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jellydator/indc"
+	"github.com/jellydator/indc/expr"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidLimit is returned when negative request size limit is
+	// provided.
+	ErrInvalidLimit = errors.New("invalid request size limit")
+
+	// errMissingData is returned when request contains neither data
+	// points nor candles.
+	errMissingData = errors.New("either data or candles must be provided")
+)
+
+// DefaultLimit is the request size limit used when none is provided.
+const DefaultLimit = 1 << 20
+
+// Request holds the body of validate, calc and series requests.
+type Request struct {
+	// Spec specifies the indicator spec.
+	Spec string `json:"spec"`
+
+	// Lenient specifies whether oversized data should be trimmed
+	// instead of rejected.
+	Lenient bool `json:"lenient,omitempty"`
+
+	// Data specifies data points used as every price of the candles.
+	Data []decimal.Decimal `json:"data,omitempty"`
+
+	// Candles specifies candles. They take precedence over data points.
+	Candles []indc.Candle `json:"candles,omitempty"`
+}
+
+// ValidateResponse holds the body of successful validate responses.
+type ValidateResponse struct {
+	// Spec specifies the canonical form of the spec.
+	Spec string `json:"spec"`
+
+	// Type specifies the type of calculated values.
+	Type expr.Type `json:"type"`
+
+	// Count specifies the amount of data points required for a single
+	// calculation.
+	Count int `json:"count"`
+}
+
+// CalcResponse holds the body of successful calc responses.
+type CalcResponse struct {
+	// Value specifies the calculated decimal or boolean value.
+	Value interface{} `json:"value"`
+}
+
+// SeriesResponse holds the body of successful series responses.
+type SeriesResponse struct {
+	// Offset specifies the index of the data point that corresponds to
+	// the first value.
+	Offset int `json:"offset"`
+
+	// Values specifies the calculated decimal or boolean values.
+	Values []interface{} `json:"values"`
+}
+
+// ErrorResponse holds the body of error responses.
+type ErrorResponse struct {
+	// Error specifies the details of the error.
+	Error ErrorDetails `json:"error"`
+}
+
+// ErrorDetails holds the details of an error.
+type ErrorDetails struct {
+	// Code specifies the machine readable error code.
+	Code string `json:"code"`
+
+	// Message specifies the human readable error message.
+	Message string `json:"message"`
+
+	// Param specifies the name of the invalid parameter, if known.
+	Param string `json:"param,omitempty"`
+}
+
+// _codes maps errors to their codes and status codes, in matching
+// order.
+var _codes = []struct {
+	err    error
+	code   string
+	status int
+}{
+	{indc.ErrInvalidLength, "invalid_length", http.StatusBadRequest},
+	{indc.ErrInvalidDataSize, "invalid_data_size", http.StatusUnprocessableEntity},
+	{indc.ErrInvalidTrend, "invalid_trend", http.StatusBadRequest},
+	{indc.ErrInvalidBand, "invalid_band", http.StatusBadRequest},
+	{indc.ErrInvalidMA, "invalid_ma", http.StatusBadRequest},
+	{indc.ErrInvalidRounding, "invalid_rounding", http.StatusBadRequest},
+	{indc.ErrInvalidFactor, "invalid_factor", http.StatusBadRequest},
+	{indc.ErrInvalidZeroPolicy, "invalid_zero_policy", http.StatusBadRequest},
+	{indc.ErrUnknownIndicator, "unknown_indicator", http.StatusBadRequest},
+	{indc.ErrInvalidArgument, "invalid_argument", http.StatusBadRequest},
+	{indc.ErrDivisionByZero, "division_by_zero", http.StatusUnprocessableEntity},
+	{expr.ErrInvalidSyntax, "invalid_syntax", http.StatusBadRequest},
+	{expr.ErrTypeMismatch, "type_mismatch", http.StatusBadRequest},
+	{errMissingData, "missing_data", http.StatusBadRequest},
+}
+
+// Server is an HTTP handler of the indicator API.
+// The zero value is not usable.
+type Server struct {
+	// valid specifies whether Server paremeters were validated.
+	valid bool
+
+	// limit specifies the maximum size of request bodies in bytes.
+	limit int64
+
+	// mux specifies the request router.
+	mux *http.ServeMux
+}
+
+// NewServer validates provided configuration options and creates new
+// Server. If limit is zero, DefaultLimit is used.
+func NewServer(limit int64) (*Server, error) {
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	s := &Server{
+		limit: limit,
+		mux:   http.NewServeMux(),
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	s.mux.HandleFunc("/indicators", s.method(http.MethodGet, s.handleIndicators))
+	s.mux.HandleFunc("/validate", s.method(http.MethodPost, s.handleValidate))
+	s.mux.HandleFunc("/calc", s.method(http.MethodPost, s.handleCalc))
+	s.mux.HandleFunc("/series", s.method(http.MethodPost, s.handleSeries))
+
+	return s, nil
+}
+
+// validate checks whether the server has valid configuration
+// properties.
+func (s *Server) validate() error {
+	if s.limit < 0 {
+		return ErrInvalidLimit
+	}
+
+	s.valid = true
+
+	return nil
+}
+
+// ServeHTTP handles the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.valid {
+		writeError(w, http.StatusInternalServerError, ErrorDetails{
+			Code:    "internal",
+			Message: indc.ErrInvalidIndicator.Error(),
+		})
+
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// method restricts the handler to the provided HTTP method.
+func (s *Server) method(m string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != m {
+			w.Header().Set("Allow", m)
+			writeError(w, http.StatusMethodNotAllowed, ErrorDetails{
+				Code:    "method_not_allowed",
+				Message: fmt.Sprintf("method %s is not allowed", r.Method),
+			})
+
+			return
+		}
+
+		h(w, r)
+	}
+}
+
+// handleIndicators lists all available indicators.
+func (s *Server) handleIndicators(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, indc.Descriptions())
+}
+
+// handleValidate validates the posted spec.
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	_, e, ok := s.parse(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ValidateResponse{
+		Spec:  e.String(),
+		Type:  e.Type(),
+		Count: e.Count(),
+	})
+}
+
+// handleCalc calculates the value at the newest data point.
+func (s *Server) handleCalc(w http.ResponseWriter, r *http.Request) {
+	req, e, ok := s.parse(w, r)
+	if !ok {
+		return
+	}
+
+	cc, err := req.candles()
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := eval(e, cc)
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, CalcResponse{Value: v})
+}
+
+// handleSeries calculates values at every data point with enough
+// preceding data points.
+func (s *Server) handleSeries(w http.ResponseWriter, r *http.Request) {
+	req, e, ok := s.parse(w, r)
+	if !ok {
+		return
+	}
+
+	cc, err := req.candles()
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	if len(cc) < e.Count() {
+		writeErr(w, &indc.DataSizeError{Expected: e.Count(), Actual: len(cc)})
+		return
+	}
+
+	res := SeriesResponse{
+		Offset: e.Count() - 1,
+		Values: make([]interface{}, 0, len(cc)-e.Count()+1),
+	}
+
+	for i := e.Count(); i <= len(cc); i++ {
+		v, err := eval(e, cc[:i])
+		if err != nil {
+			writeErr(w, err)
+			return
+		}
+
+		res.Values = append(res.Values, v)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// parse decodes the request body and parses its spec. Error response is
+// written when false is returned.
+func (s *Server) parse(w http.ResponseWriter, r *http.Request) (Request, expr.Expr, bool) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, s.limit+1))
+	if err != nil {
+		writeErr(w, err)
+		return Request{}, expr.Expr{}, false
+	}
+
+	if int64(len(body)) > s.limit {
+		writeError(w, http.StatusRequestEntityTooLarge, ErrorDetails{
+			Code:    "request_too_large",
+			Message: fmt.Sprintf("request body must not exceed %d bytes", s.limit),
+		})
+
+		return Request{}, expr.Expr{}, false
+	}
+
+	var req Request
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrorDetails{
+			Code:    "invalid_json",
+			Message: err.Error(),
+		})
+
+		return Request{}, expr.Expr{}, false
+	}
+
+	var opts []indc.Option
+	if req.Lenient {
+		opts = append(opts, indc.WithLenient())
+	}
+
+	e, err := expr.Parse(req.Spec, opts...)
+	if err != nil {
+		writeErr(w, err)
+		return Request{}, expr.Expr{}, false
+	}
+
+	return req, e, true
+}
+
+// candles returns the posted candles or candles created from the posted
+// data points.
+func (req Request) candles() ([]indc.Candle, error) {
+	if len(req.Candles) > 0 {
+		return req.Candles, nil
+	}
+
+	if len(req.Data) == 0 {
+		return nil, errMissingData
+	}
+
+	cc := make([]indc.Candle, len(req.Data))
+	for i, d := range req.Data {
+		cc[i] = indc.Candle{Open: d, High: d, Low: d, Close: d}
+	}
+
+	return cc, nil
+}
+
+// eval evaluates the expression at the newest candle.
+func eval(e expr.Expr, cc []indc.Candle) (interface{}, error) {
+	if e.Type() == expr.TypeBool {
+		return e.Bool(cc)
+	}
+
+	return e.Number(cc)
+}
+
+// writeErr writes error response of the error, mapped by its sentinel.
+func writeErr(w http.ResponseWriter, err error) {
+	det := ErrorDetails{
+		Code:    "internal",
+		Message: err.Error(),
+	}
+
+	status := http.StatusInternalServerError
+
+	for _, c := range _codes {
+		if errors.Is(err, c.err) {
+			det.Code = c.code
+			status = c.status
+
+			break
+		}
+	}
+
+	var verr *indc.ValidationError
+	if errors.As(err, &verr) {
+		det.Param = verr.Param
+	}
+
+	writeError(w, status, det)
+}
+
+// writeError writes error response with the provided details.
+func writeError(w http.ResponseWriter, status int, det ErrorDetails) {
+	writeJSON(w, status, ErrorResponse{Error: det})
+}
+
+// writeJSON writes JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	// nothing can be done about write errors at this point.
+	_ = enc.Encode(v)
+}
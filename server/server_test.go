@@ -0,0 +1,256 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jellydator/indc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertEqualError(t *testing.T, exp, err error) {
+	t.Helper()
+
+	if exp != nil {
+		if exp == assert.AnError { //nolint:goerr113 // direct check is needed
+			assert.Error(t, err)
+			return
+		}
+
+		assert.Equal(t, exp, err)
+
+		return
+	}
+
+	assert.NoError(t, err)
+}
+
+func Test_NewServer(t *testing.T) {
+	cc := map[string]struct {
+		Limit  int64
+		Result int64
+		Error  error
+	}{
+		"Invalid limit": {
+			Limit: -1,
+			Error: ErrInvalidLimit,
+		},
+		"Successfully created new Server with default limit": {
+			Result: DefaultLimit,
+		},
+		"Successfully created new Server": {
+			Limit:  10,
+			Result: 10,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewServer(c.Limit)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Result, res.limit)
+		})
+	}
+}
+
+func Test_Server(t *testing.T) {
+	s, err := NewServer(512)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	cc := map[string]struct {
+		Method string
+		Path   string
+		Body   string
+		Status int
+		Result string
+	}{
+		"Unknown path": {
+			Method: http.MethodGet,
+			Path:   "/foo",
+			Status: http.StatusNotFound,
+			Result: "404 page not found",
+		},
+		"Method not allowed": {
+			Method: http.MethodGet,
+			Path:   "/calc",
+			Status: http.StatusMethodNotAllowed,
+			Result: `{"error":{"code":"method_not_allowed","message":"method GET is not allowed"}}`,
+		},
+		"Request too large": {
+			Method: http.MethodPost,
+			Path:   "/calc",
+			Body:   `{"spec":"sma(2)","data":[` + strings.Repeat("1,", 300) + `1]}`,
+			Status: http.StatusRequestEntityTooLarge,
+			Result: `{"error":{"code":"request_too_large","message":"request body must not exceed 512 bytes"}}`,
+		},
+		"Invalid JSON": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body:   `{"spec":`,
+			Status: http.StatusBadRequest,
+			Result: `{"error":{"code":"invalid_json","message":"unexpected EOF"}}`,
+		},
+		"Unknown field": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body:   `{"spec":"sma","length":2}`,
+			Status: http.StatusBadRequest,
+			Result: `{"error":{"code":"invalid_json","message":"json: unknown field \"length\""}}`,
+		},
+		"Invalid syntax": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body:   `{"spec":"sma(2"}`,
+			Status: http.StatusBadRequest,
+			Result: `{"error":{"code":"invalid_syntax","message":"invalid syntax at position 5: expected \",\" or \")\""}}`,
+		},
+		"Invalid length": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body:   `{"spec":"sma(0)"}`,
+			Status: http.StatusBadRequest,
+			Result: `{"error":{"code":"invalid_length","message":"sma: invalid length: length 0 must be greater than 0 at position 0: cannot create sma","param":"length"}}`,
+		},
+		"Unknown indicator": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body:   `{"spec":"macd(12)"}`,
+			Status: http.StatusBadRequest,
+			Result: `{"error":{"code":"unknown_indicator","message":"unknown indicator: \"macd\" at position 0: cannot create macd"}}`,
+		},
+		"Successfully validated spec": {
+			Method: http.MethodPost,
+			Path:   "/validate",
+			Body:   `{"spec":"rsi(14) < 30"}`,
+			Status: http.StatusOK,
+			Result: `{"spec":"(rsi(14) < 30)","type":"bool","count":14}`,
+		},
+		"Missing data": {
+			Method: http.MethodPost,
+			Path:   "/calc",
+			Body:   `{"spec":"sma(2)"}`,
+			Status: http.StatusBadRequest,
+			Result: `{"error":{"code":"missing_data","message":"either data or candles must be provided"}}`,
+		},
+		"Invalid data size": {
+			Method: http.MethodPost,
+			Path:   "/calc",
+			Body:   `{"spec":"sma(3)","data":[1,2]}`,
+			Status: http.StatusUnprocessableEntity,
+			Result: `{"error":{"code":"invalid_data_size","message":"invalid data size: expected 3, got 2"}}`,
+		},
+		"Division by zero": {
+			Method: http.MethodPost,
+			Path:   "/calc",
+			Body:   `{"spec":"close / (high - low)","data":[1]}`,
+			Status: http.StatusUnprocessableEntity,
+			Result: `{"error":{"code":"division_by_zero","message":"(close / (high - low)): division by zero"}}`,
+		},
+		"Successfully calculated value": {
+			Method: http.MethodPost,
+			Path:   "/calc",
+			Body:   `{"spec":"sma(2)","data":["1",2,"4.5"]}`,
+			Status: http.StatusOK,
+			Result: `{"value":"3.25"}`,
+		},
+		"Successfully calculated lenient value from candles": {
+			Method: http.MethodPost,
+			Path:   "/calc",
+			Body: `{"spec":"close > sma(2)","lenient":true,"candles":[` +
+				`{"time":"2021-01-01T00:00:00Z","close":"1"},` +
+				`{"time":"2021-01-02T00:00:00Z","close":"3"}]}`,
+			Status: http.StatusOK,
+			Result: `{"value":true}`,
+		},
+		"Invalid series data size": {
+			Method: http.MethodPost,
+			Path:   "/series",
+			Body:   `{"spec":"sma(3)","data":[1,2]}`,
+			Status: http.StatusUnprocessableEntity,
+			Result: `{"error":{"code":"invalid_data_size","message":"invalid data size: expected 3, got 2"}}`,
+		},
+		"Series calculation returns an error": {
+			Method: http.MethodPost,
+			Path:   "/series",
+			Body:   `{"spec":"1 / (close - 2)","data":[1,2]}`,
+			Status: http.StatusUnprocessableEntity,
+			Result: `{"error":{"code":"division_by_zero","message":"(1 / (close - 2)): division by zero"}}`,
+		},
+		"Successfully calculated series": {
+			Method: http.MethodPost,
+			Path:   "/series",
+			Body:   `{"spec":"sma(2)","data":[1,3,5,9]}`,
+			Status: http.StatusOK,
+			Result: `{"offset":1,"values":["2","4","7"]}`,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(c.Method, srv.URL+c.Path, strings.NewReader(c.Body))
+			require.NoError(t, err)
+
+			resp, err := srv.Client().Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.Status, resp.StatusCode)
+			assert.Equal(t, c.Result, strings.TrimSuffix(string(body), "\n"))
+		})
+	}
+}
+
+func Test_Server_Indicators(t *testing.T) {
+	s, err := NewServer(0)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/indicators", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var dd []indc.Description
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &dd))
+	require.Len(t, dd, len(indc.Descriptions()))
+	assert.Equal(t, "aroon", dd[0].Name)
+
+	rec = httptest.NewRecorder()
+	(&Server{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/indicators", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error":{"code":"internal","message":"invalid indicator"}}`, rec.Body.String())
+}
+
+func Test_writeErr(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeErr(rec, assert.AnError)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error":{"code":"internal","message":"`+assert.AnError.Error()+`"}}`, rec.Body.String())
+}
@@ -0,0 +1,291 @@
+package indc
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidEvent is returned when event value is invalid.
+	ErrInvalidEvent = errors.New("invalid event")
+
+	// ErrInvalidDivergence is returned when divergence value is
+	// invalid.
+	ErrInvalidDivergence = errors.New("invalid divergence")
+)
+
+// Event specifies the relation between two series at a single data
+// point.
+type Event int
+
+// Available crossover events.
+const (
+	// EventAbove specifies that the first series is above the second
+	// one.
+	EventAbove Event = iota + 1
+
+	// EventBelow specifies that the first series is below the second
+	// one.
+	EventBelow
+
+	// EventCrossUp specifies that the first series moved above the
+	// second one at this data point.
+	EventCrossUp
+
+	// EventCrossDown specifies that the first series moved below the
+	// second one at this data point.
+	EventCrossDown
+)
+
+// Validate checks whether the event is one of supported event types.
+func (e Event) Validate() error {
+	switch e {
+	case EventAbove, EventBelow, EventCrossUp, EventCrossDown:
+		return nil
+	default:
+		return ErrInvalidEvent
+	}
+}
+
+// MarshalText turns event into appropriate string representation.
+func (e Event) MarshalText() ([]byte, error) {
+	var v string
+
+	switch e {
+	case EventAbove:
+		v = "above"
+	case EventBelow:
+		v = "below"
+	case EventCrossUp:
+		v = "cross_up"
+	case EventCrossDown:
+		v = "cross_down"
+	default:
+		return nil, ErrInvalidEvent
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate event value.
+func (e *Event) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "above", "a":
+		*e = EventAbove
+	case "below", "b":
+		*e = EventBelow
+	case "cross_up", "cu":
+		*e = EventCrossUp
+	case "cross_down", "cd":
+		*e = EventCrossDown
+	default:
+		return ErrInvalidEvent
+	}
+
+	return nil
+}
+
+// Constant is an indicator that always returns the same value. It
+// allows indicators to be compared with fixed levels.
+type Constant struct {
+	// value specifies the returned value.
+	value decimal.Decimal
+}
+
+// NewConstant creates new Constant.
+func NewConstant(v decimal.Decimal) Constant {
+	return Constant{value: v}
+}
+
+// Calc returns the constant value.
+func (c Constant) Calc(_ []decimal.Decimal) (decimal.Decimal, error) {
+	return c.value, nil
+}
+
+// CalcFloat returns the constant value.
+func (c Constant) CalcFloat(_ []float64) (float64, error) {
+	v, _ := c.value.Float64()
+	return v, nil
+}
+
+// Count returns the amount of data points required to calculate
+// the constant.
+func (c Constant) Count() int {
+	return 1
+}
+
+// Cross compares values of indicators a and b at every data point and
+// returns the resulting events. Result is aligned with the data points;
+// data points that do not have enough preceding data points for both
+// indicators and data points at which both values are equal have zero
+// events.
+// Cross up (down) is reported when a is above (below) b at the current
+// data point and was not above (below) it at the previous one.
+func Cross(a, b Indicator, dd []decimal.Decimal) ([]Event, error) {
+	av, err := Series(a, dd)
+	if err != nil {
+		return nil, err
+	}
+
+	bv, err := Series(b, dd)
+	if err != nil {
+		return nil, err
+	}
+
+	start := a.Count()
+	if b.Count() > start {
+		start = b.Count()
+	}
+
+	start--
+
+	res := make([]Event, len(dd))
+	prev := 0
+
+	for i := start; i < len(dd); i++ {
+		cur := av[i-a.Count()+1].Cmp(bv[i-b.Count()+1])
+
+		switch {
+		case cur > 0 && i > start && prev <= 0:
+			res[i] = EventCrossUp
+		case cur < 0 && i > start && prev >= 0:
+			res[i] = EventCrossDown
+		case cur > 0:
+			res[i] = EventAbove
+		case cur < 0:
+			res[i] = EventBelow
+		}
+
+		prev = cur
+	}
+
+	return res, nil
+}
+
+// Divergence specifies the direction of a divergence between data points
+// and indicator values.
+type Divergence int
+
+// Available divergence types.
+const (
+	// DivergenceBullish specifies that data points made a lower low
+	// while the indicator made a higher low.
+	DivergenceBullish Divergence = iota + 1
+
+	// DivergenceBearish specifies that data points made a higher high
+	// while the indicator made a lower high.
+	DivergenceBearish
+)
+
+// Validate checks whether the divergence is one of supported divergence
+// types.
+func (d Divergence) Validate() error {
+	switch d {
+	case DivergenceBullish, DivergenceBearish:
+		return nil
+	default:
+		return ErrInvalidDivergence
+	}
+}
+
+// MarshalText turns divergence into appropriate string representation.
+func (d Divergence) MarshalText() ([]byte, error) {
+	var v string
+
+	switch d {
+	case DivergenceBullish:
+		v = "bullish"
+	case DivergenceBearish:
+		v = "bearish"
+	default:
+		return nil, ErrInvalidDivergence
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate divergence value.
+func (d *Divergence) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "bullish", "bull":
+		*d = DivergenceBullish
+	case "bearish", "bear":
+		*d = DivergenceBearish
+	default:
+		return ErrInvalidDivergence
+	}
+
+	return nil
+}
+
+// Divergences detects divergences between data points and values of the
+// indicator. A pivot low (high) is a data point that is lower (higher)
+// than lookback data points on both of its sides. Two consecutive pivot
+// lows form a bullish divergence when the data points make a lower low
+// while the indicator makes a higher low; pivot highs form a bearish
+// divergence analogously.
+// Result is aligned with the data points. As a pivot is only known
+// lookback data points after it occurs, divergence is reported at that
+// later data point, so that no future data is used. Other data points
+// have zero values.
+func Divergences(ind Indicator, dd []decimal.Decimal, lookback int) ([]Divergence, error) {
+	if lookback < 1 {
+		return nil, &ValidationError{
+			Indicator:  "divergence",
+			Param:      "lookback",
+			Value:      lookback,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	vv, err := Series(ind, dd)
+	if err != nil {
+		return nil, err
+	}
+
+	off := ind.Count() - 1
+	res := make([]Divergence, len(dd))
+	low, high := -1, -1
+
+	for i := lookback; i+lookback < len(dd); i++ {
+		if i < off {
+			continue
+		}
+
+		if isPivot(dd, i, lookback, -1) {
+			if low >= 0 && dd[i].LessThan(dd[low]) && vv[i-off].GreaterThan(vv[low-off]) {
+				res[i+lookback] = DivergenceBullish
+			}
+
+			low = i
+		}
+
+		if isPivot(dd, i, lookback, 1) {
+			if high >= 0 && dd[i].GreaterThan(dd[high]) && vv[i-off].LessThan(vv[high-off]) {
+				res[i+lookback] = DivergenceBearish
+			}
+
+			high = i
+		}
+	}
+
+	return res, nil
+}
+
+// isPivot checks whether the data point at index i is a pivot high
+// (sign 1) or low (sign -1). The data point has to be strictly beyond
+// the preceding data points and not below (above) the following ones,
+// so that a flat extreme is reported only once.
+func isPivot(dd []decimal.Decimal, i, lookback, sign int) bool {
+	for j := i - lookback; j <= i+lookback; j++ {
+		c := dd[i].Cmp(dd[j]) * sign
+
+		if (j < i && c <= 0) || (j > i && c < 0) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,429 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// lookup is an indicator that maps the newest data point to a
+// predefined value.
+type lookup map[string]int64
+
+func (l lookup) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	return decimal.NewFromInt(l[dd[len(dd)-1].String()]), nil
+}
+
+func (l lookup) Count() int {
+	return 1
+}
+
+func Test_Event_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Event Event
+		Err   error
+	}{
+		"Invalid Event": {
+			Err: ErrInvalidEvent,
+		},
+		"Successful EventAbove validation": {
+			Event: EventAbove,
+		},
+		"Successful EventBelow validation": {
+			Event: EventBelow,
+		},
+		"Successful EventCrossUp validation": {
+			Event: EventCrossUp,
+		},
+		"Successful EventCrossDown validation": {
+			Event: EventCrossDown,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Event.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Event_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Event Event
+		Text  string
+		Err   error
+	}{
+		"Invalid Event": {
+			Err: ErrInvalidEvent,
+		},
+		"Successful EventAbove marshal": {
+			Event: EventAbove,
+			Text:  "above",
+		},
+		"Successful EventBelow marshal": {
+			Event: EventBelow,
+			Text:  "below",
+		},
+		"Successful EventCrossUp marshal": {
+			Event: EventCrossUp,
+			Text:  "cross_up",
+		},
+		"Successful EventCrossDown marshal": {
+			Event: EventCrossDown,
+			Text:  "cross_down",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Event.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Event_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Event
+		Err    error
+	}{
+		"Invalid Event": {
+			Err: ErrInvalidEvent,
+		},
+		"Successful EventAbove unmarshal (long form)": {
+			Text:   "above",
+			Result: EventAbove,
+		},
+		"Successful EventAbove unmarshal (short form)": {
+			Text:   "a",
+			Result: EventAbove,
+		},
+		"Successful EventBelow unmarshal (long form)": {
+			Text:   "below",
+			Result: EventBelow,
+		},
+		"Successful EventBelow unmarshal (short form)": {
+			Text:   "b",
+			Result: EventBelow,
+		},
+		"Successful EventCrossUp unmarshal (long form)": {
+			Text:   "cross_up",
+			Result: EventCrossUp,
+		},
+		"Successful EventCrossUp unmarshal (short form)": {
+			Text:   "cu",
+			Result: EventCrossUp,
+		},
+		"Successful EventCrossDown unmarshal (long form)": {
+			Text:   "cross_down",
+			Result: EventCrossDown,
+		},
+		"Successful EventCrossDown unmarshal (short form)": {
+			Text:   "cd",
+			Result: EventCrossDown,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var e Event
+			err := e.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, e)
+		})
+	}
+}
+
+func Test_Constant(t *testing.T) {
+	c := NewConstant(decimal.NewFromInt(30))
+
+	res, err := c.Calc(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "30", res.String())
+
+	resf, err := c.CalcFloat(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, resf)
+
+	assert.Equal(t, 1, c.Count())
+}
+
+func Test_Cross(t *testing.T) {
+	dd := []decimal.Decimal{
+		decimal.NewFromInt(4),
+		decimal.NewFromInt(6),
+		decimal.NewFromInt(6),
+		decimal.NewFromInt(5),
+		decimal.NewFromInt(3),
+		decimal.NewFromInt(7),
+	}
+
+	cc := map[string]struct {
+		A      Indicator
+		B      Indicator
+		Data   []decimal.Decimal
+		Result []Event
+		Error  error
+	}{
+		"Invalid first indicator": {
+			A:     SMA{length: 1},
+			B:     NewConstant(decimal.NewFromInt(5)),
+			Data:  dd,
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid second indicator data size": {
+			A:     SMA{valid: true, length: 1},
+			B:     SMA{valid: true, length: 7},
+			Data:  dd,
+			Error: &DataSizeError{Expected: 7, Actual: 6},
+		},
+		"Successful comparison with constant": {
+			A:    SMA{valid: true, length: 1},
+			B:    NewConstant(decimal.NewFromInt(5)),
+			Data: dd,
+			Result: []Event{
+				EventBelow,
+				EventCrossUp,
+				EventAbove,
+				0,
+				EventCrossDown,
+				EventCrossUp,
+			},
+		},
+		"Successful comparison with indicator": {
+			A:    SMA{valid: true, length: 1},
+			B:    SMA{valid: true, length: 2},
+			Data: dd,
+			Result: []Event{
+				0,
+				EventAbove,
+				0,
+				EventCrossDown,
+				EventBelow,
+				EventCrossUp,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Cross(c.A, c.B, c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Divergence_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Divergence Divergence
+		Err        error
+	}{
+		"Invalid Divergence": {
+			Err: ErrInvalidDivergence,
+		},
+		"Successful DivergenceBullish validation": {
+			Divergence: DivergenceBullish,
+		},
+		"Successful DivergenceBearish validation": {
+			Divergence: DivergenceBearish,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Divergence.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Divergence_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Divergence Divergence
+		Text       string
+		Err        error
+	}{
+		"Invalid Divergence": {
+			Err: ErrInvalidDivergence,
+		},
+		"Successful DivergenceBullish marshal": {
+			Divergence: DivergenceBullish,
+			Text:       "bullish",
+		},
+		"Successful DivergenceBearish marshal": {
+			Divergence: DivergenceBearish,
+			Text:       "bearish",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Divergence.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Divergence_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Divergence
+		Err    error
+	}{
+		"Invalid Divergence": {
+			Err: ErrInvalidDivergence,
+		},
+		"Successful DivergenceBullish unmarshal (long form)": {
+			Text:   "bullish",
+			Result: DivergenceBullish,
+		},
+		"Successful DivergenceBullish unmarshal (short form)": {
+			Text:   "bull",
+			Result: DivergenceBullish,
+		},
+		"Successful DivergenceBearish unmarshal (long form)": {
+			Text:   "bearish",
+			Result: DivergenceBearish,
+		},
+		"Successful DivergenceBearish unmarshal (short form)": {
+			Text:   "bear",
+			Result: DivergenceBearish,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var d Divergence
+			err := d.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, d)
+		})
+	}
+}
+
+func Test_Divergences(t *testing.T) {
+	cc := map[string]struct {
+		Indicator Indicator
+		Data      []int64
+		Lookback  int
+		Result    []Divergence
+		Error     error
+	}{
+		"Invalid lookback": {
+			Indicator: lookup{},
+			Data:      []int64{1},
+			Error: &ValidationError{
+				Indicator:  "divergence",
+				Param:      "lookback",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Invalid data size": {
+			Indicator: SMA{valid: true, length: 3},
+			Data:      []int64{1},
+			Lookback:  1,
+			Error:     &DataSizeError{Expected: 3, Actual: 1},
+		},
+		"Successful bullish divergence": {
+			Indicator: lookup{"5": 20, "4": 30, "10": 50},
+			Data:      []int64{10, 5, 10, 4, 10},
+			Lookback:  1,
+			Result:    []Divergence{0, 0, 0, 0, DivergenceBullish},
+		},
+		"Successful bearish divergence": {
+			Indicator: lookup{"5": 70, "6": 60, "1": 50},
+			Data:      []int64{1, 5, 1, 6, 1, 1},
+			Lookback:  1,
+			Result:    []Divergence{0, 0, 0, 0, DivergenceBearish, 0},
+		},
+		"Confirming pivots are not divergences": {
+			Indicator: lookup{"5": 20, "4": 10, "10": 50},
+			Data:      []int64{10, 5, 10, 10, 4, 10, 10},
+			Lookback:  1,
+			Result:    []Divergence{0, 0, 0, 0, 0, 0, 0},
+		},
+		"Flat pivot is reported once": {
+			Indicator: lookup{"5": 20, "4": 30, "10": 50},
+			Data:      []int64{10, 10, 5, 5, 10, 10, 4, 10, 10},
+			Lookback:  2,
+			Result:    []Divergence{0, 0, 0, 0, 0, 0, 0, 0, DivergenceBullish},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			dd := make([]decimal.Decimal, len(c.Data))
+			for i := range c.Data {
+				dd[i] = decimal.NewFromInt(c.Data[i])
+			}
+
+			res, err := Divergences(c.Indicator, dd, c.Lookback)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
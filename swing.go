@@ -0,0 +1,301 @@
+package indc
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidThreshold is returned when threshold doesn't match any
+	// of the available threshold types.
+	ErrInvalidThreshold = errors.New("invalid threshold")
+)
+
+// Pivot holds a confirmed swing point.
+type Pivot struct {
+	// Index specifies the index of the candle at which the swing point
+	// occurred.
+	Index int `json:"index"`
+
+	// Price specifies the high price of a swing high or the low price of
+	// a swing low.
+	Price decimal.Decimal `json:"price"`
+
+	// Trend specifies TrendUp for swing highs, which end upward moves,
+	// and TrendDown for swing lows, which end downward moves.
+	Trend Trend `json:"trend"`
+}
+
+// Threshold specifies how the reversal amount of ZigZag is measured.
+type Threshold int
+
+// Available threshold types.
+const (
+	// ThresholdPercent specifies reversal amount in percent of the
+	// swing price.
+	ThresholdPercent Threshold = iota + 1
+
+	// ThresholdAbsolute specifies reversal amount in price units.
+	ThresholdAbsolute
+
+	// ThresholdATR specifies reversal amount in multiples of the average
+	// true range.
+	ThresholdATR
+)
+
+// Validate checks whether the threshold is one of
+// supported threshold types or not.
+func (t Threshold) Validate() error {
+	switch t {
+	case ThresholdPercent, ThresholdAbsolute, ThresholdATR:
+		return nil
+	default:
+		return ErrInvalidThreshold
+	}
+}
+
+// MarshalText turns threshold into appropriate string
+// representation.
+func (t Threshold) MarshalText() ([]byte, error) {
+	var v string
+
+	switch t {
+	case ThresholdPercent:
+		v = "percent"
+	case ThresholdAbsolute:
+		v = "absolute"
+	case ThresholdATR:
+		v = "atr"
+	default:
+		return nil, ErrInvalidThreshold
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate threshold value.
+func (t *Threshold) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "percent", "p":
+		*t = ThresholdPercent
+	case "absolute", "a":
+		*t = ThresholdAbsolute
+	case "atr":
+		*t = ThresholdATR
+	default:
+		return ErrInvalidThreshold
+	}
+
+	return nil
+}
+
+// ZigZag holds all the necessary information needed to detect swing
+// points that are separated by price moves of at least the reversal
+// amount.
+// The zero value is not usable.
+type ZigZag struct {
+	// valid specifies whether ZigZag paremeters were validated.
+	valid bool
+
+	// threshold specifies how the reversal amount is measured.
+	threshold Threshold
+
+	// amount specifies the reversal amount.
+	amount decimal.Decimal
+
+	// length specifies how many candles should be used to calculate the
+	// average true range. It is used only by ThresholdATR.
+	length int
+}
+
+// NewZigZag validates provided configuration options and
+// creates new ZigZag indicator. Length is only used by ThresholdATR.
+func NewZigZag(threshold Threshold, amount decimal.Decimal, length int) (ZigZag, error) {
+	zz := ZigZag{
+		threshold: threshold,
+		amount:    amount,
+		length:    length,
+	}
+
+	if err := zz.validate(); err != nil {
+		return ZigZag{}, err
+	}
+
+	return zz, nil
+}
+
+// validate checks whether the indicator has valid configuration
+// properties.
+func (zz *ZigZag) validate() error {
+	if err := zz.threshold.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  "zigzag",
+			Param:      "threshold",
+			Value:      zz.threshold,
+			Constraint: "must be percent, absolute or atr",
+			Err:        err,
+		}
+	}
+
+	if !zz.amount.IsPositive() {
+		return &ValidationError{
+			Indicator:  "zigzag",
+			Param:      "amount",
+			Value:      zz.amount,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidSize,
+		}
+	}
+
+	if zz.threshold == ThresholdATR && zz.length < 1 {
+		return &ValidationError{
+			Indicator:  "zigzag",
+			Param:      "length",
+			Value:      zz.length,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	zz.valid = true
+
+	return nil
+}
+
+// Pivots detects swing points of the candles. A swing high is confirmed
+// once the low price falls from it by at least the reversal amount, and
+// a swing low once the high price rises from it by at least the
+// reversal amount; the pivots therefore alternate between highs and
+// lows. The last, not yet confirmed swing point is not returned.
+// With ThresholdATR, the average true range of the length candles up to
+// the current one is used, so no reversal is confirmed before length
+// candles are available.
+func (zz ZigZag) Pivots(cc []Candle) ([]Pivot, error) {
+	if !zz.valid {
+		return nil, ErrInvalidIndicator
+	}
+
+	if len(cc) == 0 {
+		return nil, nil
+	}
+
+	var (
+		res  []Pivot
+		high = Pivot{Price: cc[0].High, Trend: TrendUp}
+		low  = Pivot{Price: cc[0].Low, Trend: TrendDown}
+		dir  int
+	)
+
+	for i, c := range cc {
+		if dir >= 0 && c.High.GreaterThan(high.Price) {
+			high.Index, high.Price = i, c.High
+		}
+
+		if dir <= 0 && c.Low.LessThan(low.Price) {
+			low.Index, low.Price = i, c.Low
+		}
+
+		amount, ok, err := zz.reversal(cc[:i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		switch dir {
+		case 0:
+			// the first pivot is the older extreme, once the move to
+			// the newer one is large enough.
+			switch {
+			case low.Index < high.Index && high.Price.Sub(low.Price).GreaterThanOrEqual(amount(low.Price)):
+				res, dir = append(res, low), 1
+			case high.Index < low.Index && high.Price.Sub(low.Price).GreaterThanOrEqual(amount(high.Price)):
+				res, dir = append(res, high), -1
+			}
+		case 1:
+			if high.Index < i && high.Price.Sub(c.Low).GreaterThanOrEqual(amount(high.Price)) {
+				res, dir = append(res, high), -1
+				low = Pivot{Index: i, Price: c.Low, Trend: TrendDown}
+			}
+		case -1:
+			if low.Index < i && c.High.Sub(low.Price).GreaterThanOrEqual(amount(low.Price)) {
+				res, dir = append(res, low), 1
+				high = Pivot{Index: i, Price: c.High, Trend: TrendUp}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// reversal returns a function that calculates the reversal amount
+// from a swing price, based on the candles up to the newest one. False
+// is returned when there are not enough candles to calculate the
+// average true range.
+func (zz ZigZag) reversal(cc []Candle) (func(decimal.Decimal) decimal.Decimal, bool, error) {
+	amount := zz.amount
+
+	switch zz.threshold {
+	case ThresholdPercent:
+		return func(p decimal.Decimal) decimal.Decimal {
+			return p.Mul(amount).Div(decimal.NewFromInt(100))
+		}, true, nil
+	case ThresholdATR:
+		if len(cc) < zz.length {
+			return nil, false, nil
+		}
+
+		v, err := atr(cc, zz.length)
+		if err != nil {
+			return nil, false, relabel(err, "zigzag")
+		}
+
+		amount = amount.Mul(v)
+	}
+
+	return func(decimal.Decimal) decimal.Decimal {
+		return amount
+	}, true, nil
+}
+
+// Fractals detects Bill Williams fractals. A fractal high is a candle
+// whose high price is higher than the high prices of n candles on both
+// of its sides and a fractal low is defined analogously with low prices;
+// the classic definition uses n equal to 2. Equal extremes that follow
+// the candle do not invalidate it, so that a flat extreme is reported
+// only once. As a fractal is only confirmed n candles after it occurs,
+// fractals of the newest n candles are not returned.
+// Pivots are ordered by their index; a candle that is both a fractal high
+// and low yields the high first.
+func Fractals(cc []Candle, n int) ([]Pivot, error) {
+	if n < 1 {
+		return nil, &ValidationError{
+			Indicator:  "fractals",
+			Param:      "n",
+			Value:      n,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	var (
+		res []Pivot
+		hh  = Highs(cc)
+		ll  = Lows(cc)
+	)
+
+	for i := n; i+n < len(cc); i++ {
+		if isPivot(hh, i, n, 1) {
+			res = append(res, Pivot{Index: i, Price: hh[i], Trend: TrendUp})
+		}
+
+		if isPivot(ll, i, n, -1) {
+			res = append(res, Pivot{Index: i, Price: ll[i], Trend: TrendDown})
+		}
+	}
+
+	return res, nil
+}
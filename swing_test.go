@@ -0,0 +1,386 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Threshold_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Threshold Threshold
+		Err       error
+	}{
+		"Invalid Threshold": {
+			Err: ErrInvalidThreshold,
+		},
+		"Successful ThresholdPercent validation": {
+			Threshold: ThresholdPercent,
+		},
+		"Successful ThresholdAbsolute validation": {
+			Threshold: ThresholdAbsolute,
+		},
+		"Successful ThresholdATR validation": {
+			Threshold: ThresholdATR,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Threshold.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Threshold_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Threshold Threshold
+		Text      string
+		Err       error
+	}{
+		"Invalid Threshold": {
+			Err: ErrInvalidThreshold,
+		},
+		"Successful ThresholdPercent marshal": {
+			Threshold: ThresholdPercent,
+			Text:      "percent",
+		},
+		"Successful ThresholdAbsolute marshal": {
+			Threshold: ThresholdAbsolute,
+			Text:      "absolute",
+		},
+		"Successful ThresholdATR marshal": {
+			Threshold: ThresholdATR,
+			Text:      "atr",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Threshold.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Threshold_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Threshold
+		Err    error
+	}{
+		"Invalid Threshold": {
+			Err: ErrInvalidThreshold,
+		},
+		"Successful ThresholdPercent unmarshal (long form)": {
+			Text:   "percent",
+			Result: ThresholdPercent,
+		},
+		"Successful ThresholdPercent unmarshal (short form)": {
+			Text:   "p",
+			Result: ThresholdPercent,
+		},
+		"Successful ThresholdAbsolute unmarshal (long form)": {
+			Text:   "absolute",
+			Result: ThresholdAbsolute,
+		},
+		"Successful ThresholdAbsolute unmarshal (short form)": {
+			Text:   "a",
+			Result: ThresholdAbsolute,
+		},
+		"Successful ThresholdATR unmarshal": {
+			Text:   "atr",
+			Result: ThresholdATR,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var th Threshold
+			err := th.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, th)
+		})
+	}
+}
+
+func Test_NewZigZag(t *testing.T) {
+	cc := map[string]struct {
+		Threshold Threshold
+		Amount    decimal.Decimal
+		Length    int
+		Error     error
+	}{
+		"Invalid threshold": {
+			Amount: decimal.NewFromInt(1),
+			Error: &ValidationError{
+				Indicator:  "zigzag",
+				Param:      "threshold",
+				Value:      Threshold(0),
+				Constraint: "must be percent, absolute or atr",
+				Err:        ErrInvalidThreshold,
+			},
+		},
+		"Invalid amount": {
+			Threshold: ThresholdPercent,
+			Amount:    decimal.NewFromInt(-1),
+			Error: &ValidationError{
+				Indicator:  "zigzag",
+				Param:      "amount",
+				Value:      decimal.NewFromInt(-1),
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidSize,
+			},
+		},
+		"Invalid length": {
+			Threshold: ThresholdATR,
+			Amount:    decimal.NewFromInt(1),
+			Error: &ValidationError{
+				Indicator:  "zigzag",
+				Param:      "length",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully created new ZigZag without length": {
+			Threshold: ThresholdAbsolute,
+			Amount:    decimal.NewFromInt(1),
+		},
+		"Successfully created new ZigZag": {
+			Threshold: ThresholdATR,
+			Amount:    decimal.NewFromInt(1),
+			Length:    2,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewZigZag(c.Threshold, c.Amount, c.Length)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Threshold, res.threshold)
+			assert.Equal(t, c.Amount, res.amount)
+			assert.Equal(t, c.Length, res.length)
+		})
+	}
+}
+
+// swings returns candles that rise to 13, fall to 9 and rise to 14.
+func swings() []Candle {
+	return []Candle{
+		bar(1, "9.5", "10", "9", "9.5", "1"),
+		bar(2, "11", "12", "10", "11", "1"),
+		bar(3, "12", "13", "11", "12", "1"),
+		bar(4, "11.25", "12", "10.5", "11.25", "1"),
+		bar(5, "10", "11", "9", "10", "1"),
+		bar(6, "11", "12", "10", "11", "1"),
+		bar(7, "13", "14", "12", "13", "1"),
+		bar(8, "13", "13.5", "12.5", "13", "1"),
+	}
+}
+
+// pivot is a shorthand for creating pivots.
+func pivot(i int, p string, t Trend) Pivot {
+	return Pivot{Index: i, Price: decimal.RequireFromString(p), Trend: t}
+}
+
+func Test_ZigZag_Pivots(t *testing.T) {
+	cc := map[string]struct {
+		Threshold Threshold
+		Amount    string
+		Length    int
+		Candles   []Candle
+		Result    []Pivot
+	}{
+		"Successfully detected no pivots of empty candles": {
+			Threshold: ThresholdAbsolute,
+			Amount:    "2",
+		},
+		"Successfully detected pivots by absolute amount": {
+			Threshold: ThresholdAbsolute,
+			Amount:    "2",
+			Candles:   swings(),
+			Result: []Pivot{
+				pivot(0, "9", TrendDown),
+				pivot(2, "13", TrendUp),
+				pivot(4, "9", TrendDown),
+			},
+		},
+		"Successfully detected pivots by percent": {
+			Threshold: ThresholdPercent,
+			Amount:    "30",
+			Candles:   swings(),
+			Result: []Pivot{
+				pivot(0, "9", TrendDown),
+				pivot(2, "13", TrendUp),
+				pivot(4, "9", TrendDown),
+			},
+		},
+		"Successfully detected pivots by large percent": {
+			Threshold: ThresholdPercent,
+			Amount:    "40",
+			Candles:   swings(),
+			Result: []Pivot{
+				pivot(0, "9", TrendDown),
+			},
+		},
+		"Successfully detected pivots by ATR": {
+			Threshold: ThresholdATR,
+			Amount:    "1.5",
+			Length:    2,
+			Candles:   swings(),
+			Result: []Pivot{
+				pivot(0, "9", TrendDown),
+				pivot(2, "13", TrendUp),
+				pivot(4, "9", TrendDown),
+			},
+		},
+		"Successfully detected pivots by ATR after warmup": {
+			Threshold: ThresholdATR,
+			Amount:    "1",
+			Length:    8,
+			Candles:   swings(),
+			Result: []Pivot{
+				pivot(0, "9", TrendDown),
+			},
+		},
+		"Successfully detected first swing high": {
+			Threshold: ThresholdAbsolute,
+			Amount:    "2",
+			Candles: []Candle{
+				bar(1, "12", "13", "11", "12", "1"),
+				bar(2, "11", "12", "10", "11", "1"),
+				bar(3, "10", "11", "9", "10", "1"),
+			},
+			Result: []Pivot{
+				pivot(0, "13", TrendUp),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			zz, err := NewZigZag(c.Threshold, decimal.RequireFromString(c.Amount), c.Length)
+			assertEqualError(t, nil, err)
+
+			res, err := zz.Pivots(c.Candles)
+			assertEqualError(t, nil, err)
+			assertEqualPivots(t, c.Result, res)
+		})
+	}
+}
+
+func Test_ZigZag_Invalid(t *testing.T) {
+	_, err := ZigZag{}.Pivots(swings())
+	assertEqualError(t, ErrInvalidIndicator, err)
+}
+
+func Test_Fractals(t *testing.T) {
+	cc := map[string]struct {
+		Candles []Candle
+		N       int
+		Result  []Pivot
+		Error   error
+	}{
+		"Invalid n": {
+			Candles: swings(),
+			Error: &ValidationError{
+				Indicator:  "fractals",
+				Param:      "n",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Successfully detected no fractals of too few candles": {
+			Candles: swings()[:4],
+			N:       2,
+		},
+		"Successfully detected fractals": {
+			Candles: swings(),
+			N:       2,
+			Result: []Pivot{
+				pivot(2, "13", TrendUp),
+				pivot(4, "9", TrendDown),
+			},
+		},
+		"Successfully detected fractals of an outside candle": {
+			Candles: []Candle{
+				bar(1, "10", "11", "9", "10", "1"),
+				bar(2, "10", "12", "8", "10", "1"),
+				bar(3, "10", "11", "9", "10", "1"),
+			},
+			N: 1,
+			Result: []Pivot{
+				pivot(1, "12", TrendUp),
+				pivot(1, "8", TrendDown),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Fractals(c.Candles, c.N)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assertEqualPivots(t, c.Result, res)
+		})
+	}
+}
+
+func assertEqualPivots(t *testing.T, exp, res []Pivot) {
+	t.Helper()
+
+	if !assert.Len(t, res, len(exp)) {
+		return
+	}
+
+	for i := range exp {
+		assert.Equal(t, exp[i].Index, res[i].Index, "index %d", i)
+		assert.Equal(t, exp[i].Price.String(), res[i].Price.String(), "price %d", i)
+		assert.Equal(t, exp[i].Trend, res[i].Trend, "trend %d", i)
+	}
+}
@@ -0,0 +1,226 @@
+package indc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrClosedInterval is returned when a candle belongs to an interval
+	// that was already closed.
+	ErrClosedInterval = errors.New("candle belongs to a closed interval")
+)
+
+// Timeframe binds an indicator to candles of a higher interval. Lower
+// interval candles are pushed one by one, aggregated internally by the
+// resampler and the indicator is calculated from close prices of the
+// aggregated candles.
+//
+// Two values are exposed: Closed uses only higher interval candles that
+// have already closed, so it never changes until the next one closes
+// and is free of lookahead bias; Current also includes the candle that
+// is still forming, so it repaints with every pushed candle until that
+// candle closes.
+// The zero value is not usable.
+type Timeframe struct {
+	// valid specifies whether Timeframe paremeters were validated.
+	valid bool
+
+	// ind specifies the indicator calculated on higher interval candles.
+	ind Indicator
+
+	// resampler specifies how lower interval candles are aggregated.
+	resampler Resampler
+
+	// base specifies the duration of pushed candles.
+	base time.Duration
+
+	// closed specifies the newest closed higher interval candles, ordered
+	// from the oldest to the newest.
+	closed []Candle
+
+	// forming specifies the higher interval candle that is not closed
+	// yet.
+	forming Candle
+
+	// isForming specifies whether forming holds a candle.
+	isForming bool
+
+	// last specifies the time of the newest pushed candle.
+	last time.Time
+}
+
+// NewTimeframe validates provided configuration options and creates new
+// Timeframe. Base specifies the duration of pushed candles and allows
+// a higher interval candle to close as soon as its last lower interval
+// candle is pushed. When it is zero, e.g. for ticks, a higher interval
+// candle closes only when a candle of a later interval is pushed.
+func NewTimeframe(ind Indicator, r Resampler, base time.Duration) (*Timeframe, error) {
+	tf := &Timeframe{
+		ind:       ind,
+		resampler: r,
+		base:      base,
+	}
+
+	if err := tf.validate(); err != nil {
+		return nil, err
+	}
+
+	return tf, nil
+}
+
+// validate checks whether the timeframe has valid configuration
+// properties.
+func (tf *Timeframe) validate() error {
+	if tf.ind == nil {
+		return &ValidationError{
+			Indicator:  "timeframe",
+			Param:      "indicator",
+			Value:      tf.ind,
+			Constraint: "must not be nil",
+			Err:        ErrInvalidIndicator,
+		}
+	}
+
+	if !tf.resampler.valid {
+		return &ValidationError{
+			Indicator:  "timeframe",
+			Param:      "resampler",
+			Value:      tf.resampler,
+			Constraint: "must be created by NewResampler",
+			Err:        ErrInvalidIndicator,
+		}
+	}
+
+	if tf.base < 0 {
+		return &ValidationError{
+			Indicator:  "timeframe",
+			Param:      "base",
+			Value:      tf.base,
+			Constraint: "must not be negative",
+			Err:        ErrInvalidInterval,
+		}
+	}
+
+	tf.valid = true
+
+	return nil
+}
+
+// Push adds the lower interval candle. Its time must specify the start
+// of its period. Candles must be pushed from the oldest to the newest.
+func (tf *Timeframe) Push(c Candle) error {
+	if !tf.valid {
+		return ErrInvalidIndicator
+	}
+
+	if c.Time.Before(tf.last) {
+		return ErrUnordered
+	}
+
+	start := tf.resampler.Start(c.Time)
+
+	if len(tf.closed) > 0 && !start.After(tf.closed[len(tf.closed)-1].Time) {
+		return ErrClosedInterval
+	}
+
+	tf.last = c.Time
+	end := c.Time.Add(tf.base)
+
+	if tf.isForming && tf.forming.Time.Equal(start) {
+		tf.forming = Merge(tf.forming, c)
+	} else {
+		if tf.isForming {
+			tf.close()
+		}
+
+		tf.fill(start)
+
+		c.Time = start
+		tf.forming = c
+		tf.isForming = true
+	}
+
+	if tf.base > 0 && !end.Before(tf.resampler.End(start)) {
+		tf.close()
+	}
+
+	return nil
+}
+
+// close moves the forming candle to closed candles.
+func (tf *Timeframe) close() {
+	tf.append(tf.forming)
+	tf.forming = Candle{}
+	tf.isForming = false
+}
+
+// fill appends flat candles for every empty interval before start, if
+// the resampler fills gaps.
+func (tf *Timeframe) fill(start time.Time) {
+	if tf.resampler.gap != GapFill || len(tf.closed) == 0 {
+		return
+	}
+
+	last := tf.closed[len(tf.closed)-1]
+
+	for t := tf.resampler.End(last.Time); t.Before(start); t = tf.resampler.End(t) {
+		tf.append(flat(t, last.Close))
+	}
+}
+
+// append adds the candle to closed candles and drops the ones that are
+// no longer needed by the indicator.
+func (tf *Timeframe) append(c Candle) {
+	tf.closed = append(tf.closed, c)
+
+	if n := tf.ind.Count(); len(tf.closed) > n {
+		tf.closed = append(tf.closed[:0], tf.closed[len(tf.closed)-n:]...)
+	}
+}
+
+// Closed calculates the indicator from closed higher interval candles
+// only. The value does not repaint.
+func (tf *Timeframe) Closed() (decimal.Decimal, error) {
+	if !tf.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	return tf.ind.Calc(Closes(tf.closed))
+}
+
+// Current calculates the indicator from closed higher interval candles
+// and the forming one, if any. The value repaints until the forming
+// candle closes.
+func (tf *Timeframe) Current() (decimal.Decimal, error) {
+	if !tf.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	if !tf.isForming {
+		return tf.Closed()
+	}
+
+	cc := tf.closed
+	if len(cc) == tf.ind.Count() {
+		cc = cc[1:]
+	}
+
+	dd := append(Closes(cc), tf.forming.Close)
+
+	return tf.ind.Calc(dd)
+}
+
+// Forming returns the higher interval candle that is not closed yet.
+// False is returned when there is no such candle.
+func (tf *Timeframe) Forming() (Candle, bool) {
+	return tf.forming, tf.isForming
+}
+
+// Candles returns closed higher interval candles that are kept for the
+// indicator, ordered from the oldest to the newest.
+func (tf *Timeframe) Candles() []Candle {
+	return append([]Candle(nil), tf.closed...)
+}
@@ -0,0 +1,239 @@
+package indc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewTimeframe(t *testing.T) {
+	r, err := NewResampler(time.Hour, Session{}, GapSkip)
+	require.NoError(t, err)
+
+	cc := map[string]struct {
+		Indicator Indicator
+		Resampler Resampler
+		Base      time.Duration
+		Error     error
+	}{
+		"Invalid indicator": {
+			Resampler: r,
+			Error: &ValidationError{
+				Indicator:  "timeframe",
+				Param:      "indicator",
+				Constraint: "must not be nil",
+				Err:        ErrInvalidIndicator,
+			},
+		},
+		"Invalid resampler": {
+			Indicator: SMA{valid: true, length: 2},
+			Error: &ValidationError{
+				Indicator:  "timeframe",
+				Param:      "resampler",
+				Value:      Resampler(Resampler{valid: false, interval: 0, session: Session{Location: (*time.Location)(nil), Start: 0}, gap: 0}),
+				Constraint: "must be created by NewResampler",
+				Err:        ErrInvalidIndicator,
+			},
+		},
+		"Invalid base": {
+			Indicator: SMA{valid: true, length: 2},
+			Resampler: r,
+			Base:      -time.Minute,
+			Error: &ValidationError{
+				Indicator:  "timeframe",
+				Param:      "base",
+				Value:      -time.Minute,
+				Constraint: "must not be negative",
+				Err:        ErrInvalidInterval,
+			},
+		},
+		"Successfully created new Timeframe": {
+			Indicator: SMA{valid: true, length: 2},
+			Resampler: r,
+			Base:      time.Minute,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewTimeframe(c.Indicator, c.Resampler, c.Base)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Indicator, res.ind)
+			assert.Equal(t, c.Resampler, res.resampler)
+			assert.Equal(t, c.Base, res.base)
+		})
+	}
+}
+
+func Test_Timeframe_Push(t *testing.T) {
+	type step struct {
+		Candle  Candle
+		Error   error
+		Closed  string
+		Current string
+		Forming bool
+	}
+
+	bar := func(h, m int, price int64) Candle {
+		d := decimal.NewFromInt(price)
+
+		return Candle{
+			Time:   time.Date(2021, 3, 1, h, m, 0, 0, time.UTC),
+			Open:   d,
+			High:   d,
+			Low:    d,
+			Close:  d,
+			Volume: decimal.NewFromInt(1),
+		}
+	}
+
+	cc := map[string]struct {
+		Gap   Gap
+		Base  time.Duration
+		Steps []step
+	}{
+		"Unordered candle": {
+			Gap:  GapSkip,
+			Base: 30 * time.Minute,
+			Steps: []step{
+				{Candle: bar(10, 0, 1), Forming: true},
+				{Candle: bar(9, 30, 1), Error: ErrUnordered},
+			},
+		},
+		"Candle of a closed interval": {
+			Gap:  GapSkip,
+			Base: time.Hour,
+			Steps: []step{
+				{Candle: bar(10, 0, 1), Closed: "-", Current: "-"},
+				{Candle: bar(10, 30, 1), Error: ErrClosedInterval},
+			},
+		},
+		"Successfully closed candles by base duration": {
+			Gap:  GapSkip,
+			Base: 30 * time.Minute,
+			Steps: []step{
+				{Candle: bar(10, 0, 1), Closed: "-", Current: "-", Forming: true},
+				{Candle: bar(10, 30, 3), Closed: "-", Current: "-"},
+				{Candle: bar(11, 0, 5), Closed: "-", Current: "4", Forming: true},
+				{Candle: bar(11, 30, 7), Closed: "5", Current: "5"},
+				{Candle: bar(12, 0, 9), Closed: "5", Current: "8", Forming: true},
+				{Candle: bar(12, 0, 11), Closed: "5", Current: "9", Forming: true},
+			},
+		},
+		"Successfully closed candles by later candles": {
+			Gap: GapSkip,
+			Steps: []step{
+				{Candle: bar(10, 0, 1), Closed: "-", Current: "-", Forming: true},
+				{Candle: bar(10, 59, 3), Closed: "-", Current: "-", Forming: true},
+				{Candle: bar(11, 0, 5), Closed: "-", Current: "4", Forming: true},
+				{Candle: bar(13, 0, 7), Closed: "4", Current: "6", Forming: true},
+			},
+		},
+		"Successfully filled gaps": {
+			Gap: GapFill,
+			Steps: []step{
+				{Candle: bar(10, 0, 1), Closed: "-", Current: "-", Forming: true},
+				{Candle: bar(12, 0, 3), Closed: "1", Current: "2", Forming: true},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := NewResampler(time.Hour, Session{}, c.Gap)
+			require.NoError(t, err)
+
+			tf, err := NewTimeframe(SMA{valid: true, length: 2}, r, c.Base)
+			require.NoError(t, err)
+
+			for i, s := range c.Steps {
+				err := tf.Push(s.Candle)
+				assertEqualError(t, s.Error, err)
+				if err != nil {
+					return
+				}
+
+				_, forming := tf.Forming()
+				assert.Equal(t, s.Forming, forming, "step %d", i)
+
+				if s.Closed == "" {
+					continue
+				}
+
+				assertValue(t, s.Closed, tf.Closed, i)
+				assertValue(t, s.Current, tf.Current, i)
+				assert.LessOrEqual(t, len(tf.Candles()), 2)
+			}
+		})
+	}
+}
+
+// assertValue checks the value returned by fn; "-" expects
+// ErrInvalidDataSize.
+func assertValue(t *testing.T, exp string, fn func() (decimal.Decimal, error), step int) {
+	t.Helper()
+
+	res, err := fn()
+	if exp == "-" {
+		assert.ErrorIs(t, err, ErrInvalidDataSize, "step %d", step)
+		return
+	}
+
+	require.NoError(t, err, "step %d", step)
+	assert.Equal(t, exp, res.String(), "step %d", step)
+}
+
+func Test_Timeframe_Invalid(t *testing.T) {
+	tf := &Timeframe{}
+
+	assertEqualError(t, ErrInvalidIndicator, tf.Push(Candle{}))
+
+	_, err := tf.Closed()
+	assertEqualError(t, ErrInvalidIndicator, err)
+
+	_, err = tf.Current()
+	assertEqualError(t, ErrInvalidIndicator, err)
+}
+
+func Test_Timeframe_Candles(t *testing.T) {
+	r, err := NewResampler(time.Hour, Session{}, GapSkip)
+	require.NoError(t, err)
+
+	tf, err := NewTimeframe(SMA{valid: true, length: 2}, r, 0)
+	require.NoError(t, err)
+
+	for h := 10; h < 14; h++ {
+		require.NoError(t, tf.Push(Candle{
+			Time:  time.Date(2021, 3, 1, h, 15, 0, 0, time.UTC),
+			Close: decimal.NewFromInt(int64(h)),
+		}))
+	}
+
+	res := tf.Candles()
+	require.Len(t, res, 2)
+	assert.Equal(t, time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC), res[0].Time)
+	assert.Equal(t, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), res[1].Time)
+
+	forming, ok := tf.Forming()
+	assert.True(t, ok)
+	assert.Equal(t, "13", forming.Close.String())
+
+	res[0].Close = decimal.Zero
+	assert.Equal(t, "11", tf.Candles()[0].Close.String())
+}
@@ -2,6 +2,7 @@ package indc
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/shopspring/decimal"
@@ -36,18 +37,116 @@ var (
 	// ErrInvalidMA is returned when ma doesn't match any of the
 	// availabble ma types.
 	ErrInvalidMA = errors.New("invalid moving average")
+
+	// ErrInvalidRounding is returned when rounding mode doesn't match
+	// any of the available rounding modes.
+	ErrInvalidRounding = errors.New("invalid rounding mode")
+
+	// ErrInvalidOrder is returned when order doesn't match any of the
+	// available data point orders.
+	ErrInvalidOrder = errors.New("invalid order")
+
+	// ErrInvalidFactor is returned when incorrect factor is provided.
+	ErrInvalidFactor = errors.New("invalid factor")
+
+	// ErrInvalidUnit is returned when unit doesn't match any of the
+	// available output units.
+	ErrInvalidUnit = errors.New("invalid unit")
+
+	// ErrInvalidParamType is returned when parameter type doesn't match
+	// any of the available parameter types.
+	ErrInvalidParamType = errors.New("invalid parameter type")
+
+	// ErrInvalidZeroPolicy is returned when zero policy doesn't match
+	// any of the available zero policies.
+	ErrInvalidZeroPolicy = errors.New("invalid zero policy")
+
+	// ErrDivisionByZero is returned when a denominator of indicator's
+	// formula is zero and ZeroPolicyError is used.
+	ErrDivisionByZero = errors.New("division by zero")
 )
 
+// ValidationError describes which configuration property of an
+// indicator is invalid and why.
+type ValidationError struct {
+	// Indicator specifies the name of the indicator that failed
+	// validation.
+	Indicator string
+
+	// Param specifies the name of the invalid parameter.
+	Param string
+
+	// Value specifies the offending value.
+	Value interface{}
+
+	// Constraint describes the requirement that the value does not
+	// satisfy.
+	Constraint string
+
+	// Err specifies the sentinel error describing the failure.
+	Err error
+}
+
+// Error returns a human readable description of the validation error.
+func (verr *ValidationError) Error() string {
+	msg := fmt.Sprintf("%v: %s %v %s", verr.Err, verr.Param, verr.Value, verr.Constraint)
+	if verr.Indicator == "" {
+		return msg
+	}
+
+	return verr.Indicator + ": " + msg
+}
+
+// Unwrap returns the sentinel error describing the failure.
+func (verr *ValidationError) Unwrap() error {
+	return verr.Err
+}
+
+// DataSizeError describes how many data points were expected by an
+// indicator and how many were received.
+type DataSizeError struct {
+	// Expected specifies the required amount of data points.
+	Expected int
+
+	// Actual specifies the received amount of data points.
+	Actual int
+}
+
+// Error returns a human readable description of the data size error.
+func (derr *DataSizeError) Error() string {
+	return fmt.Sprintf("%v: expected %d, got %d", ErrInvalidDataSize, derr.Expected, derr.Actual)
+}
+
+// Unwrap returns ErrInvalidDataSize.
+func (derr *DataSizeError) Unwrap() error {
+	return ErrInvalidDataSize
+}
+
+// relabel changes the indicator name of the validation error, so that
+// errors of the nested indicators are reported under the name of the
+// indicator that is being created.
+func relabel(err error, name string) error {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+
+	res := *verr
+	res.Indicator = name
+
+	return &res
+}
+
 // avg is a helper function that calculates average decimal number of
 // given slice.
-func avg(dd []decimal.Decimal) decimal.Decimal {
+func (c config) avg(dd []decimal.Decimal) decimal.Decimal {
 	var sum decimal.Decimal
 
 	for i := range dd {
 		sum = sum.Add(dd[i])
 	}
 
-	return sum.Div(decimal.NewFromInt(int64(len(dd))))
+	return c.div(sum, decimal.NewFromInt(int64(len(dd))))
 }
 
 // sqrt is a helper function that calculated the square root of decimal number.
@@ -56,8 +155,15 @@ func sqrt(d decimal.Decimal) decimal.Decimal {
 	return decimal.NewFromFloat(math.Sqrt(f))
 }
 
+// ln is a helper function that calculates the natural logarithm of
+// decimal number.
+func ln(d decimal.Decimal) decimal.Decimal {
+	f, _ := d.Float64()
+	return decimal.NewFromFloat(math.Log(f))
+}
+
 // mdev calculates mean deviation of given slice.
-func mdev(dd []decimal.Decimal) decimal.Decimal {
+func (c config) mdev(dd []decimal.Decimal) decimal.Decimal {
 	length := decimal.NewFromInt(int64(len(dd)))
 
 	if length.Equal(decimal.Zero) {
@@ -65,17 +171,17 @@ func mdev(dd []decimal.Decimal) decimal.Decimal {
 	}
 
 	res := decimal.Zero
-	mean := avg(dd)
+	mean := c.avg(dd)
 
 	for i := range dd {
-		res = res.Add(dd[i].Sub(mean).Abs().Div(length))
+		res = res.Add(c.div(dd[i].Sub(mean).Abs(), length))
 	}
 
 	return res
 }
 
 // sdev calculates standart deviation of given slice.
-func sdev(dd []decimal.Decimal) decimal.Decimal {
+func (c config) sdev(dd []decimal.Decimal) decimal.Decimal {
 	length := decimal.NewFromInt(int64(len(dd)))
 
 	if length.Equal(decimal.Zero) {
@@ -83,13 +189,36 @@ func sdev(dd []decimal.Decimal) decimal.Decimal {
 	}
 
 	res := decimal.Zero
-	mean := avg(dd)
+	mean := c.avg(dd)
 
 	for i := range dd {
-		res = res.Add(dd[i].Sub(mean).Pow(decimal.NewFromInt(2)).Div(length))
+		res = res.Add(c.div(dd[i].Sub(mean).Pow(decimal.NewFromInt(2)), length))
 	}
 
-	return sqrt(res)
+	return c.round(sqrt(res))
+}
+
+// svar calculates sample variance of given slice, i.e. with n - 1
+// degrees of freedom.
+func (c config) svar(dd []decimal.Decimal) decimal.Decimal {
+	return c.cov(dd, dd)
+}
+
+// cov calculates sample covariance of given slices of the same length,
+// i.e. with n - 1 degrees of freedom.
+func (c config) cov(aa, bb []decimal.Decimal) decimal.Decimal {
+	if len(aa) < 2 {
+		return decimal.Zero
+	}
+
+	res := decimal.Zero
+	ma, mb := c.avg(aa), c.avg(bb)
+
+	for i := range aa {
+		res = res.Add(aa[i].Sub(ma).Mul(bb[i].Sub(mb)))
+	}
+
+	return c.div(res, decimal.NewFromInt(int64(len(aa)-1)))
 }
 
 // Trend specifies which trend should be used.
@@ -212,20 +341,30 @@ const (
 	MATypeWMA
 )
 
+// _maTypeNames holds canonical names of all available moving average
+// types.
+var _maTypeNames = map[MAType]string{
+	MATypeDEMA: "dema",
+	MATypeEMA:  "ema",
+	MATypeHMA:  "hma",
+	MATypeSMA:  "sma",
+	MATypeWMA:  "wma",
+}
+
 // Initialize tries to construct new moving average based on the provided
 // name.
-func (mat MAType) Initialize(length int) (Indicator, error) {
+func (mat MAType) Initialize(length int, opts ...Option) (Indicator, error) {
 	switch mat {
 	case MATypeDEMA:
-		return NewDEMA(length)
+		return NewDEMA(length, opts...)
 	case MATypeEMA:
-		return NewEMA(length)
+		return NewEMA(length, opts...)
 	case MATypeHMA:
-		return NewHMA(length)
+		return NewHMA(length, opts...)
 	case MATypeSMA:
-		return NewSMA(length)
+		return NewSMA(length, opts...)
 	case MATypeWMA:
-		return NewWMA(length)
+		return NewWMA(length, opts...)
 	default:
 		return nil, ErrInvalidMA
 	}
@@ -233,20 +372,8 @@ func (mat MAType) Initialize(length int) (Indicator, error) {
 
 // MarshalText turns MAType into appropriate string representation in JSON.
 func (mat MAType) MarshalText() ([]byte, error) {
-	var v string
-
-	switch mat {
-	case MATypeDEMA:
-		v = "dema"
-	case MATypeEMA:
-		v = "ema"
-	case MATypeHMA:
-		v = "hma"
-	case MATypeSMA:
-		v = "sma"
-	case MATypeWMA:
-		v = "wma"
-	default:
+	v, ok := _maTypeNames[mat]
+	if !ok {
 		return nil, ErrInvalidMA
 	}
 
@@ -255,31 +382,48 @@ func (mat MAType) MarshalText() ([]byte, error) {
 
 // UnmarshalText turns JSON string to appropriate moving average type value.
 func (mat *MAType) UnmarshalText(d []byte) error {
-	switch string(d) {
-	case "dema":
-		*mat = MATypeDEMA
-	case "ema":
-		*mat = MATypeEMA
-	case "hma":
-		*mat = MATypeHMA
-	case "sma":
-		*mat = MATypeSMA
-	case "wma":
-		*mat = MATypeWMA
-	default:
-		return ErrInvalidMA
+	for t, v := range _maTypeNames {
+		if v == string(d) {
+			*mat = t
+			return nil
+		}
 	}
 
-	return nil
+	return ErrInvalidMA
 }
 
 // Indicator is an interface that every indicator should implement.
 type Indicator interface {
 	// Calc should return calculation results based on provided data
-	// points slice.
+	// points slice, ordered from the oldest to the newest.
 	Calc([]decimal.Decimal) (decimal.Decimal, error)
 
 	// Count should determine the total amount data points required for
 	// the calculation.
 	Count() int
 }
+
+// CandleIndicator is an interface that every indicator calculated from
+// whole candles, rather than single data points, should implement.
+type CandleIndicator interface {
+	// CalcCandles should return calculation results based on provided
+	// candles slice, ordered from the oldest to the newest.
+	CalcCandles([]Candle) (decimal.Decimal, error)
+
+	// Count should determine the total amount of candles required for
+	// the calculation.
+	Count() int
+}
+
+// PairIndicator is an interface that every indicator calculated from two
+// data point series, e.g. an asset and a benchmark, should implement.
+type PairIndicator interface {
+	// CalcPair should return calculation results based on provided data
+	// point slices, both ordered from the oldest to the newest and
+	// aligned by their newest data points.
+	CalcPair(aa, bb []decimal.Decimal) (decimal.Decimal, error)
+
+	// Count should determine the total amount of data points of each
+	// series required for the calculation.
+	Count() int
+}
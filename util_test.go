@@ -1,6 +1,8 @@
 package indc
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -23,7 +25,77 @@ func assertEqualError(t *testing.T, exp, err error) {
 
 	assert.NoError(t, err)
 }
-func Test_mdev(t *testing.T) {
+
+func Test_ValidationError_Error(t *testing.T) {
+	verr := &ValidationError{
+		Param:      "length",
+		Value:      0,
+		Constraint: "must be greater than 0",
+		Err:        ErrInvalidLength,
+	}
+
+	assert.Equal(t, "invalid length: length 0 must be greater than 0", verr.Error())
+
+	verr.Indicator = "sma"
+
+	assert.Equal(t, "sma: invalid length: length 0 must be greater than 0", verr.Error())
+}
+
+func Test_ValidationError_Unwrap(t *testing.T) {
+	var err error = &ValidationError{Err: ErrInvalidLength}
+
+	assert.Equal(t, ErrInvalidLength, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, ErrInvalidLength))
+}
+
+func Test_DataSizeError_Error(t *testing.T) {
+	derr := &DataSizeError{Expected: 5, Actual: 3}
+
+	assert.Equal(t, "invalid data size: expected 5, got 3", derr.Error())
+}
+
+func Test_DataSizeError_Unwrap(t *testing.T) {
+	var err error = &DataSizeError{Expected: 5, Actual: 3}
+
+	assert.Equal(t, ErrInvalidDataSize, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, ErrInvalidDataSize))
+}
+
+func Test_errors_wrapping(t *testing.T) {
+	_, err := NewSMA(0)
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	_, err = NewSMA(1, WithPrecision(2, 70))
+	assert.ErrorIs(t, err, ErrInvalidRounding)
+
+	_, err = SMA{valid: true, length: 2}.Calc(ratios("1"))
+	assert.ErrorIs(t, err, ErrInvalidDataSize)
+
+	_, err = Build("macd", nil)
+	assert.ErrorIs(t, err, ErrUnknownIndicator)
+
+	_, err = ReadCandlesCSV(strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrInvalidCandle)
+}
+
+func Test_relabel(t *testing.T) {
+	assert.Equal(t, assert.AnError, relabel(assert.AnError, "bb"))
+
+	verr := &ValidationError{
+		Indicator: "sma",
+		Param:     "length",
+		Err:       ErrInvalidLength,
+	}
+
+	assert.Equal(t, &ValidationError{
+		Indicator: "bb",
+		Param:     "length",
+		Err:       ErrInvalidLength,
+	}, relabel(verr, "bb"))
+	assert.Equal(t, "sma", verr.Indicator)
+}
+
+func Test_config_mdev(t *testing.T) {
 	cc := map[string]struct {
 		Data   []decimal.Decimal
 		Result decimal.Decimal
@@ -54,14 +126,14 @@ func Test_mdev(t *testing.T) {
 		t.Run(cn, func(t *testing.T) {
 			t.Parallel()
 
-			res := mdev(c.Data)
+			res := config{}.mdev(c.Data)
 
 			assert.Equal(t, c.Result.String(), res.String())
 		})
 	}
 }
 
-func Test_sdev(t *testing.T) {
+func Test_config_sdev(t *testing.T) {
 	cc := map[string]struct {
 		Data   []decimal.Decimal
 		Result decimal.Decimal
@@ -94,7 +166,86 @@ func Test_sdev(t *testing.T) {
 		t.Run(cn, func(t *testing.T) {
 			t.Parallel()
 
-			res := sdev(c.Data)
+			res := config{}.sdev(c.Data)
+
+			assert.Equal(t, c.Result.String(), res.String())
+		})
+	}
+}
+
+func Test_config_svar(t *testing.T) {
+	cc := map[string]struct {
+		Data   []decimal.Decimal
+		Result decimal.Decimal
+	}{
+		"Successful calculation with no values": {
+			Data:   []decimal.Decimal{},
+			Result: decimal.NewFromInt(0),
+		},
+		"Successful calculation with one value": {
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(2),
+			},
+			Result: decimal.NewFromInt(0),
+		},
+		"Successful calculation": {
+			Data: []decimal.Decimal{
+				decimal.NewFromInt(600),
+				decimal.NewFromInt(470),
+				decimal.NewFromInt(170),
+				decimal.NewFromInt(430),
+				decimal.NewFromInt(300),
+			},
+			Result: decimal.NewFromInt(27130),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := config{}.svar(c.Data)
+
+			assert.Equal(t, c.Result.String(), res.String())
+		})
+	}
+}
+
+func Test_config_cov(t *testing.T) {
+	cc := map[string]struct {
+		A      []decimal.Decimal
+		B      []decimal.Decimal
+		Result decimal.Decimal
+	}{
+		"Successful calculation with one value": {
+			A:      []decimal.Decimal{decimal.NewFromInt(2)},
+			B:      []decimal.Decimal{decimal.NewFromInt(3)},
+			Result: decimal.NewFromInt(0),
+		},
+		"Successful calculation": {
+			A: []decimal.Decimal{
+				decimal.NewFromInt(1),
+				decimal.NewFromInt(2),
+				decimal.NewFromInt(3),
+			},
+			B: []decimal.Decimal{
+				decimal.NewFromInt(6),
+				decimal.NewFromInt(4),
+				decimal.NewFromInt(2),
+			},
+			Result: decimal.NewFromInt(-2),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := config{}.cov(c.A, c.B)
 
 			assert.Equal(t, c.Result.String(), res.String())
 		})
@@ -513,3 +664,8 @@ func Test_MAType_UnmarshalText(t *testing.T) {
 		})
 	}
 }
+
+func Test_ln(t *testing.T) {
+	assert.Equal(t, "0", ln(decimal.NewFromInt(1)).String())
+	assert.Equal(t, "1", ln(decimal.NewFromFloat(2.718281828459045)).Round(6).String())
+}
@@ -0,0 +1,357 @@
+package indc
+
+import (
+	"errors"
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidEstimator is returned when estimator doesn't match any
+	// of the available volatility estimators.
+	ErrInvalidEstimator = errors.New("invalid estimator")
+)
+
+// Commonly used numbers of periods per year, used to annualize
+// volatility.
+const (
+	// PeriodsTradingDays specifies the number of trading days in a year.
+	PeriodsTradingDays = 252
+
+	// PeriodsCalendarDays specifies the number of days in a year of
+	// markets that trade every day, e.g. crypto.
+	PeriodsCalendarDays = 365
+
+	// PeriodsMinutes specifies the number of minutes in a year of
+	// markets that trade around the clock, e.g. crypto.
+	PeriodsMinutes = 365 * 24 * 60
+)
+
+// Estimator specifies how volatility is estimated.
+type Estimator int
+
+// Available volatility estimators.
+const (
+	// EstimatorClose specifies historical volatility estimated from
+	// close-to-close log returns.
+	EstimatorClose Estimator = iota + 1
+
+	// EstimatorParkinson specifies Parkinson estimator that uses high
+	// and low prices.
+	EstimatorParkinson
+
+	// EstimatorGarmanKlass specifies Garman-Klass estimator that uses
+	// open, high, low and close prices.
+	EstimatorGarmanKlass
+
+	// EstimatorYangZhang specifies Yang-Zhang estimator that uses open,
+	// high, low and close prices as well as overnight jumps from the
+	// previous close.
+	EstimatorYangZhang
+)
+
+// Validate checks whether the estimator is one of
+// supported estimators or not.
+func (e Estimator) Validate() error {
+	switch e {
+	case EstimatorClose, EstimatorParkinson, EstimatorGarmanKlass, EstimatorYangZhang:
+		return nil
+	default:
+		return ErrInvalidEstimator
+	}
+}
+
+// MarshalText turns estimator into appropriate string
+// representation.
+func (e Estimator) MarshalText() ([]byte, error) {
+	var v string
+
+	switch e {
+	case EstimatorClose:
+		v = "close"
+	case EstimatorParkinson:
+		v = "parkinson"
+	case EstimatorGarmanKlass:
+		v = "garman_klass"
+	case EstimatorYangZhang:
+		v = "yang_zhang"
+	default:
+		return nil, ErrInvalidEstimator
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate estimator value.
+func (e *Estimator) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "close", "c":
+		*e = EstimatorClose
+	case "parkinson", "p":
+		*e = EstimatorParkinson
+	case "garman_klass", "gk":
+		*e = EstimatorGarmanKlass
+	case "yang_zhang", "yz":
+		*e = EstimatorYangZhang
+	default:
+		return ErrInvalidEstimator
+	}
+
+	return nil
+}
+
+// Volatility holds all the necessary information needed to calculate
+// annualized volatility.
+// The zero value is not usable.
+type Volatility struct {
+	// valid specifies whether Volatility paremeters were validated.
+	valid bool
+
+	// cfg specifies optional configuration properties.
+	cfg config
+
+	// estimator specifies how volatility is estimated.
+	estimator Estimator
+
+	// length specifies how many periods should be used during the
+	// calculations.
+	length int
+
+	// periods specifies how many periods there are in a year.
+	periods int
+}
+
+// NewVolatility validates provided configuration options and
+// creates new Volatility indicator. Periods specifies how many periods,
+// i.e. candles, there are in a year, e.g. PeriodsTradingDays.
+func NewVolatility(estimator Estimator, length, periods int, opts ...Option) (Volatility, error) {
+	v := Volatility{
+		cfg:       newConfig(opts),
+		estimator: estimator,
+		length:    length,
+		periods:   periods,
+	}
+
+	if err := v.validate(); err != nil {
+		return Volatility{}, err
+	}
+
+	return v, nil
+}
+
+// validate checks whether the indicator has valid configuration properties.
+func (v *Volatility) validate() error {
+	if err := v.cfg.validate("volatility"); err != nil {
+		return err
+	}
+
+	if err := v.estimator.Validate(); err != nil {
+		return &ValidationError{
+			Indicator:  "volatility",
+			Param:      "estimator",
+			Value:      v.estimator,
+			Constraint: "must be a valid estimator",
+			Err:        err,
+		}
+	}
+
+	if v.length < 2 {
+		return &ValidationError{
+			Indicator:  "volatility",
+			Param:      "length",
+			Value:      v.length,
+			Constraint: "must be greater than 1",
+			Err:        ErrInvalidLength,
+		}
+	}
+
+	if v.periods < 1 {
+		return &ValidationError{
+			Indicator:  "volatility",
+			Param:      "periods",
+			Value:      v.periods,
+			Constraint: "must be greater than 0",
+			Err:        ErrInvalidFactor,
+		}
+	}
+
+	v.valid = true
+
+	return nil
+}
+
+// Calc calculates annualized close-to-close volatility from the provided
+// data points slice, so that Volatility can be used wherever Indicator
+// is accepted. Other estimators need whole candles, so
+// ErrInvalidEstimator is returned for them and CalcCandles should be
+// used instead.
+func (v Volatility) Calc(dd []decimal.Decimal) (decimal.Decimal, error) {
+	if !v.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	if v.estimator != EstimatorClose {
+		return decimal.Zero, ErrInvalidEstimator
+	}
+
+	dd, err := window(v.cfg, dd, v.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	for _, d := range dd {
+		if !d.IsPositive() {
+			return v.cfg.divByZero()
+		}
+	}
+
+	return v.cfg.keep(v.annualize(v.closeVariance(dd)))
+}
+
+// CalcCandles calculates annualized volatility from the provided candles
+// slice. The result is in percent, i.e. the per-period standard
+// deviation of log returns multiplied by the square root of periods and
+// by 100. Prices that are not positive are treated as zero denominators.
+//
+// Close-to-close volatility uses the population standard deviation of
+// length log returns. Parkinson and Garman-Klass estimators use length
+// candles and Yang-Zhang estimator uses length candles together with
+// the close of the preceding one, as described in
+// https://portfolioslab.com/tools/yang-zhang.
+func (v Volatility) CalcCandles(cc []Candle) (decimal.Decimal, error) {
+	if !v.valid {
+		return decimal.Zero, ErrInvalidIndicator
+	}
+
+	cc, err := window(v.cfg, cc, v.Count())
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	for _, c := range cc {
+		if !c.Close.IsPositive() || v.estimator != EstimatorClose &&
+			(!c.Open.IsPositive() || !c.High.IsPositive() || !c.Low.IsPositive()) {
+			return v.cfg.divByZero()
+		}
+	}
+
+	var variance decimal.Decimal
+
+	switch v.estimator {
+	case EstimatorClose:
+		variance = v.closeVariance(Closes(cc))
+	case EstimatorParkinson:
+		variance = v.parkinsonVariance(cc)
+	case EstimatorGarmanKlass:
+		variance = v.garmanKlassVariance(cc)
+	case EstimatorYangZhang:
+		variance = v.yangZhangVariance(cc)
+	}
+
+	return v.cfg.keep(v.annualize(variance))
+}
+
+// annualize turns the per-period variance into annualized volatility in
+// percent.
+func (v Volatility) annualize(variance decimal.Decimal) decimal.Decimal {
+	res := sqrt(variance.Mul(decimal.NewFromInt(int64(v.periods)))).Mul(_hundred)
+
+	return v.cfg.round(res)
+}
+
+// logRatio calculates the natural logarithm of a / b.
+func (v Volatility) logRatio(a, b decimal.Decimal) decimal.Decimal {
+	return ln(v.cfg.div(a, b))
+}
+
+// closeVariance calculates the population variance of close-to-close
+// log returns.
+func (v Volatility) closeVariance(dd []decimal.Decimal) decimal.Decimal {
+	rr := make([]decimal.Decimal, len(dd)-1)
+
+	for i := 1; i < len(dd); i++ {
+		rr[i-1] = v.logRatio(dd[i], dd[i-1])
+	}
+
+	var (
+		sum  decimal.Decimal
+		mean = v.cfg.avg(rr)
+	)
+
+	for _, r := range rr {
+		d := r.Sub(mean)
+		sum = sum.Add(d.Mul(d))
+	}
+
+	return v.cfg.div(sum, decimal.NewFromInt(int64(len(rr))))
+}
+
+// parkinsonVariance calculates the variance using Parkinson estimator.
+func (v Volatility) parkinsonVariance(cc []Candle) decimal.Decimal {
+	var sum decimal.Decimal
+
+	for _, c := range cc {
+		hl := v.logRatio(c.High, c.Low)
+		sum = sum.Add(hl.Mul(hl))
+	}
+
+	n := decimal.NewFromInt(int64(len(cc) * 4))
+
+	return v.cfg.div(sum, n.Mul(decimal.NewFromFloat(math.Ln2)))
+}
+
+// garmanKlassVariance calculates the variance using Garman-Klass
+// estimator.
+func (v Volatility) garmanKlassVariance(cc []Candle) decimal.Decimal {
+	var (
+		sum  decimal.Decimal
+		half = decimal.NewFromFloat(0.5)
+		k    = decimal.NewFromFloat(2*math.Ln2 - 1)
+	)
+
+	for _, c := range cc {
+		hl := v.logRatio(c.High, c.Low)
+		co := v.logRatio(c.Close, c.Open)
+		sum = sum.Add(half.Mul(hl).Mul(hl)).Sub(k.Mul(co).Mul(co))
+	}
+
+	return v.cfg.div(sum, decimal.NewFromInt(int64(len(cc))))
+}
+
+// yangZhangVariance calculates the variance using Yang-Zhang estimator.
+// The first candle is only used for its close.
+func (v Volatility) yangZhangVariance(cc []Candle) decimal.Decimal {
+	n := len(cc) - 1
+	oo := make([]decimal.Decimal, n)
+	co := make([]decimal.Decimal, n)
+	rs := make([]decimal.Decimal, n)
+
+	for i := 1; i < len(cc); i++ {
+		c := cc[i]
+		oo[i-1] = v.logRatio(c.Open, cc[i-1].Close)
+		co[i-1] = v.logRatio(c.Close, c.Open)
+		rs[i-1] = v.logRatio(c.High, c.Close).Mul(v.logRatio(c.High, c.Open)).
+			Add(v.logRatio(c.Low, c.Close).Mul(v.logRatio(c.Low, c.Open)))
+	}
+
+	nd := decimal.NewFromInt(int64(n))
+	k := v.cfg.div(
+		decimal.NewFromFloat(0.34),
+		decimal.NewFromFloat(1.34).Add(v.cfg.div(nd.Add(_one), nd.Sub(_one))),
+	)
+
+	return v.cfg.svar(oo).
+		Add(k.Mul(v.cfg.svar(co))).
+		Add(_one.Sub(k).Mul(v.cfg.avg(rs)))
+}
+
+// Count determines the total amount of candles needed for Volatility
+// calculation.
+func (v Volatility) Count() int {
+	if v.estimator == EstimatorClose || v.estimator == EstimatorYangZhang {
+		return v.length + 1
+	}
+
+	return v.length
+}
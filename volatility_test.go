@@ -0,0 +1,389 @@
+package indc
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Estimator_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Estimator Estimator
+		Err       error
+	}{
+		"Invalid Estimator": {
+			Err: ErrInvalidEstimator,
+		},
+		"Successful EstimatorClose validation": {
+			Estimator: EstimatorClose,
+		},
+		"Successful EstimatorParkinson validation": {
+			Estimator: EstimatorParkinson,
+		},
+		"Successful EstimatorGarmanKlass validation": {
+			Estimator: EstimatorGarmanKlass,
+		},
+		"Successful EstimatorYangZhang validation": {
+			Estimator: EstimatorYangZhang,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Estimator.Validate()
+			assertEqualError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Estimator_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Estimator Estimator
+		Text      string
+		Err       error
+	}{
+		"Invalid Estimator": {
+			Err: ErrInvalidEstimator,
+		},
+		"Successful EstimatorClose marshal": {
+			Estimator: EstimatorClose,
+			Text:      "close",
+		},
+		"Successful EstimatorParkinson marshal": {
+			Estimator: EstimatorParkinson,
+			Text:      "parkinson",
+		},
+		"Successful EstimatorGarmanKlass marshal": {
+			Estimator: EstimatorGarmanKlass,
+			Text:      "garman_klass",
+		},
+		"Successful EstimatorYangZhang marshal": {
+			Estimator: EstimatorYangZhang,
+			Text:      "yang_zhang",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Estimator.MarshalText()
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(res))
+		})
+	}
+}
+
+func Test_Estimator_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Estimator
+		Err    error
+	}{
+		"Invalid Estimator": {
+			Err: ErrInvalidEstimator,
+		},
+		"Successful EstimatorClose unmarshal (long form)": {
+			Text:   "close",
+			Result: EstimatorClose,
+		},
+		"Successful EstimatorClose unmarshal (short form)": {
+			Text:   "c",
+			Result: EstimatorClose,
+		},
+		"Successful EstimatorParkinson unmarshal (long form)": {
+			Text:   "parkinson",
+			Result: EstimatorParkinson,
+		},
+		"Successful EstimatorParkinson unmarshal (short form)": {
+			Text:   "p",
+			Result: EstimatorParkinson,
+		},
+		"Successful EstimatorGarmanKlass unmarshal (long form)": {
+			Text:   "garman_klass",
+			Result: EstimatorGarmanKlass,
+		},
+		"Successful EstimatorGarmanKlass unmarshal (short form)": {
+			Text:   "gk",
+			Result: EstimatorGarmanKlass,
+		},
+		"Successful EstimatorYangZhang unmarshal (long form)": {
+			Text:   "yang_zhang",
+			Result: EstimatorYangZhang,
+		},
+		"Successful EstimatorYangZhang unmarshal (short form)": {
+			Text:   "yz",
+			Result: EstimatorYangZhang,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var e Estimator
+			err := e.UnmarshalText([]byte(c.Text))
+			assertEqualError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, e)
+		})
+	}
+}
+
+func Test_NewVolatility(t *testing.T) {
+	cc := map[string]struct {
+		Estimator Estimator
+		Length    int
+		Periods   int
+		Options   []Option
+		Error     error
+	}{
+		"Invalid config": {
+			Estimator: EstimatorClose,
+			Length:    2,
+			Periods:   PeriodsTradingDays,
+			Options:   []Option{WithPrecision(2, 70)},
+			Error: &ValidationError{
+				Indicator:  "volatility",
+				Param:      "rounding",
+				Value:      RoundingMode(70),
+				Constraint: "must be a valid rounding mode",
+				Err:        ErrInvalidRounding,
+			},
+		},
+		"Invalid estimator": {
+			Length:  2,
+			Periods: PeriodsTradingDays,
+			Error: &ValidationError{
+				Indicator:  "volatility",
+				Param:      "estimator",
+				Value:      Estimator(0),
+				Constraint: "must be a valid estimator",
+				Err:        ErrInvalidEstimator,
+			},
+		},
+		"Invalid length": {
+			Estimator: EstimatorParkinson,
+			Length:    1,
+			Periods:   PeriodsTradingDays,
+			Error: &ValidationError{
+				Indicator:  "volatility",
+				Param:      "length",
+				Value:      1,
+				Constraint: "must be greater than 1",
+				Err:        ErrInvalidLength,
+			},
+		},
+		"Invalid periods": {
+			Estimator: EstimatorParkinson,
+			Length:    2,
+			Error: &ValidationError{
+				Indicator:  "volatility",
+				Param:      "periods",
+				Value:      0,
+				Constraint: "must be greater than 0",
+				Err:        ErrInvalidFactor,
+			},
+		},
+		"Successfully created new Volatility": {
+			Estimator: EstimatorYangZhang,
+			Length:    2,
+			Periods:   PeriodsMinutes,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewVolatility(c.Estimator, c.Length, c.Periods, c.Options...)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, res.valid)
+			assert.Equal(t, c.Estimator, res.estimator)
+			assert.Equal(t, c.Length, res.length)
+			assert.Equal(t, c.Periods, res.periods)
+		})
+	}
+}
+
+// ohlcs returns candles used by volatility tests.
+func ohlcs() []Candle {
+	return []Candle{
+		bar(1, "100", "105", "98", "102", "1"),
+		bar(2, "102", "106", "101", "104", "1"),
+		bar(3, "104", "104", "99", "100", "1"),
+		bar(4, "100", "103", "97", "101", "1"),
+	}
+}
+
+func Test_Volatility_Calc(t *testing.T) {
+	cc := map[string]struct {
+		Volatility Volatility
+		Data       []decimal.Decimal
+		Result     float64
+		Error      error
+	}{
+		"Invalid indicator": {
+			Data:  ratios("102", "104", "100", "101"),
+			Error: ErrInvalidIndicator,
+		},
+		"Invalid estimator": {
+			Volatility: Volatility{valid: true, estimator: EstimatorParkinson, length: 3, periods: 252},
+			Data:       ratios("102", "104", "100"),
+			Error:      ErrInvalidEstimator,
+		},
+		"Invalid data size": {
+			Volatility: Volatility{valid: true, estimator: EstimatorClose, length: 3, periods: 252},
+			Data:       ratios("104", "100", "101"),
+			Error:      &DataSizeError{Expected: 4, Actual: 3},
+		},
+		"Division by zero": {
+			Volatility: Volatility{
+				valid:     true,
+				cfg:       config{zero: ZeroPolicyError},
+				estimator: EstimatorClose,
+				length:    3,
+				periods:   252,
+			},
+			Data:  ratios("102", "0", "100", "101"),
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated close-to-close volatility": {
+			Volatility: Volatility{valid: true, estimator: EstimatorClose, length: 3, periods: 252},
+			Data:       ratios("102", "104", "100", "101"),
+			Result:     40.80273075182654,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Volatility.Calc(c.Data)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Volatility_CalcCandles(t *testing.T) {
+	cc := map[string]struct {
+		Volatility Volatility
+		Candles    []Candle
+		Result     float64
+		Error      error
+	}{
+		"Invalid indicator": {
+			Candles: ohlcs(),
+			Error:   ErrInvalidIndicator,
+		},
+		"Invalid data size": {
+			Volatility: Volatility{valid: true, estimator: EstimatorClose, length: 3, periods: 252},
+			Candles:    ohlcs()[1:],
+			Error:      &DataSizeError{Expected: 4, Actual: 3},
+		},
+		"Division by zero": {
+			Volatility: Volatility{
+				valid:     true,
+				cfg:       config{zero: ZeroPolicyError},
+				estimator: EstimatorParkinson,
+				length:    2,
+				periods:   252,
+			},
+			Candles: []Candle{
+				bar(1, "100", "105", "98", "102", "1"),
+				bar(2, "0", "106", "101", "104", "1"),
+			},
+			Error: ErrDivisionByZero,
+		},
+		"Successfully calculated close-to-close volatility of flat candles": {
+			Volatility: Volatility{valid: true, estimator: EstimatorClose, length: 3, periods: 252},
+			Candles:    closes("102", "104", "100", "101"),
+			Result:     40.80273075182654,
+		},
+		"Successfully calculated close-to-close volatility": {
+			Volatility: Volatility{valid: true, estimator: EstimatorClose, length: 3, periods: 252},
+			Candles:    ohlcs(),
+			Result:     40.80273075182654,
+		},
+		"Successfully calculated Parkinson volatility": {
+			Volatility: Volatility{valid: true, estimator: EstimatorParkinson, length: 3, periods: 252},
+			Candles:    ohlcs()[1:],
+			Result:     50.34037654371624,
+		},
+		"Successfully calculated Garman-Klass volatility": {
+			Volatility: Volatility{valid: true, estimator: EstimatorGarmanKlass, length: 3, periods: 252},
+			Candles:    ohlcs()[1:],
+			Result:     53.47388627803888,
+		},
+		"Successfully calculated Yang-Zhang volatility": {
+			Volatility: Volatility{valid: true, estimator: EstimatorYangZhang, length: 3, periods: 252},
+			Candles:    ohlcs(),
+			Result:     52.57569100275477,
+		},
+		"Successfully calculated volatility with lenient window": {
+			Volatility: Volatility{
+				valid:     true,
+				cfg:       config{lenient: true},
+				estimator: EstimatorParkinson,
+				length:    3,
+				periods:   252,
+			},
+			Candles: ohlcs(),
+			Result:  50.34037654371624,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Volatility.CalcCandles(c.Candles)
+			assertEqualError(t, c.Error, err)
+			if err != nil {
+				return
+			}
+
+			f, _ := res.Float64()
+			assert.InDelta(t, c.Result, f, 1e-6)
+		})
+	}
+}
+
+func Test_Volatility_Count(t *testing.T) {
+	assert.Equal(t, 4, Volatility{estimator: EstimatorClose, length: 3}.Count())
+	assert.Equal(t, 3, Volatility{estimator: EstimatorParkinson, length: 3}.Count())
+	assert.Equal(t, 3, Volatility{estimator: EstimatorGarmanKlass, length: 3}.Count())
+	assert.Equal(t, 4, Volatility{estimator: EstimatorYangZhang, length: 3}.Count())
+}